@@ -0,0 +1,16 @@
+package api
+
+import "context"
+
+type readPrimaryKey struct{}
+
+// WithReadPrimary return context that force repository to read from primary database instead of replica, used when request need to read data it just wrote
+func WithReadPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readPrimaryKey{}, true)
+}
+
+// IsReadPrimary report whether ctx force reading from primary database
+func IsReadPrimary(ctx context.Context) bool {
+	forced, _ := ctx.Value(readPrimaryKey{}).(bool)
+	return forced
+}
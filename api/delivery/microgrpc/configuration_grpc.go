@@ -2,6 +2,7 @@ package microgrpc
 
 import (
 	"context"
+	"time"
 
 	"github.com/muhammadhidayah/configuration-service/api"
 	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
@@ -22,7 +23,7 @@ func NewMicroGrpc(ucase api.Usecase) *microgrpc {
 func (micro *microgrpc) GetConfigurationClient(ctx context.Context, req *pb.RequestConfigCient, res *pb.ResponseConfigClient) error {
 	resp, err := micro.uscase.GetConfigurationClient(ctx)
 	if err != nil {
-		return err
+		return microError(err)
 	}
 
 	res.Configclients = resp.GetConfigclients()
@@ -32,9 +33,27 @@ func (micro *microgrpc) GetConfigurationClient(ctx context.Context, req *pb.Requ
 func (micro *microgrpc) GetConfigurationClientBySubs(ctx context.Context, req *pb.RequestConfigCient, res *pb.ResponseConfigClient) error {
 	companySubsID := req.Configclient.CompanySubsId
 
-	resp, err := micro.uscase.GetConfigurationClientBySubs(ctx, companySubsID)
+	var resp *pb.ResponseConfigClient
+	var err error
+
+	// long polling, caller is held until its configuration changed
+	if req.GetMaxWaitMs() > 0 && (req.GetContentHash() != "" || req.GetRevision() != 0) {
+		resp, err = micro.uscase.WaitConfigurationClientBySubs(ctx, companySubsID, req.GetContentHash(), req.GetRevision(), time.Duration(req.GetMaxWaitMs())*time.Millisecond)
+	} else {
+		resp, err = micro.uscase.GetConfigurationClientBySubs(ctx, companySubsID)
+	}
+
 	if err != nil {
-		return err
+		return microError(err)
+	}
+
+	res.ContentHash = resp.GetContentHash()
+	res.Revision = resp.GetRevision()
+
+	// caller already has the same configuration, so configclient is not sent again
+	if clientNotModified(req, res) {
+		res.NotModified = true
+		return nil
 	}
 
 	res.Configclient = resp.Configclient
@@ -46,8 +65,15 @@ func (micro *microgrpc) AddConfigurationClient(ctx context.Context, req *pb.Requ
 	configClient := req.Configclient
 
 	resp, err := micro.uscase.AddConfigurationClient(ctx, configClient)
+	if len(resp.GetViolations()) > 0 {
+		// violations is sent in response, because response is not sent when error is returned
+		res.Status = resp.GetStatus()
+		res.Violations = resp.GetViolations()
+		return nil
+	}
+
 	if err != nil {
-		return err
+		return microError(err)
 	}
 
 	res.Status = resp.GetStatus()
@@ -60,8 +86,14 @@ func (micro *microgrpc) UpdateConfigurationClientBySubs(ctx context.Context, req
 	configClient := req.Configclient
 
 	resp, err := micro.uscase.UpdateConfigurationClientBySubs(ctx, configClient)
+	if len(resp.GetViolations()) > 0 {
+		res.Status = resp.GetStatus()
+		res.Violations = resp.GetViolations()
+		return nil
+	}
+
 	if err != nil {
-		return err
+		return microError(err)
 	}
 
 	res.Status = resp.GetStatus()
@@ -69,12 +101,30 @@ func (micro *microgrpc) UpdateConfigurationClientBySubs(ctx context.Context, req
 	return nil
 }
 
+func (micro *microgrpc) UpsertConfigurationClientBySubs(ctx context.Context, req *pb.RequestConfigCient, res *pb.ResponseConfigClient) error {
+	resp, err := micro.uscase.UpsertConfigurationClientBySubs(ctx, req.GetConfigclient())
+	if len(resp.GetViolations()) > 0 {
+		res.Status = resp.GetStatus()
+		res.Violations = resp.GetViolations()
+		return nil
+	}
+
+	if err != nil {
+		return microError(err)
+	}
+
+	res.Status = resp.GetStatus()
+	res.Configclient = resp.GetConfigclient()
+
+	return nil
+}
+
 func (micro *microgrpc) DeleteConfigurationClientBySubs(ctx context.Context, req *pb.RequestConfigCient, res *pb.ResponseConfigClient) error {
 	configClient := req.Configclient
 
 	resp, err := micro.uscase.DeleteConfigurationClientBySubs(ctx, configClient)
 	if err != nil {
-		return err
+		return microError(err)
 	}
 
 	res.Status = resp.GetStatus()
@@ -86,12 +136,19 @@ func (micro *microgrpc) AddConfigurationGlobal(ctx context.Context, req *pb.Requ
 	configGlobal := req.Configglobal
 
 	resp, err := micro.uscase.AddConfigurationGlobal(ctx, configGlobal)
+	if len(resp.GetViolations()) > 0 {
+		res.Configstatus = resp.GetConfigstatus()
+		res.Violations = resp.GetViolations()
+		return nil
+	}
+
 	if err != nil {
-		return err
+		return microError(err)
 	}
 
 	res.Configstatus = resp.GetConfigstatus()
 	res.Configglobal = configGlobal
+	res.Findings = resp.GetFindings()
 
 	return nil
 }
@@ -100,11 +157,18 @@ func (micro *microgrpc) UpdateConfigurationGlobal(ctx context.Context, req *pb.R
 	configGlobal := req.Configglobal
 
 	resp, err := micro.uscase.UpdateConfigurationGlobal(ctx, configGlobal)
+	if len(resp.GetViolations()) > 0 {
+		res.Configstatus = resp.GetConfigstatus()
+		res.Violations = resp.GetViolations()
+		return nil
+	}
+
 	if err != nil {
-		return err
+		return microError(err)
 	}
 
 	res.Configstatus = resp.GetConfigstatus()
+	res.Findings = resp.GetFindings()
 	return nil
 }
 
@@ -113,7 +177,7 @@ func (micro *microgrpc) DeleteConfiguration(ctx context.Context, req *pb.Request
 
 	resp, err := micro.uscase.DeleteConfiguration(ctx, configGlobalID)
 	if err != nil {
-		return err
+		return microError(err)
 	}
 
 	res.Configstatus = resp.GetConfigstatus()
@@ -124,7 +188,7 @@ func (micro *microgrpc) DeleteConfiguration(ctx context.Context, req *pb.Request
 func (micro *microgrpc) GetConfigurationGlobal(ctx context.Context, req *pb.RequestConfigGlobal, res *pb.ResponseConfigGlobal) error {
 	resp, err := micro.uscase.GetConfigurationGlobal(ctx)
 	if err != nil {
-		return err
+		return microError(err)
 	}
 
 	res.Configglobals = resp.GetConfigglobals()
@@ -136,7 +200,7 @@ func (micro *microgrpc) GetConfigurationGlobalByID(ctx context.Context, req *pb.
 
 	resp, err := micro.uscase.GetConfigurationGlobalByID(ctx, configGlobalID)
 	if err != nil {
-		return err
+		return microError(err)
 	}
 
 	res.Configglobal = resp.GetConfigglobal()
@@ -146,7 +210,15 @@ func (micro *microgrpc) GetConfigurationGlobalByID(ctx context.Context, req *pb.
 func (micro *microgrpc) GetConfigurationGlobalActive(ctx context.Context, req *pb.RequestConfigGlobal, res *pb.ResponseConfigGlobal) error {
 	resp, err := micro.uscase.GetConfigurationGlobalActive(ctx)
 	if err != nil {
-		return err
+		return microError(err)
+	}
+
+	res.ContentHash = resp.GetContentHash()
+
+	// caller already has the same configuration, so configglobal is not sent again
+	if notModified(req.GetContentHash(), res.ContentHash) {
+		res.NotModified = true
+		return nil
 	}
 
 	res.Configglobal = resp.GetConfigglobal()
@@ -156,12 +228,46 @@ func (micro *microgrpc) GetConfigurationGlobalActive(ctx context.Context, req *p
 func (micro *microgrpc) SetConfigurationGlobalActive(ctx context.Context, req *pb.RequestConfigGlobal, res *pb.ResponseConfigGlobal) error {
 	configGlobal := req.GetConfigglobal()
 
-	resp, err := micro.uscase.SetConfigurationGlobalActive(ctx, configGlobal)
+	resp, err := micro.uscase.SetConfigurationGlobalActive(ctx, configGlobal, req.GetOverrideLint())
+	if err != nil && len(resp.GetFindings()) > 0 {
+		// activation refused by lint, findings is sent in detail of the error to show why
+		return microErrorDetail(err, resp.GetFindings())
+	}
+
 	if err != nil {
-		res.Configstatus.Updated = false
-		return err
+		res.Configstatus = &pb.ConfigurationStatus{Updated: false}
+		return microError(err)
 	}
 
 	res.Configstatus = resp.GetConfigstatus()
+	res.Configglobal = resp.GetConfigglobal()
+	res.Findings = resp.GetFindings()
 	return nil
 }
+
+// stream is closed when usecase stop watching, because client cancelled or sending failed
+func (micro *microgrpc) WatchConfigurationClient(ctx context.Context, req *pb.RequestConfigCient, stream pb.ConfigurationService_WatchConfigurationClientStream) error {
+	defer stream.Close()
+
+	return microError(micro.uscase.WatchConfigurationClient(ctx, req.GetConfigclient().GetCompanySubsId(), stream.Send))
+}
+
+func (micro *microgrpc) WatchConfigurationGlobalActive(ctx context.Context, req *pb.RequestConfigGlobal, stream pb.ConfigurationService_WatchConfigurationGlobalActiveStream) error {
+	defer stream.Close()
+
+	return microError(micro.uscase.WatchConfigurationGlobalActive(ctx, stream.Send))
+}
+
+// notModified is true when caller send hash, and it is equal with hash of the current configuration
+func notModified(requestHash, currentHash string) bool {
+	return requestHash != "" && requestHash == currentHash
+}
+
+// clientNotModified return true when caller sent content hash or revision, and all of them is equal with the current one
+func clientNotModified(req *pb.RequestConfigCient, res *pb.ResponseConfigClient) bool {
+	if req.GetContentHash() == "" && req.GetRevision() == 0 {
+		return false
+	}
+
+	return (req.GetContentHash() == "" || req.GetContentHash() == res.GetContentHash()) && (req.GetRevision() == 0 || req.GetRevision() == res.GetRevision())
+}
@@ -2,9 +2,13 @@ package microgrpc_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
+	microerrors "github.com/micro/go-micro/errors"
+	"github.com/muhammadhidayah/configuration-service/api"
 	micro "github.com/muhammadhidayah/configuration-service/api/delivery/microgrpc"
 	"github.com/muhammadhidayah/configuration-service/api/mocks"
 	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
@@ -102,6 +106,55 @@ func TestGetConfigurationClientBySubs(t *testing.T) {
 
 		assert.Error(t, err)
 	})
+	t.Run("Get Configuration Client By Company Subs ID with the same content hash", func(t *testing.T) {
+		mockUseCaseConf.On("GetConfigurationClientBySubs", mock.Anything, "012-031-234-542").Return(&pb.ResponseConfigClient{Configclient: mockRespConfigClient.Configclients[0], ContentHash: "abc"}, nil).Once()
+
+		handler := micro.NewMicroGrpc(mockUseCaseConf)
+		res := &pb.ResponseConfigClient{}
+		err := handler.GetConfigurationClientBySubs(context.TODO(), &pb.RequestConfigCient{Configclient: mockReqConfigClient.Configclient, ContentHash: "abc"}, res)
+
+		assert.NoError(t, err)
+		assert.True(t, res.NotModified)
+		assert.Nil(t, res.Configclient)
+		mockUseCaseConf.AssertExpectations(t)
+	})
+	t.Run("Get Configuration Client By Company Subs ID with max wait is long polling", func(t *testing.T) {
+		mockUseCaseConf.On("WaitConfigurationClientBySubs", mock.Anything, "012-031-234-542", "abc", int64(0), 1500*time.Millisecond).Return(&pb.ResponseConfigClient{Configclient: mockRespConfigClient.Configclients[0], ContentHash: "abc"}, nil).Once()
+
+		handler := micro.NewMicroGrpc(mockUseCaseConf)
+		res := &pb.ResponseConfigClient{}
+		err := handler.GetConfigurationClientBySubs(context.TODO(), &pb.RequestConfigCient{Configclient: mockReqConfigClient.Configclient, ContentHash: "abc", MaxWaitMs: 1500}, res)
+
+		// nothing changed while waiting
+		assert.NoError(t, err)
+		assert.True(t, res.NotModified)
+		mockUseCaseConf.AssertExpectations(t)
+	})
+	t.Run("Get Configuration Client By Company Subs ID with revision is long polling", func(t *testing.T) {
+		mockUseCaseConf.On("WaitConfigurationClientBySubs", mock.Anything, "012-031-234-542", "", int64(7), 1500*time.Millisecond).Return(&pb.ResponseConfigClient{Configclient: mockRespConfigClient.Configclients[0], ContentHash: "abc", Revision: 7}, nil).Once()
+
+		handler := micro.NewMicroGrpc(mockUseCaseConf)
+		res := &pb.ResponseConfigClient{}
+		err := handler.GetConfigurationClientBySubs(context.TODO(), &pb.RequestConfigCient{Configclient: mockReqConfigClient.Configclient, Revision: 7, MaxWaitMs: 1500}, res)
+
+		assert.NoError(t, err)
+		assert.True(t, res.NotModified)
+		assert.Equal(t, int64(7), res.Revision)
+		assert.Equal(t, "abc", res.ContentHash)
+		mockUseCaseConf.AssertExpectations(t)
+	})
+	t.Run("Get Configuration Client By Company Subs ID with old revision", func(t *testing.T) {
+		mockUseCaseConf.On("GetConfigurationClientBySubs", mock.Anything, "012-031-234-542").Return(&pb.ResponseConfigClient{Configclient: mockRespConfigClient.Configclients[0], ContentHash: "abc", Revision: 8}, nil).Once()
+
+		handler := micro.NewMicroGrpc(mockUseCaseConf)
+		res := &pb.ResponseConfigClient{}
+		err := handler.GetConfigurationClientBySubs(context.TODO(), &pb.RequestConfigCient{Configclient: mockReqConfigClient.Configclient, ContentHash: "abc", Revision: 7}, res)
+
+		assert.NoError(t, err)
+		assert.False(t, res.NotModified)
+		assert.NotNil(t, res.Configclient)
+		mockUseCaseConf.AssertExpectations(t)
+	})
 }
 
 func TestAddConfigurationClient(t *testing.T) {
@@ -188,6 +241,48 @@ func TestUpdateConfigurationClientBySubs(t *testing.T) {
 	})
 }
 
+func TestUpsertConfigurationClientBySubs(t *testing.T) {
+	mockUseCaseConf := new(mocks.Usecase)
+	mockReqConfigClient := &pb.RequestConfigCient{
+		Configclient: &pb.ConfigurationClient{
+			MultipleLanguageId: 2,
+			Appname:            "client1.inactsoft.com",
+			ReportTitle:        "Client Satu",
+			CompanySubsId:      "012-031-234-542",
+		},
+	}
+
+	t.Run("Upsert configuration client", func(t *testing.T) {
+		mockRespConfigClient := &pb.ResponseConfigClient{
+			Status:       &pb.ConfigurationStatus{Created: true},
+			Configclient: mockReqConfigClient.Configclient,
+		}
+		mockUseCaseConf.On("UpsertConfigurationClientBySubs", mock.Anything, mock.AnythingOfType("*configuration.ConfigurationClient")).Return(mockRespConfigClient, nil).Once()
+
+		res := &pb.ResponseConfigClient{}
+		handler := micro.NewMicroGrpc(mockUseCaseConf)
+		err := handler.UpsertConfigurationClientBySubs(context.TODO(), mockReqConfigClient, res)
+
+		assert.NoError(t, err)
+		assert.True(t, res.Status.Created)
+		assert.Equal(t, "012-031-234-542", res.Configclient.CompanySubsId)
+	})
+
+	t.Run("Failed upsert configuration client", func(t *testing.T) {
+		mockRespConfigClient := &pb.ResponseConfigClient{Status: &pb.ConfigurationStatus{}}
+		mockUseCaseConf.On("UpsertConfigurationClientBySubs", mock.Anything, mock.AnythingOfType("*configuration.ConfigurationClient")).Return(mockRespConfigClient, errors.New("Unexpected Error")).Once()
+
+		res := &pb.ResponseConfigClient{}
+		handler := micro.NewMicroGrpc(mockUseCaseConf)
+		err := handler.UpsertConfigurationClientBySubs(context.TODO(), mockReqConfigClient, res)
+
+		assert.Error(t, err)
+		assert.Nil(t, res.Status)
+	})
+
+	mockUseCaseConf.AssertExpectations(t)
+}
+
 func TestDeleteConfigurationClientBySubs(t *testing.T) {
 	mockUseCaseConf := new(mocks.Usecase)
 	mockRespConfigClient := &pb.ResponseConfigClient{
@@ -273,6 +368,23 @@ func TestAddConfigurationGlobal(t *testing.T) {
 		assert.Error(t, err)
 		assert.False(t, mockRespConfGlobalRes.Configstatus.GetCreated())
 	})
+
+	t.Run("Invalid Configuration Global return violations", func(t *testing.T) {
+		violations := []*pb.FieldViolation{{Field: "port", Description: "must be between 1 and 65535"}}
+		mockUseCaseConf.On("AddConfigurationGlobal", mock.Anything, mock.AnythingOfType("*configuration.ConfigurationGlobal")).Return(&pb.ResponseConfigGlobal{
+			Configstatus: &pb.ConfigurationStatus{Created: false},
+			Violations:   violations,
+		}, api.Errorf(api.ErrInvalidArgument, "Configuration is not valid, port: must be between 1 and 65535")).Once()
+
+		res := &pb.ResponseConfigGlobal{}
+		handler := micro.NewMicroGrpc(mockUseCaseConf)
+		err := handler.AddConfigurationGlobal(context.TODO(), mockReqConfGlobal, res)
+
+		assert.NoError(t, err)
+		assert.False(t, res.Configstatus.GetCreated())
+		assert.Equal(t, violations, res.Violations)
+		assert.Nil(t, res.Configglobal)
+	})
 }
 
 func TestUpdateConfigurationGlobal(t *testing.T) {
@@ -499,6 +611,27 @@ func TestGetConfigurationGlobalActive(t *testing.T) {
 
 		assert.Error(t, err)
 	})
+	t.Run("Get Configuration Global Active with the same content hash", func(t *testing.T) {
+		mockUseCaseConf.On("GetConfigurationGlobalActive", mock.Anything).Return(&pb.ResponseConfigGlobal{Configglobal: mockRespConfGlobal.Configglobal, ContentHash: "abc"}, nil).Twice()
+
+		handler := micro.NewMicroGrpc(mockUseCaseConf)
+
+		// caller has the same configuration, configuration is not sent
+		res := &pb.ResponseConfigGlobal{}
+		err := handler.GetConfigurationGlobalActive(context.TODO(), &pb.RequestConfigGlobal{ContentHash: "abc"}, res)
+		assert.NoError(t, err)
+		assert.True(t, res.NotModified)
+		assert.Equal(t, "abc", res.ContentHash)
+		assert.Nil(t, res.Configglobal)
+
+		// caller has old configuration
+		res = &pb.ResponseConfigGlobal{}
+		err = handler.GetConfigurationGlobalActive(context.TODO(), &pb.RequestConfigGlobal{ContentHash: "old"}, res)
+		assert.NoError(t, err)
+		assert.False(t, res.NotModified)
+		assert.Equal(t, "abc", res.ContentHash)
+		assert.NotNil(t, res.Configglobal)
+	})
 }
 
 func TestSetConfigurationGlobalActive(t *testing.T) {
@@ -514,7 +647,7 @@ func TestSetConfigurationGlobalActive(t *testing.T) {
 	mockRespConfGlobalRes := &pb.ResponseConfigGlobal{}
 
 	t.Run("Set Configuration Global Active", func(t *testing.T) {
-		mockUseCaseConf.On("SetConfigurationGlobalActive", mock.Anything, mock.AnythingOfType("*configuration.ConfigurationGlobal")).Return(mockRespConfGlobal, nil).Once()
+		mockUseCaseConf.On("SetConfigurationGlobalActive", mock.Anything, mock.AnythingOfType("*configuration.ConfigurationGlobal"), false).Return(mockRespConfGlobal, nil).Once()
 
 		handler := micro.NewMicroGrpc(mockUseCaseConf)
 		err := handler.SetConfigurationGlobalActive(context.TODO(), mockReqConfGlobal, mockRespConfGlobalRes)
@@ -524,7 +657,7 @@ func TestSetConfigurationGlobalActive(t *testing.T) {
 	})
 
 	t.Run("Set Configuration Global Active", func(t *testing.T) {
-		mockUseCaseConf.On("SetConfigurationGlobalActive", mock.Anything, mock.AnythingOfType("*configuration.ConfigurationGlobal")).Return(nil, errors.New("Unexpected Error")).Once()
+		mockUseCaseConf.On("SetConfigurationGlobalActive", mock.Anything, mock.AnythingOfType("*configuration.ConfigurationGlobal"), false).Return(nil, errors.New("Unexpected Error")).Once()
 
 		handler := micro.NewMicroGrpc(mockUseCaseConf)
 		err := handler.SetConfigurationGlobalActive(context.TODO(), mockReqConfGlobal, mockRespConfGlobalRes)
@@ -532,4 +665,122 @@ func TestSetConfigurationGlobalActive(t *testing.T) {
 		assert.Error(t, err)
 		assert.False(t, mockRespConfGlobalRes.Configstatus.GetUpdated())
 	})
+
+	t.Run("Set Configuration Global Active, error with empty response", func(t *testing.T) {
+		mockUseCaseConf.On("SetConfigurationGlobalActive", mock.Anything, mock.AnythingOfType("*configuration.ConfigurationGlobal"), false).Return(nil, errors.New("No Data to Activate")).Once()
+
+		res := &pb.ResponseConfigGlobal{}
+
+		handler := micro.NewMicroGrpc(mockUseCaseConf)
+		err := handler.SetConfigurationGlobalActive(context.TODO(), mockReqConfGlobal, res)
+
+		assert.Error(t, err)
+		assert.False(t, res.Configstatus.GetUpdated())
+	})
+
+	t.Run("Set Configuration Global Active refused by lint return findings", func(t *testing.T) {
+		findings := []*pb.LintFinding{{Rule: "private-server", Field: "server_smpt", Severity: "error"}}
+		mockUseCaseConf.On("SetConfigurationGlobalActive", mock.Anything, mock.AnythingOfType("*configuration.ConfigurationGlobal"), false).Return(&pb.ResponseConfigGlobal{
+			Configstatus: &pb.ConfigurationStatus{Updated: false},
+			Findings:     findings,
+		}, api.Errorf(api.ErrConflict, "Configuration global has error finding of lint")).Once()
+
+		res := &pb.ResponseConfigGlobal{}
+		handler := micro.NewMicroGrpc(mockUseCaseConf)
+		err := handler.SetConfigurationGlobalActive(context.TODO(), mockReqConfGlobal, res)
+
+		merr, ok := err.(*microerrors.Error)
+		assert.True(t, ok)
+		assert.Equal(t, int32(409), merr.Code)
+
+		var detail struct {
+			Findings []*pb.LintFinding `json:"findings"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(merr.Detail), &detail))
+		assert.Equal(t, findings, detail.Findings)
+		assert.False(t, res.Configstatus.GetUpdated())
+	})
+
+	t.Run("Set Configuration Global Active with override", func(t *testing.T) {
+		mockUseCaseConf.On("SetConfigurationGlobalActive", mock.Anything, mock.AnythingOfType("*configuration.ConfigurationGlobal"), true).Return(mockRespConfGlobal, nil).Once()
+
+		req := &pb.RequestConfigGlobal{Configglobal: mockReqConfGlobal.Configglobal, OverrideLint: true}
+		res := &pb.ResponseConfigGlobal{}
+		handler := micro.NewMicroGrpc(mockUseCaseConf)
+		err := handler.SetConfigurationGlobalActive(context.TODO(), req, res)
+
+		assert.NoError(t, err)
+		mockUseCaseConf.AssertExpectations(t)
+	})
+}
+
+// watchClientStream is pb.ConfigurationService_WatchConfigurationClientStream that keep sent response
+type watchClientStream struct {
+	sent   []*pb.ResponseConfigClient
+	closed bool
+}
+
+func (s *watchClientStream) Context() context.Context    { return context.TODO() }
+func (s *watchClientStream) SendMsg(m interface{}) error { return nil }
+func (s *watchClientStream) RecvMsg(m interface{}) error { return nil }
+func (s *watchClientStream) Close() error                { s.closed = true; return nil }
+func (s *watchClientStream) Send(m *pb.ResponseConfigClient) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func TestWatchConfigurationClient(t *testing.T) {
+	mockUseCaseConf := new(mocks.Usecase)
+	mockRespConfigClient := &pb.ResponseConfigClient{
+		Configclient: &pb.ConfigurationClient{ConfigClientId: 1, CompanySubsId: "012-031-234-542"},
+	}
+
+	mockUseCaseConf.On("WatchConfigurationClient", mock.Anything, "012-031-234-542", mock.Anything).Return(func(ctx context.Context, subsID string, send func(*pb.ResponseConfigClient) error) error {
+		return send(mockRespConfigClient)
+	}).Once()
+
+	stream := &watchClientStream{}
+	handler := micro.NewMicroGrpc(mockUseCaseConf)
+	err := handler.WatchConfigurationClient(context.TODO(), &pb.RequestConfigCient{Configclient: &pb.ConfigurationClient{CompanySubsId: "012-031-234-542"}}, stream)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.ResponseConfigClient{mockRespConfigClient}, stream.sent)
+	assert.True(t, stream.closed)
+	mockUseCaseConf.AssertExpectations(t)
+}
+
+func TestGetWebhookDeliveries(t *testing.T) {
+	mockUseCaseConf := new(mocks.Usecase)
+	mockDeliveries := []*pb.WebhookDelivery{{DeliveryId: 1, WebhookId: 1, Status: "dead", Attempts: 8}}
+
+	mockUseCaseConf.On("GetWebhookDeliveries", mock.Anything, "dead").Return(&pb.ResponseWebhook{Deliveries: mockDeliveries}, nil).Once()
+
+	handler := micro.NewMicroGrpc(mockUseCaseConf)
+	res := &pb.ResponseWebhook{}
+	err := handler.GetWebhookDeliveries(context.TODO(), &pb.RequestWebhook{Status: "dead"}, res)
+
+	assert.NoError(t, err)
+	assert.Equal(t, mockDeliveries, res.Deliveries)
+	mockUseCaseConf.AssertExpectations(t)
+}
+
+func TestSyncConfiguration(t *testing.T) {
+	mockUseCaseConf := new(mocks.Usecase)
+	mockSync := &pb.ResponseSync{
+		Revision:       12,
+		Configclients:  []*pb.ConfigurationClient{{ConfigClientId: 1, CompanySubsId: "180-000-123-0321"}},
+		DeletedGlobals: []*pb.GlobalTombstone{{ConfigGlobalId: 4, Revision: 12}},
+	}
+
+	mockUseCaseConf.On("SyncConfiguration", mock.Anything, int64(10)).Return(mockSync, nil).Once()
+
+	handler := micro.NewMicroGrpc(mockUseCaseConf)
+	res := &pb.ResponseSync{}
+	err := handler.SyncConfiguration(context.TODO(), &pb.RequestSync{SinceRevision: 10}, res)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), res.Revision)
+	assert.Equal(t, mockSync.Configclients, res.Configclients)
+	assert.Equal(t, mockSync.DeletedGlobals, res.DeletedGlobals)
+	mockUseCaseConf.AssertExpectations(t)
 }
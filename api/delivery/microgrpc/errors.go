@@ -0,0 +1,70 @@
+package microgrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	microerrors "github.com/micro/go-micro/errors"
+	"github.com/muhammadhidayah/configuration-service/api"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+)
+
+// id of go-micro error, it is the name of the service
+const errorID = "inact.srv.configuration"
+
+// microError map error of usecase to go-micro error, so caller can check code of error instead of its message.
+// error that is not one of kind of api is internal server error
+func microError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	// error already mapped, or sent by other service
+	var merr *microerrors.Error
+	if errors.As(err, &merr) {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, api.ErrNotFound):
+		return microerrors.NotFound(errorID, err.Error())
+	case errors.Is(err, api.ErrAlreadyExists), errors.Is(err, api.ErrConflict):
+		return microerrors.Conflict(errorID, err.Error())
+	case errors.Is(err, api.ErrInvalidArgument):
+		return microerrors.BadRequest(errorID, err.Error())
+	case errors.Is(err, api.ErrUnavailable):
+		return microerrors.New(errorID, err.Error(), 503)
+	case errors.Is(err, context.DeadlineExceeded):
+		return microerrors.Timeout(errorID, err.Error())
+	case errors.Is(err, context.Canceled):
+		// caller is gone, code 499 is used by nginx for the same case
+		return microerrors.New(errorID, err.Error(), 499)
+	}
+
+	return microerrors.InternalServerError(errorID, err.Error())
+}
+
+// errorDetail is detail of go-micro error as json, so caller get why request is refused and not only the message
+type errorDetail struct {
+	Message  string            `json:"message"`
+	Findings []*pb.LintFinding `json:"findings,omitempty"`
+}
+
+// microErrorDetail map error like microError, and put findings in the detail of the error as json.
+// go-micro does not send response together with error, so they cannot be sent in the response
+func microErrorDetail(err error, findings []*pb.LintFinding) error {
+	mapped := microError(err)
+
+	var merr *microerrors.Error
+	if !errors.As(mapped, &merr) {
+		return mapped
+	}
+
+	detail, jerr := json.Marshal(errorDetail{Message: merr.Detail, Findings: findings})
+	if jerr != nil {
+		return mapped
+	}
+
+	return &microerrors.Error{Id: merr.Id, Code: merr.Code, Detail: string(detail), Status: merr.Status}
+}
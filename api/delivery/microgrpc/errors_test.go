@@ -0,0 +1,52 @@
+package microgrpc_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	microerrors "github.com/micro/go-micro/errors"
+	"github.com/muhammadhidayah/configuration-service/api"
+	micro "github.com/muhammadhidayah/configuration-service/api/delivery/microgrpc"
+	"github.com/muhammadhidayah/configuration-service/api/mocks"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code int32
+	}{
+		{"Not found", api.Errorf(api.ErrNotFound, "Data Not Found"), 404},
+		{"Already exists", api.WrapError(api.ErrAlreadyExists, "Data Already Exists", errors.New("duplicate key")), 409},
+		{"Conflict", api.Errorf(api.ErrConflict, "Other Configuration Activated at The Same Time"), 409},
+		{"Invalid argument", api.Errorf(api.ErrInvalidArgument, "Since revision must not be negative"), 400},
+		{"Unavailable", api.WrapError(api.ErrUnavailable, "Database Unavailable", errors.New("connection refused")), 503},
+		{"Wrapped by fmt", fmt.Errorf("read configuration: %w", api.ErrNotFound), 404},
+		{"Deadline exceeded", context.DeadlineExceeded, 408},
+		{"Unknown", errors.New("Unexpected syntax error"), 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUseCaseConf := new(mocks.Usecase)
+			mockUseCaseConf.On("SyncConfiguration", mock.Anything, mock.AnythingOfType("int64")).Return(nil, tt.err).Once()
+
+			handler := micro.NewMicroGrpc(mockUseCaseConf)
+			err := handler.SyncConfiguration(context.TODO(), &pb.RequestSync{}, &pb.ResponseSync{})
+
+			merr, ok := err.(*microerrors.Error)
+			require.True(t, ok, "expected go-micro error, got %T", err)
+			assert.Equal(t, tt.code, merr.Code)
+			assert.Equal(t, "inact.srv.configuration", merr.Id)
+			assert.Equal(t, tt.err.Error(), merr.Detail)
+
+			mockUseCaseConf.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package microgrpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/micro/go-micro/metadata"
+	"github.com/micro/go-micro/server"
+	"github.com/muhammadhidayah/configuration-service/api"
+)
+
+// ReadPrimaryHeader is metadata of request to read from primary database, so caller can read data it just wrote
+const ReadPrimaryHeader = "X-Read-Primary"
+
+// ReadPrimaryWrapper is server.HandlerWrapper that force repository to read from primary database when request has metadata ReadPrimaryHeader "true"
+func ReadPrimaryWrapper(fn server.HandlerFunc) server.HandlerFunc {
+	return func(ctx context.Context, req server.Request, rsp interface{}) error {
+		if readPrimary(ctx) {
+			ctx = api.WithReadPrimary(ctx)
+		}
+
+		return fn(ctx, req, rsp)
+	}
+}
+
+// key of metadata may be canonicalized by transport, so it is compared case insensitive
+func readPrimary(ctx context.Context) bool {
+	md, ok := metadata.FromContext(ctx)
+	if !ok {
+		return false
+	}
+
+	for key, value := range md {
+		if strings.EqualFold(key, ReadPrimaryHeader) {
+			return strings.EqualFold(value, "true")
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,37 @@
+package microgrpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/micro/go-micro/metadata"
+	"github.com/micro/go-micro/server"
+	"github.com/muhammadhidayah/configuration-service/api"
+	"github.com/muhammadhidayah/configuration-service/api/delivery/microgrpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadPrimaryWrapper(t *testing.T) {
+	var forced bool
+	handler := microgrpc.ReadPrimaryWrapper(func(ctx context.Context, req server.Request, rsp interface{}) error {
+		forced = api.IsReadPrimary(ctx)
+		return nil
+	})
+
+	t.Run("Request without metadata read from replica", func(t *testing.T) {
+		assert.NoError(t, handler(context.TODO(), nil, nil))
+		assert.False(t, forced)
+	})
+
+	t.Run("Request with metadata read from primary", func(t *testing.T) {
+		ctx := metadata.NewContext(context.TODO(), metadata.Metadata{"x-read-primary": "true"})
+		assert.NoError(t, handler(ctx, nil, nil))
+		assert.True(t, forced)
+	})
+
+	t.Run("Request with metadata false read from replica", func(t *testing.T) {
+		ctx := metadata.NewContext(context.TODO(), metadata.Metadata{microgrpc.ReadPrimaryHeader: "false"})
+		assert.NoError(t, handler(ctx, nil, nil))
+		assert.False(t, forced)
+	})
+}
@@ -0,0 +1,21 @@
+package microgrpc
+
+import (
+	"context"
+
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+)
+
+func (micro *microgrpc) SyncConfiguration(ctx context.Context, req *pb.RequestSync, res *pb.ResponseSync) error {
+	resp, err := micro.uscase.SyncConfiguration(ctx, req.GetSinceRevision())
+	if err != nil {
+		return microError(err)
+	}
+
+	res.Revision = resp.GetRevision()
+	res.Configclients = resp.GetConfigclients()
+	res.Configglobals = resp.GetConfigglobals()
+	res.DeletedClients = resp.GetDeletedClients()
+	res.DeletedGlobals = resp.GetDeletedGlobals()
+	return nil
+}
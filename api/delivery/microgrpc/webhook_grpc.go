@@ -0,0 +1,62 @@
+package microgrpc
+
+import (
+	"context"
+
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+)
+
+func (micro *microgrpc) AddWebhook(ctx context.Context, req *pb.RequestWebhook, res *pb.ResponseWebhook) error {
+	resp, err := micro.uscase.AddWebhook(ctx, req.GetWebhook())
+	if err != nil {
+		res.Status = &pb.ConfigurationStatus{Created: false}
+		return microError(err)
+	}
+
+	res.Status = resp.GetStatus()
+	res.Webhook = resp.GetWebhook()
+	return nil
+}
+
+func (micro *microgrpc) DeleteWebhook(ctx context.Context, req *pb.RequestWebhook, res *pb.ResponseWebhook) error {
+	resp, err := micro.uscase.DeleteWebhook(ctx, req.GetWebhook().GetWebhookId())
+	if err != nil {
+		res.Status = &pb.ConfigurationStatus{Deleted: false}
+		return microError(err)
+	}
+
+	res.Status = resp.GetStatus()
+	return nil
+}
+
+func (micro *microgrpc) GetWebhooks(ctx context.Context, req *pb.RequestWebhook, res *pb.ResponseWebhook) error {
+	resp, err := micro.uscase.GetWebhooks(ctx)
+	if err != nil {
+		return microError(err)
+	}
+
+	res.Webhooks = resp.GetWebhooks()
+	return nil
+}
+
+// status of request choose the deliveries, dead deliveries are returned when it is empty
+func (micro *microgrpc) GetWebhookDeliveries(ctx context.Context, req *pb.RequestWebhook, res *pb.ResponseWebhook) error {
+	resp, err := micro.uscase.GetWebhookDeliveries(ctx, req.GetStatus())
+	if err != nil {
+		return microError(err)
+	}
+
+	res.Deliveries = resp.GetDeliveries()
+	return nil
+}
+
+func (micro *microgrpc) RedeliverWebhook(ctx context.Context, req *pb.RequestWebhook, res *pb.ResponseWebhook) error {
+	resp, err := micro.uscase.RedeliverWebhook(ctx, req.GetDeliveryId())
+	if err != nil {
+		res.Status = &pb.ConfigurationStatus{Updated: false}
+		return microError(err)
+	}
+
+	res.Status = resp.GetStatus()
+	return nil
+}
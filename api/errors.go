@@ -0,0 +1,71 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+)
+
+// kind of error returned by Repository and Usecase. error of one kind is checked with errors.Is, so caller never compare the message
+var (
+	// ErrNotFound is returned when configuration, webhook or delivery looked up not exists or deleted
+	ErrNotFound = errors.New("Data Not Found")
+
+	// ErrAlreadyExists is returned when data with the same unique key already exists
+	ErrAlreadyExists = errors.New("Data Already Exists")
+
+	// ErrConflict is returned when data is changed by other request, or not in state that allow the change
+	ErrConflict = errors.New("Data Conflict")
+
+	// ErrInvalidArgument is returned when request is not valid, it fails the same way every time it is sent
+	ErrInvalidArgument = errors.New("Invalid Argument")
+
+	// ErrUnavailable is returned when database cannot be reached, request can be sent again later
+	ErrUnavailable = errors.New("Service Unavailable")
+)
+
+// Error is error of kind with its own message
+type Error struct {
+	Kind    error
+	Message string
+
+	// Err is error that cause this error, it may be nil
+	Err error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+
+	return e.Message
+}
+
+// Is make errors.Is(err, kind) true for the kind of error
+func (e *Error) Is(target error) bool {
+	return e.Kind == target
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Errorf return error of kind with formatted message
+func Errorf(kind error, format string, args ...interface{}) error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, args...)}
+}
+
+// WrapError return error of kind caused by err, message of err is kept
+func WrapError(kind error, message string, err error) error {
+	return &Error{Kind: kind, Message: message, Err: err}
+}
+
+// KindOf return kind of err, nil when err is not one of the kind
+func KindOf(err error) error {
+	for _, kind := range []error{ErrNotFound, ErrAlreadyExists, ErrConflict, ErrInvalidArgument, ErrUnavailable} {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+
+	return nil
+}
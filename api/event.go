@@ -0,0 +1,25 @@
+package api
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// topic of event published after configuration changed
+const (
+	TopicClientCreated = "config.client.created"
+	TopicClientUpdated = "config.client.updated"
+	TopicClientDeleted = "config.client.deleted"
+
+	TopicGlobalCreated   = "config.global.created"
+	TopicGlobalUpdated   = "config.global.updated"
+	TopicGlobalDeleted   = "config.global.deleted"
+	TopicGlobalActivated = "config.global.activated"
+)
+
+// EventPublisher publish event of configuration change to other service. event is
+// pb.ConfigurationClientEvent or pb.ConfigurationGlobalEvent
+type EventPublisher interface {
+	Publish(ctx context.Context, topic string, event proto.Message) error
+}
@@ -0,0 +1,23 @@
+package event
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/client"
+	"github.com/muhammadhidayah/configuration-service/api"
+)
+
+type microPublisher struct {
+	client client.Client
+}
+
+// NewMicroPublisher return api.EventPublisher that publish event through broker of client c, the same way as micro.Publisher.
+// subscriber can receive it using micro.RegisterSubscriber with the topic
+func NewMicroPublisher(c client.Client) api.EventPublisher {
+	return &microPublisher{client: c}
+}
+
+func (p *microPublisher) Publish(ctx context.Context, topic string, event proto.Message) error {
+	return p.client.Publish(ctx, p.client.NewMessage(topic, event))
+}
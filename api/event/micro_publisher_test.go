@@ -0,0 +1,53 @@
+package event_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/broker"
+	"github.com/micro/go-micro/broker/memory"
+	"github.com/micro/go-micro/client"
+	"github.com/muhammadhidayah/configuration-service/api"
+	"github.com/muhammadhidayah/configuration-service/api/event"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMicroPublisher(t *testing.T) {
+	b := memory.NewBroker()
+	require.NoError(t, b.Connect())
+	defer b.Disconnect()
+
+	received := make(chan *pb.ConfigurationClientEvent, 1)
+	sub, err := b.Subscribe(api.TopicClientUpdated, func(p broker.Event) error {
+		ev := &pb.ConfigurationClientEvent{}
+		if err := proto.Unmarshal(p.Message().Body, ev); err != nil {
+			return err
+		}
+
+		received <- ev
+		return nil
+	})
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	publisher := event.NewMicroPublisher(client.NewClient(client.Broker(b)))
+
+	sent := &pb.ConfigurationClientEvent{
+		Id:     "e7b2bd1b-5fe9-4a55-9d83-5f7a47e2d1f6",
+		Topic:  api.TopicClientUpdated,
+		Before: &pb.ConfigurationClient{CompanySubsId: "012-031-234-542", ReportTitle: "Client Satu"},
+		After:  &pb.ConfigurationClient{CompanySubsId: "012-031-234-542", ReportTitle: "Client Updated"},
+	}
+	require.NoError(t, publisher.Publish(context.TODO(), api.TopicClientUpdated, sent))
+
+	select {
+	case ev := <-received:
+		assert.True(t, proto.Equal(sent, ev))
+	case <-time.After(time.Second):
+		t.Fatal("event not received")
+	}
+}
@@ -0,0 +1,26 @@
+package event
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/muhammadhidayah/configuration-service/api"
+)
+
+type multiPublisher []api.EventPublisher
+
+// NewMultiPublisher return api.EventPublisher that publish event to every publisher in order.
+// it stop at the first error, so relay retry the event and publisher before it receive the event again
+func NewMultiPublisher(publishers ...api.EventPublisher) api.EventPublisher {
+	return multiPublisher(publishers)
+}
+
+func (m multiPublisher) Publish(ctx context.Context, topic string, event proto.Message) error {
+	for _, p := range m {
+		if err := p.Publish(ctx, topic, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
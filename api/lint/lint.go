@@ -0,0 +1,158 @@
+// Package lint find risky combination of configuration global, like smtp password sent as plain text.
+// rule is declared as data, so rule can be added without changing the linter
+package lint
+
+import (
+	"net"
+
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+)
+
+// Severity of finding. configuration with finding of severity Error is not activated unless it is overridden
+type Severity int
+
+const (
+	Info Severity = iota
+	Warning
+	Error
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Info:
+		return "info"
+	case Warning:
+		return "warning"
+	case Error:
+		return "error"
+	}
+
+	return "unknown"
+}
+
+// Rule is one check of configuration global. configuration is found by the rule when Match return true
+type Rule struct {
+	Name     string
+	Severity Severity
+	// Field is name of field in proto that should be changed to fix the finding
+	Field   string
+	Message string
+	Match   func(cg *pb.ConfigurationGlobal) bool
+}
+
+// Finding is rule that found the configuration
+type Finding struct {
+	Rule     string
+	Field    string
+	Severity Severity
+	Message  string
+}
+
+// rules of smtp that is checked in every environment
+var (
+	ImplicitTLSWithoutSSL = Rule{
+		Name:     "implicit-tls-without-ssl",
+		Severity: Error,
+		Field:    "ssl",
+		Message:  "port 465 is implicit tls, ssl must be true or mail cannot be sent",
+		Match: func(cg *pb.ConfigurationGlobal) bool {
+			return cg.GetPort() == 465 && !cg.GetSsl()
+		},
+	}
+
+	AuthOverPlaintext = Rule{
+		Name:     "auth-over-plaintext",
+		Severity: Warning,
+		Field:    "is_auth",
+		Message:  "username and password may be sent as plain text, because ssl is false",
+		Match: func(cg *pb.ConfigurationGlobal) bool {
+			return cg.GetIsAuth() && !cg.GetSsl()
+		},
+	}
+
+	EmptyFootertext = Rule{
+		Name:     "empty-footertext",
+		Severity: Info,
+		Field:    "footertext",
+		Message:  "footertext is empty, mail is sent without support contact",
+		Match: func(cg *pb.ConfigurationGlobal) bool {
+			return cg.GetFootertext() == ""
+		},
+	}
+)
+
+// PrivateServer is only checked in production, server on private network is fine in development
+var PrivateServer = Rule{
+	Name:     "private-server",
+	Severity: Error,
+	Field:    "server_smpt",
+	Message:  "server_smpt is private ip address, it cannot be reached from production",
+	Match: func(cg *pb.ConfigurationGlobal) bool {
+		ip := net.ParseIP(cg.GetServerSmpt())
+		return ip != nil && isPrivate(ip)
+	},
+}
+
+// DefaultRules return rules checked in every environment
+func DefaultRules() []Rule {
+	return []Rule{ImplicitTLSWithoutSSL, AuthOverPlaintext, EmptyFootertext}
+}
+
+// ProductionRules return DefaultRules and rules that only matter in production
+func ProductionRules() []Rule {
+	return append(DefaultRules(), PrivateServer)
+}
+
+// Linter check configuration global with its rules
+type Linter struct {
+	rules []Rule
+}
+
+// New create linter of rules, linter without rule find nothing
+func New(rules ...Rule) *Linter {
+	return &Linter{rules: rules}
+}
+
+// Lint return finding of every rule that match cg, in order of the rules
+func (l *Linter) Lint(cg *pb.ConfigurationGlobal) []Finding {
+	var findings []Finding
+	for _, rule := range l.rules {
+		if rule.Match(cg) {
+			findings = append(findings, Finding{Rule: rule.Name, Field: rule.Field, Severity: rule.Severity, Message: rule.Message})
+		}
+	}
+
+	return findings
+}
+
+// HasError is true when one of findings has severity Error
+func HasError(findings []Finding) bool {
+	for _, f := range findings {
+		if f.Severity >= Error {
+			return true
+		}
+	}
+
+	return false
+}
+
+// network of private, loopback and link local address
+var privateNetworks = func() []*net.IPNet {
+	var networks []*net.IPNet
+	for _, cidr := range []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "127.0.0.0/8", "169.254.0.0/16", "::1/128", "fc00::/7", "fe80::/10"} {
+		_, network, _ := net.ParseCIDR(cidr)
+		networks = append(networks, network)
+	}
+
+	return networks
+}()
+
+func isPrivate(ip net.IP) bool {
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,71 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/muhammadhidayah/configuration-service/api/lint"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+// rules return name of rule of findings, in order
+func rules(findings []lint.Finding) []string {
+	names := []string{}
+	for _, f := range findings {
+		names = append(names, f.Rule)
+	}
+
+	return names
+}
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name  string
+		cg    *pb.ConfigurationGlobal
+		rules []string
+	}{
+		{"Safe configuration", &pb.ConfigurationGlobal{Footertext: "Technical support", ServerSmpt: "mail.google.com", Port: 465, Ssl: true, IsAuth: true}, []string{}},
+		{"Port 465 without ssl", &pb.ConfigurationGlobal{Footertext: "Technical support", ServerSmpt: "mail.google.com", Port: 465}, []string{"implicit-tls-without-ssl"}},
+		{"Auth over plaintext", &pb.ConfigurationGlobal{Footertext: "Technical support", ServerSmpt: "mail.google.com", Port: 587, IsAuth: true}, []string{"auth-over-plaintext"}},
+		{"Empty footertext", &pb.ConfigurationGlobal{ServerSmpt: "mail.google.com", Port: 465, Ssl: true}, []string{"empty-footertext"}},
+		{"Private server is fine outside production", &pb.ConfigurationGlobal{Footertext: "Technical support", ServerSmpt: "192.168.1.10", Port: 465, Ssl: true}, []string{}},
+	}
+
+	linter := lint.New(lint.DefaultRules()...)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.rules, rules(linter.Lint(tt.cg)))
+		})
+	}
+}
+
+func TestLintProduction(t *testing.T) {
+	linter := lint.New(lint.ProductionRules()...)
+
+	for _, server := range []string{"10.0.0.25", "172.20.1.1", "192.168.1.10", "127.0.0.1", "fd00::25"} {
+		findings := linter.Lint(&pb.ConfigurationGlobal{Footertext: "Technical support", ServerSmpt: server, Port: 465, Ssl: true})
+		assert.Equal(t, []string{"private-server"}, rules(findings), server)
+		assert.True(t, lint.HasError(findings))
+	}
+
+	for _, server := range []string{"mail.google.com", "172.32.0.1", "8.8.8.8"} {
+		findings := linter.Lint(&pb.ConfigurationGlobal{Footertext: "Technical support", ServerSmpt: server, Port: 465, Ssl: true})
+		assert.Empty(t, findings, server)
+	}
+}
+
+func TestCustomRule(t *testing.T) {
+	plainPort := lint.Rule{
+		Name:     "plain-port",
+		Severity: lint.Warning,
+		Field:    "port",
+		Message:  "port 25 is often blocked",
+		Match:    func(cg *pb.ConfigurationGlobal) bool { return cg.GetPort() == 25 },
+	}
+
+	findings := lint.New(plainPort).Lint(&pb.ConfigurationGlobal{Port: 25})
+
+	assert.Equal(t, []lint.Finding{{Rule: "plain-port", Field: "port", Severity: lint.Warning, Message: "port 25 is often blocked"}}, findings)
+	assert.False(t, lint.HasError(findings))
+	assert.Equal(t, "warning", lint.Warning.String())
+}
@@ -2,6 +2,7 @@
 
 package mocks
 
+import api "github.com/muhammadhidayah/configuration-service/api"
 import configuration "github.com/muhammadhidayah/configuration-service/proto/configuration"
 import context "context"
 import mock "github.com/stretchr/testify/mock"
@@ -53,6 +54,76 @@ func (_m *Repository) AddConfigurationGlobal(_a0 context.Context, _a1 *configura
 	return r0, r1
 }
 
+// AddOutboxEvent provides a mock function with given fields: _a0, _a1
+func (_m *Repository) AddOutboxEvent(_a0 context.Context, _a1 *api.OutboxEvent) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *api.OutboxEvent) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddWebhook provides a mock function with given fields: _a0, _a1
+func (_m *Repository) AddWebhook(_a0 context.Context, _a1 *configuration.Webhook) (bool, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *configuration.Webhook) bool); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *configuration.Webhook) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddWebhookDelivery provides a mock function with given fields: _a0, _a1
+func (_m *Repository) AddWebhookDelivery(_a0 context.Context, _a1 *configuration.WebhookDelivery) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *configuration.WebhookDelivery) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClaimWebhookDelivery provides a mock function with given fields: ctx, d, until
+func (_m *Repository) ClaimWebhookDelivery(ctx context.Context, d *configuration.WebhookDelivery, until int64) (bool, error) {
+	ret := _m.Called(ctx, d, until)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *configuration.WebhookDelivery, int64) bool); ok {
+		r0 = rf(ctx, d, until)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *configuration.WebhookDelivery, int64) error); ok {
+		r1 = rf(ctx, d, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteConfiguration provides a mock function with given fields: _a0, _a1
 func (_m *Repository) DeleteConfiguration(_a0 context.Context, _a1 int32) (bool, error) {
 	ret := _m.Called(_a0, _a1)
@@ -95,6 +166,27 @@ func (_m *Repository) DeleteConfigurationClientBySubs(_a0 context.Context, _a1 *
 	return r0, r1
 }
 
+// DeleteWebhook provides a mock function with given fields: _a0, _a1
+func (_m *Repository) DeleteWebhook(_a0 context.Context, _a1 int64) (bool, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetConfigurationClient provides a mock function with given fields: _a0
 func (_m *Repository) GetConfigurationClient(_a0 context.Context) ([]*configuration.ConfigurationClient, error) {
 	ret := _m.Called(_a0)
@@ -141,6 +233,29 @@ func (_m *Repository) GetConfigurationClientBySubs(_a0 context.Context, _a1 stri
 	return r0, r1
 }
 
+// GetConfigurationClientByUUID provides a mock function with given fields: _a0, _a1
+func (_m *Repository) GetConfigurationClientByUUID(_a0 context.Context, _a1 string) (*configuration.ConfigurationClient, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *configuration.ConfigurationClient
+	if rf, ok := ret.Get(0).(func(context.Context, string) *configuration.ConfigurationClient); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*configuration.ConfigurationClient)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetConfigurationGlobal provides a mock function with given fields: _a0
 func (_m *Repository) GetConfigurationGlobal(_a0 context.Context) ([]*configuration.ConfigurationGlobal, error) {
 	ret := _m.Called(_a0)
@@ -210,6 +325,186 @@ func (_m *Repository) GetConfigurationGlobalByID(_a0 context.Context, _a1 int32)
 	return r0, r1
 }
 
+// GetDuplicateConfigurationClient provides a mock function with given fields: _a0
+func (_m *Repository) GetDuplicateConfigurationClient(_a0 context.Context) ([]*configuration.ConfigurationClient, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []*configuration.ConfigurationClient
+	if rf, ok := ret.Get(0).(func(context.Context) []*configuration.ConfigurationClient); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*configuration.ConfigurationClient)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhookDeliveries provides a mock function with given fields: ctx, status
+func (_m *Repository) GetWebhookDeliveries(ctx context.Context, status string) ([]*configuration.WebhookDelivery, error) {
+	ret := _m.Called(ctx, status)
+
+	var r0 []*configuration.WebhookDelivery
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*configuration.WebhookDelivery); ok {
+		r0 = rf(ctx, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*configuration.WebhookDelivery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhooks provides a mock function with given fields: _a0
+func (_m *Repository) GetWebhooks(_a0 context.Context) ([]*configuration.Webhook, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []*configuration.Webhook
+	if rf, ok := ret.Get(0).(func(context.Context) []*configuration.Webhook); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*configuration.Webhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PendingWebhookDeliveries provides a mock function with given fields: ctx, now, limit
+func (_m *Repository) PendingWebhookDeliveries(ctx context.Context, now int64, limit int) ([]*configuration.WebhookDelivery, error) {
+	ret := _m.Called(ctx, now, limit)
+
+	var r0 []*configuration.WebhookDelivery
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []*configuration.WebhookDelivery); ok {
+		r0 = rf(ctx, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*configuration.WebhookDelivery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RedeliverWebhookDelivery provides a mock function with given fields: ctx, deliveryID, now
+func (_m *Repository) RedeliverWebhookDelivery(ctx context.Context, deliveryID int64, now int64) (bool, error) {
+	ret := _m.Called(ctx, deliveryID, now)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) bool); ok {
+		r0 = rf(ctx, deliveryID, now)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, deliveryID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RepairDuplicateConfigurationClient provides a mock function with given fields: _a0
+func (_m *Repository) RepairDuplicateConfigurationClient(_a0 context.Context) ([]*configuration.ConfigurationClient, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []*configuration.ConfigurationClient
+	if rf, ok := ret.Get(0).(func(context.Context) []*configuration.ConfigurationClient); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*configuration.ConfigurationClient)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetConfigurationGlobalActive provides a mock function with given fields: _a0, _a1
+func (_m *Repository) SetConfigurationGlobalActive(_a0 context.Context, _a1 int32) (bool, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int32) bool); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SyncConfiguration provides a mock function with given fields: ctx, since
+func (_m *Repository) SyncConfiguration(ctx context.Context, since int64) (*configuration.ResponseSync, error) {
+	ret := _m.Called(ctx, since)
+
+	var r0 *configuration.ResponseSync
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *configuration.ResponseSync); ok {
+		r0 = rf(ctx, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*configuration.ResponseSync)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateConfigurationClientBySubs provides a mock function with given fields: _a0, _a1
 func (_m *Repository) UpdateConfigurationClientBySubs(_a0 context.Context, _a1 *configuration.ConfigurationClient) (bool, error) {
 	ret := _m.Called(_a0, _a1)
@@ -251,3 +546,52 @@ func (_m *Repository) UpdateConfigurationGlobal(_a0 context.Context, _a1 *config
 
 	return r0, r1
 }
+
+// UpdateWebhookDelivery provides a mock function with given fields: _a0, _a1
+func (_m *Repository) UpdateWebhookDelivery(_a0 context.Context, _a1 *configuration.WebhookDelivery) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *configuration.WebhookDelivery) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpsertConfigurationClientBySubs provides a mock function with given fields: _a0, _a1
+func (_m *Repository) UpsertConfigurationClientBySubs(_a0 context.Context, _a1 *configuration.ConfigurationClient) (bool, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *configuration.ConfigurationClient) bool); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *configuration.ConfigurationClient) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WithinTx provides a mock function with given fields: _a0, _a1
+func (_m *Repository) WithinTx(_a0 context.Context, _a1 func(api.Repository) error) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(api.Repository) error) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -4,6 +4,7 @@ package mocks
 
 import configuration "github.com/muhammadhidayah/configuration-service/proto/configuration"
 import context "context"
+import time "time"
 import mock "github.com/stretchr/testify/mock"
 
 // Usecase is an autogenerated mock type for the Usecase type
@@ -57,6 +58,29 @@ func (_m *Usecase) AddConfigurationGlobal(_a0 context.Context, _a1 *configuratio
 	return r0, r1
 }
 
+// AddWebhook provides a mock function with given fields: _a0, _a1
+func (_m *Usecase) AddWebhook(_a0 context.Context, _a1 *configuration.Webhook) (*configuration.ResponseWebhook, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *configuration.ResponseWebhook
+	if rf, ok := ret.Get(0).(func(context.Context, *configuration.Webhook) *configuration.ResponseWebhook); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*configuration.ResponseWebhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *configuration.Webhook) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteConfiguration provides a mock function with given fields: _a0, _a1
 func (_m *Usecase) DeleteConfiguration(_a0 context.Context, _a1 int32) (*configuration.ResponseConfigGlobal, error) {
 	ret := _m.Called(_a0, _a1)
@@ -103,6 +127,29 @@ func (_m *Usecase) DeleteConfigurationClientBySubs(_a0 context.Context, _a1 *con
 	return r0, r1
 }
 
+// DeleteWebhook provides a mock function with given fields: _a0, _a1
+func (_m *Usecase) DeleteWebhook(_a0 context.Context, _a1 int64) (*configuration.ResponseWebhook, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *configuration.ResponseWebhook
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *configuration.ResponseWebhook); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*configuration.ResponseWebhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetConfigurationClient provides a mock function with given fields: _a0
 func (_m *Usecase) GetConfigurationClient(_a0 context.Context) (*configuration.ResponseConfigClient, error) {
 	ret := _m.Called(_a0)
@@ -218,13 +265,82 @@ func (_m *Usecase) GetConfigurationGlobalByID(_a0 context.Context, _a1 int32) (*
 	return r0, r1
 }
 
-// SetConfigurationGlobalActive provides a mock function with given fields: _a0, _a1
-func (_m *Usecase) SetConfigurationGlobalActive(_a0 context.Context, _a1 *configuration.ConfigurationGlobal) (*configuration.ResponseConfigGlobal, error) {
+// GetWebhookDeliveries provides a mock function with given fields: _a0, _a1
+func (_m *Usecase) GetWebhookDeliveries(_a0 context.Context, _a1 string) (*configuration.ResponseWebhook, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *configuration.ResponseConfigGlobal
-	if rf, ok := ret.Get(0).(func(context.Context, *configuration.ConfigurationGlobal) *configuration.ResponseConfigGlobal); ok {
+	var r0 *configuration.ResponseWebhook
+	if rf, ok := ret.Get(0).(func(context.Context, string) *configuration.ResponseWebhook); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*configuration.ResponseWebhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhooks provides a mock function with given fields: _a0
+func (_m *Usecase) GetWebhooks(_a0 context.Context) (*configuration.ResponseWebhook, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *configuration.ResponseWebhook
+	if rf, ok := ret.Get(0).(func(context.Context) *configuration.ResponseWebhook); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*configuration.ResponseWebhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RedeliverWebhook provides a mock function with given fields: _a0, _a1
+func (_m *Usecase) RedeliverWebhook(_a0 context.Context, _a1 int64) (*configuration.ResponseWebhook, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *configuration.ResponseWebhook
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *configuration.ResponseWebhook); ok {
 		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*configuration.ResponseWebhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetConfigurationGlobalActive provides a mock function with given fields: ctx, cg, override
+func (_m *Usecase) SetConfigurationGlobalActive(ctx context.Context, cg *configuration.ConfigurationGlobal, override bool) (*configuration.ResponseConfigGlobal, error) {
+	ret := _m.Called(ctx, cg, override)
+
+	var r0 *configuration.ResponseConfigGlobal
+	if rf, ok := ret.Get(0).(func(context.Context, *configuration.ConfigurationGlobal, bool) *configuration.ResponseConfigGlobal); ok {
+		r0 = rf(ctx, cg, override)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*configuration.ResponseConfigGlobal)
@@ -232,7 +348,30 @@ func (_m *Usecase) SetConfigurationGlobalActive(_a0 context.Context, _a1 *config
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *configuration.ConfigurationGlobal) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *configuration.ConfigurationGlobal, bool) error); ok {
+		r1 = rf(ctx, cg, override)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SyncConfiguration provides a mock function with given fields: _a0, _a1
+func (_m *Usecase) SyncConfiguration(_a0 context.Context, _a1 int64) (*configuration.ResponseSync, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *configuration.ResponseSync
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *configuration.ResponseSync); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*configuration.ResponseSync)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -286,3 +425,77 @@ func (_m *Usecase) UpdateConfigurationGlobal(_a0 context.Context, _a1 *configura
 
 	return r0, r1
 }
+
+// UpsertConfigurationClientBySubs provides a mock function with given fields: _a0, _a1
+func (_m *Usecase) UpsertConfigurationClientBySubs(_a0 context.Context, _a1 *configuration.ConfigurationClient) (*configuration.ResponseConfigClient, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *configuration.ResponseConfigClient
+	if rf, ok := ret.Get(0).(func(context.Context, *configuration.ConfigurationClient) *configuration.ResponseConfigClient); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*configuration.ResponseConfigClient)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *configuration.ConfigurationClient) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WaitConfigurationClientBySubs provides a mock function with given fields: ctx, subsID, hash, revision, wait
+func (_m *Usecase) WaitConfigurationClientBySubs(ctx context.Context, subsID string, hash string, revision int64, wait time.Duration) (*configuration.ResponseConfigClient, error) {
+	ret := _m.Called(ctx, subsID, hash, revision, wait)
+
+	var r0 *configuration.ResponseConfigClient
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, time.Duration) *configuration.ResponseConfigClient); ok {
+		r0 = rf(ctx, subsID, hash, revision, wait)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*configuration.ResponseConfigClient)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64, time.Duration) error); ok {
+		r1 = rf(ctx, subsID, hash, revision, wait)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WatchConfigurationClient provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Usecase) WatchConfigurationClient(_a0 context.Context, _a1 string, _a2 func(*configuration.ResponseConfigClient) error) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(*configuration.ResponseConfigClient) error) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WatchConfigurationGlobalActive provides a mock function with given fields: _a0, _a1
+func (_m *Usecase) WatchConfigurationGlobalActive(_a0 context.Context, _a1 func(*configuration.ResponseConfigGlobal) error) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(*configuration.ResponseConfigGlobal) error) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,32 @@
+package api
+
+import "strconv"
+
+// GlobalActiveKey is key of the active configuration global
+const GlobalActiveKey = "global:active"
+
+// OutboxKey is notified after event saved in outbox, it wake relay that publish the event
+const OutboxKey = "outbox"
+
+// ClientKey return key of configuration client of company_subs_id
+func ClientKey(companySubsID string) string {
+	return "client:" + companySubsID
+}
+
+// GlobalKey return key of configuration global of config_global_id
+func GlobalKey(configGlobalID int32) string {
+	return "global:" + strconv.Itoa(int(configGlobalID))
+}
+
+// Notifier deliver change of configuration to watcher. key is made by ClientKey, GlobalKey or GlobalActiveKey
+type Notifier interface {
+	// Notify wake watcher of key
+	Notify(key string)
+
+	// NotifyPrefix wake watcher of every key that start with prefix, empty prefix wake all of watcher
+	NotifyPrefix(prefix string)
+
+	// Subscribe return channel that receive value every time key changed, changes while watcher busy are merged into one.
+	// the returned function stop the subscription
+	Subscribe(key string) (<-chan struct{}, func())
+}
@@ -0,0 +1,71 @@
+package notifier
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+)
+
+// hub is in process api.Notifier, safe to be used by many goroutine
+type hub struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan struct{}]struct{}
+}
+
+func NewHub() api.Notifier {
+	return &hub{subscribers: make(map[string]map[chan struct{}]struct{})}
+}
+
+func (h *hub) Subscribe(key string) (<-chan struct{}, func()) {
+	// buffer of one merge change that happen while subscriber busy
+	ch := make(chan struct{}, 1)
+
+	h.mu.Lock()
+	if h.subscribers[key] == nil {
+		h.subscribers[key] = make(map[chan struct{}]struct{})
+	}
+	h.subscribers[key][ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+
+			delete(h.subscribers[key], ch)
+			if len(h.subscribers[key]) == 0 {
+				delete(h.subscribers, key)
+			}
+		})
+	}
+}
+
+func (h *hub) Notify(key string) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	wake(h.subscribers[key])
+}
+
+func (h *hub) NotifyPrefix(prefix string) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for key, subscribers := range h.subscribers {
+		if strings.HasPrefix(key, prefix) {
+			wake(subscribers)
+		}
+	}
+}
+
+// wake never block, subscriber that has pending notification already will read the latest value
+func wake(subscribers map[chan struct{}]struct{}) {
+	for ch := range subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package notifier_test
+
+import (
+	"testing"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+	"github.com/muhammadhidayah/configuration-service/api/notifier"
+	"github.com/stretchr/testify/assert"
+)
+
+// received report whether ch has notification without blocking
+func received(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestHub(t *testing.T) {
+	hub := notifier.NewHub()
+
+	client, stopClient := hub.Subscribe(api.ClientKey("012-031-234-542"))
+	active, stopActive := hub.Subscribe(api.GlobalActiveKey)
+	defer stopActive()
+
+	t.Run("Notify only wake watcher of the key", func(t *testing.T) {
+		hub.Notify(api.ClientKey("012-031-234-542"))
+		assert.True(t, received(client))
+		assert.False(t, received(active))
+	})
+
+	t.Run("Many notification is merged into one", func(t *testing.T) {
+		hub.Notify(api.GlobalActiveKey)
+		hub.Notify(api.GlobalActiveKey)
+		assert.True(t, received(active))
+		assert.False(t, received(active))
+	})
+
+	t.Run("Notify prefix wake watcher of every matched key", func(t *testing.T) {
+		hub.NotifyPrefix("client:")
+		assert.True(t, received(client))
+		assert.False(t, received(active))
+
+		hub.NotifyPrefix("")
+		assert.True(t, received(client))
+		assert.True(t, received(active))
+	})
+
+	t.Run("Stopped watcher is not notified", func(t *testing.T) {
+		stopClient()
+		stopClient()
+
+		hub.Notify(api.ClientKey("012-031-234-542"))
+		assert.False(t, received(client))
+	})
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+	"time"
+)
+
+// OutboxEvent is event saved in the same transaction as the change, and published later by relay
+type OutboxEvent struct {
+	ID    int64
+	Topic string
+
+	// Key is ordering key, event of the same key is published in order of ID
+	Key string
+
+	// Payload is marshaled pb.ConfigurationClientEvent or pb.ConfigurationGlobalEvent
+	Payload []byte
+
+	// Attempts is how many times publishing the event failed, next attempt is not done before NextAttemptAt
+	Attempts      int
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+}
+
+// Outbox is used by relay to publish event saved by Repository.AddOutboxEvent
+type Outbox interface {
+	// LockOutbox try to take lock of relay without waiting. only one relay of all instances hold it, so event is published once
+	// and in order. locked is false when relay of other instance hold it, unlock must be called when locked is true
+	LockOutbox(ctx context.Context) (unlock func(), locked bool, err error)
+
+	// PendingOutboxEvents return at most limit of event that is due at now and not dead, ordered by ID. event is skipped while
+	// event before it with the same key wait for retry, so key that is backing off never fill the batch
+	PendingOutboxEvents(ctx context.Context, now time.Time, limit int) ([]*OutboxEvent, error)
+
+	// DeleteOutboxEvent remove event that is already published
+	DeleteOutboxEvent(ctx context.Context, id int64) error
+
+	// RetryOutboxEvent record failed attempt of publishing event, it will be attempted again at nextAttemptAt
+	RetryOutboxEvent(ctx context.Context, id int64, nextAttemptAt time.Time, reason string) error
+
+	// DeadOutboxEvent stop publishing event that cannot be published. it is kept with the reason, and event after it with the same key continue
+	DeadOutboxEvent(ctx context.Context, id int64, reason string) error
+}
@@ -0,0 +1,185 @@
+// Package outbox publish event saved in outbox of repository to broker
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/muhammadhidayah/configuration-service/api"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+)
+
+const (
+	defaultBatchSize   = 100
+	defaultMaxAttempts = 20
+	minBackoff         = time.Second
+	maxBackoff         = 5 * time.Minute
+)
+
+// Relay publish event of outbox in order of id. event that failed to be published is retried with backoff,
+// and event after it with the same key wait, so event of one key is never published out of order.
+// event is deleted after published, so it is delivered at least once. relay of every instance can run,
+// only the one that hold lock of outbox publish. event that cannot be decoded or failed max attempts become dead
+type Relay struct {
+	outbox      api.Outbox
+	publisher   api.EventPublisher
+	interval    time.Duration
+	batchSize   int
+	maxAttempts int
+}
+
+// Option configure relay created by NewRelay
+type Option func(*Relay)
+
+// WithMaxAttempts set how many times event is attempted before it become dead
+func WithMaxAttempts(n int) Option {
+	return func(r *Relay) {
+		if n > 0 {
+			r.maxAttempts = n
+		}
+	}
+}
+
+// NewRelay create relay that read outbox every interval
+func NewRelay(outbox api.Outbox, publisher api.EventPublisher, interval time.Duration, opts ...Option) *Relay {
+	r := &Relay{
+		outbox:      outbox,
+		publisher:   publisher,
+		interval:    interval,
+		batchSize:   defaultBatchSize,
+		maxAttempts: defaultMaxAttempts,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Run publish event every interval and every time wake receive value, until ctx is done
+func (r *Relay) Run(ctx context.Context, wake <-chan struct{}) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		// keep publishing while batch is full, there may be more event waiting
+		for {
+			published, err := r.Flush(ctx)
+			if err != nil {
+				log.Printf("Could not relay outbox: %v", err)
+			}
+
+			if err != nil || published < r.batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush publish one batch of pending event and return how many event is published.
+// nothing is published while relay of other instance hold lock of outbox
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	unlock, locked, err := r.outbox.LockOutbox(ctx)
+	if err != nil || !locked {
+		return 0, err
+	}
+	defer unlock()
+
+	now := time.Now()
+	events, err := r.outbox.PendingOutboxEvents(ctx, now, r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+
+	// key that has event failed in this batch, event after it is not published yet
+	blocked := make(map[string]bool)
+	for _, ev := range events {
+		if blocked[ev.Key] {
+			continue
+		}
+
+		msg, err := DecodeEvent(ev.Topic, ev.Payload)
+		if err != nil {
+			// retrying never decode it, event after it with the same key continue
+			log.Printf("Event %d of outbox is dead: %v", ev.ID, err)
+			if err := r.outbox.DeadOutboxEvent(ctx, ev.ID, err.Error()); err != nil {
+				return published, err
+			}
+
+			continue
+		}
+
+		if err := r.publisher.Publish(ctx, ev.Topic, msg); err != nil {
+			if ev.Attempts+1 >= r.maxAttempts {
+				log.Printf("Event %d of outbox is dead after %d attempts: %v", ev.ID, ev.Attempts+1, err)
+				if err := r.outbox.DeadOutboxEvent(ctx, ev.ID, err.Error()); err != nil {
+					return published, err
+				}
+
+				continue
+			}
+
+			blocked[ev.Key] = true
+			if err := r.outbox.RetryOutboxEvent(ctx, ev.ID, now.Add(backoff(ev.Attempts+1)), err.Error()); err != nil {
+				return published, err
+			}
+
+			continue
+		}
+
+		if err := r.outbox.DeleteOutboxEvent(ctx, ev.ID); err != nil {
+			return published, err
+		}
+
+		published++
+	}
+
+	return published, nil
+}
+
+// DecodeEvent unmarshal payload of outbox into pb.ConfigurationClientEvent or pb.ConfigurationGlobalEvent by the topic
+func DecodeEvent(topic string, payload []byte) (proto.Message, error) {
+	var msg proto.Message
+	switch {
+	case strings.HasPrefix(topic, "config.client."):
+		msg = &pb.ConfigurationClientEvent{}
+	case strings.HasPrefix(topic, "config.global."):
+		msg = &pb.ConfigurationGlobalEvent{}
+	default:
+		return nil, fmt.Errorf("Unknown topic of event: %s", topic)
+	}
+
+	if err := proto.Unmarshal(payload, msg); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// backoff return how long to wait before the next attempt, it is doubled every attempt up to maxBackoff
+func backoff(attempts int) time.Duration {
+	wait := minBackoff
+	for i := 1; i < attempts && wait < maxBackoff; i++ {
+		wait *= 2
+	}
+
+	if wait > maxBackoff {
+		wait = maxBackoff
+	}
+
+	return wait
+}
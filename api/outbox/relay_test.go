@@ -0,0 +1,158 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/muhammadhidayah/configuration-service/api"
+	"github.com/muhammadhidayah/configuration-service/api/outbox"
+	"github.com/muhammadhidayah/configuration-service/api/repository"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePublisher keep id of published event, publishing event of topic in failing return error
+type fakePublisher struct {
+	mu        sync.Mutex
+	failing   map[string]bool
+	published []string
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, topic string, event proto.Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.failing[topic] {
+		return errors.New("broker is down")
+	}
+
+	p.published = append(p.published, event.(*pb.ConfigurationClientEvent).Id)
+	return nil
+}
+
+func addEvent(t *testing.T, repo api.Repository, id, topic, key string) *api.OutboxEvent {
+	payload, err := proto.Marshal(&pb.ConfigurationClientEvent{Id: id, Topic: topic})
+	require.NoError(t, err)
+
+	ev := &api.OutboxEvent{Topic: topic, Key: key, Payload: payload}
+	require.NoError(t, repo.AddOutboxEvent(context.TODO(), ev))
+
+	return ev
+}
+
+func TestRelayFlush(t *testing.T) {
+	repo := repository.NewMemoryConfiguration()
+	store := repo.(api.Outbox)
+	publisher := &fakePublisher{failing: map[string]bool{api.TopicClientUpdated: true}}
+	relay := outbox.NewRelay(store, publisher, time.Second)
+
+	first := addEvent(t, repo, "1", api.TopicClientUpdated, "client:a")
+	addEvent(t, repo, "2", api.TopicClientDeleted, "client:a")
+	addEvent(t, repo, "3", api.TopicClientCreated, "client:b")
+
+	// event 1 failed, event 2 of the same key wait for it
+	published, err := relay.Flush(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, []string{"3"}, publisher.published)
+
+	// event 1 is backing off, event 2 is skipped until event 1 is published
+	pending, err := store.PendingOutboxEvents(context.TODO(), time.Now(), 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	pending, err = store.PendingOutboxEvents(context.TODO(), time.Now().Add(time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, 1, pending[0].Attempts)
+	assert.True(t, pending[0].NextAttemptAt.After(time.Now()))
+
+	// broker is back, but event 1 is not due yet
+	publisher.failing = nil
+	published, err = relay.Flush(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, 0, published)
+
+	require.NoError(t, store.RetryOutboxEvent(context.TODO(), first.ID, time.Now().Add(-time.Second), ""))
+	published, err = relay.Flush(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, 2, published)
+	assert.Equal(t, []string{"3", "1", "2"}, publisher.published)
+
+	pending, err = store.PendingOutboxEvents(context.TODO(), time.Now(), 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestRelayDeadEvent(t *testing.T) {
+	repo := repository.NewMemoryConfiguration()
+	store := repo.(api.Outbox)
+	publisher := &fakePublisher{failing: map[string]bool{api.TopicClientUpdated: true}}
+	relay := outbox.NewRelay(store, publisher, time.Second, outbox.WithMaxAttempts(2))
+
+	// unknown topic is never retried, event after it with the same key is published
+	require.NoError(t, repo.AddOutboxEvent(context.TODO(), &api.OutboxEvent{Topic: "config.unknown", Key: "client:a", Payload: []byte{1}}))
+	addEvent(t, repo, "1", api.TopicClientCreated, "client:a")
+
+	published, err := relay.Flush(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, []string{"1"}, publisher.published)
+
+	// event that failed max attempts become dead
+	failing := addEvent(t, repo, "2", api.TopicClientUpdated, "client:b")
+	published, err = relay.Flush(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, 0, published)
+
+	require.NoError(t, store.RetryOutboxEvent(context.TODO(), failing.ID, time.Now().Add(-time.Second), "broker is down"))
+	published, err = relay.Flush(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, 0, published)
+
+	pending, err := store.PendingOutboxEvents(context.TODO(), time.Now().Add(time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestRelayLocked(t *testing.T) {
+	repo := repository.NewMemoryConfiguration()
+	store := repo.(api.Outbox)
+	publisher := &fakePublisher{}
+	relay := outbox.NewRelay(store, publisher, time.Second)
+
+	addEvent(t, repo, "1", api.TopicClientCreated, "client:a")
+
+	// relay of other instance hold the lock
+	unlock, locked, err := store.LockOutbox(context.TODO())
+	require.NoError(t, err)
+	require.True(t, locked)
+
+	published, err := relay.Flush(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, 0, published)
+	assert.Empty(t, publisher.published)
+
+	unlock()
+	published, err = relay.Flush(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, []string{"1"}, publisher.published)
+}
+
+func TestDecodeEvent(t *testing.T) {
+	payload, err := proto.Marshal(&pb.ConfigurationGlobalEvent{Id: "1", After: &pb.ConfigurationGlobal{ConfigGlobalId: 2}})
+	require.NoError(t, err)
+
+	msg, err := outbox.DecodeEvent(api.TopicGlobalActivated, payload)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), msg.(*pb.ConfigurationGlobalEvent).After.ConfigGlobalId)
+
+	_, err = outbox.DecodeEvent("config.unknown", payload)
+	assert.Error(t, err)
+}
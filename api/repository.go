@@ -9,14 +9,56 @@ import (
 type Repository interface {
 	GetConfigurationClient(context.Context) ([]*pb.ConfigurationClient, error)
 	GetConfigurationClientBySubs(context.Context, string) (*pb.ConfigurationClient, error)
+
+	// GetConfigurationClientByUUID return client of config_client_uuid even when it is deleted, like update by uuid
+	GetConfigurationClientByUUID(context.Context, string) (*pb.ConfigurationClient, error)
 	AddConfigurationClient(context.Context, *pb.ConfigurationClient) (bool, error)
 	UpdateConfigurationClientBySubs(context.Context, *pb.ConfigurationClient) (bool, error)
+
+	// UpsertConfigurationClientBySubs create client, or update client that is not deleted and has the same company_subs_id.
+	// it return true when client is created, and set id and uuid of the stored client to cc
+	UpsertConfigurationClientBySubs(context.Context, *pb.ConfigurationClient) (bool, error)
 	DeleteConfigurationClientBySubs(context.Context, *pb.ConfigurationClient) (bool, error)
 
+	// GetDuplicateConfigurationClient return client that is not deleted while older client of the same company_subs_id is not deleted too.
+	// RepairDuplicateConfigurationClient soft delete them, so only the oldest client of company_subs_id is left, and return the deleted client
+	GetDuplicateConfigurationClient(context.Context) ([]*pb.ConfigurationClient, error)
+	RepairDuplicateConfigurationClient(context.Context) ([]*pb.ConfigurationClient, error)
+
 	AddConfigurationGlobal(context.Context, *pb.ConfigurationGlobal) (bool, error)
 	UpdateConfigurationGlobal(context.Context, *pb.ConfigurationGlobal) (bool, error)
 	DeleteConfiguration(context.Context, int32) (bool, error)
 	GetConfigurationGlobal(context.Context) ([]*pb.ConfigurationGlobal, error)
 	GetConfigurationGlobalByID(context.Context, int32) (*pb.ConfigurationGlobal, error)
 	GetConfigurationGlobalActive(context.Context) (*pb.ConfigurationGlobal, error)
+	SetConfigurationGlobalActive(context.Context, int32) (bool, error)
+
+	AddWebhook(context.Context, *pb.Webhook) (bool, error)
+	DeleteWebhook(context.Context, int64) (bool, error)
+	GetWebhooks(context.Context) ([]*pb.Webhook, error)
+
+	// AddWebhookDelivery save delivery with status pending. PendingWebhookDeliveries return pending delivery
+	// that next_attempt_at is not after now, ordered by delivery_id
+	AddWebhookDelivery(context.Context, *pb.WebhookDelivery) error
+	PendingWebhookDeliveries(ctx context.Context, now int64, limit int) ([]*pb.WebhookDelivery, error)
+
+	// ClaimWebhookDelivery postpone pending delivery to until, only when it is not changed since it is read. it return false when
+	// dispatcher of other instance claimed or updated it first, so delivery is posted by one dispatcher
+	ClaimWebhookDelivery(ctx context.Context, d *pb.WebhookDelivery, until int64) (bool, error)
+	UpdateWebhookDelivery(context.Context, *pb.WebhookDelivery) error
+	GetWebhookDeliveries(ctx context.Context, status string) ([]*pb.WebhookDelivery, error)
+
+	// RedeliverWebhookDelivery set dead delivery to be pending again from the first attempt
+	RedeliverWebhookDelivery(ctx context.Context, deliveryID int64, now int64) (bool, error)
+
+	// SyncConfiguration return client and global changed after since revision, tombstone of deleted client and global,
+	// and head revision. every write increase the revision
+	SyncConfiguration(ctx context.Context, since int64) (*pb.ResponseSync, error)
+
+	// AddOutboxEvent save event to be published by relay. it is called inside WithinTx, so event is saved only when the change is committed
+	AddOutboxEvent(context.Context, *OutboxEvent) error
+
+	// WithinTx run fn as one unit of work. every method of Repository passed to fn is part of the same transaction,
+	// it will be committed when fn return nil, and rolled back when fn return error
+	WithinTx(context.Context, func(Repository) error) error
 }
@@ -0,0 +1,398 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/muhammadhidayah/configuration-service/api"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+	"golang.org/x/sync/singleflight"
+)
+
+// prefix of key made by api.ClientKey and api.GlobalKey
+const (
+	cacheKeyClientPrefix = "client:"
+	cacheKeyGlobalPrefix = "global:"
+)
+
+type cacheEntry struct {
+	value   proto.Message
+	expires time.Time
+}
+
+// configurationCache keep result of lookup until ttl passed or it is invalidated
+type configurationCache struct {
+	ttl   time.Duration
+	group singleflight.Group
+
+	mu      sync.RWMutex
+	entries map[string]cacheEntry
+
+	// generation is increased by every invalidation. load started before invalidation does not store its result, so stale data never cached.
+	// generation is part of key of singleflight too, caller after invalidation never join load started before it
+	generation uint64
+}
+
+// this function will return cached value of key, or call fn once for all of concurrent caller and cache its result.
+// fn return nil value when data not found, nil value and error are not cached
+func (c *configurationCache) load(key string, fn func() (proto.Message, error)) (proto.Message, error) {
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	generation := c.generation
+	c.mu.RUnlock()
+
+	if ok && time.Now().Before(entry.expires) {
+		return proto.Clone(entry.value), nil
+	}
+
+	value, err, _ := c.group.Do(key+"@"+strconv.FormatUint(generation, 10), func() (interface{}, error) {
+		value, err := fn()
+		if err != nil || value == nil {
+			return value, err
+		}
+
+		c.mu.Lock()
+		if c.generation == generation {
+			c.entries[key] = cacheEntry{value: value, expires: time.Now().Add(c.ttl)}
+		}
+		c.mu.Unlock()
+
+		return value, nil
+	})
+
+	if err != nil || value == nil {
+		return nil, err
+	}
+
+	// every caller get its own copy, so caller can not change cached value
+	return proto.Clone(value.(proto.Message)), nil
+}
+
+// this function will remove entry of keys, and every entry that start with one of prefixes
+func (c *configurationCache) invalidate(keys []string, prefixes []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+
+	for _, prefix := range prefixes {
+		for key := range c.entries {
+			if strings.HasPrefix(key, prefix) {
+				delete(c.entries, key)
+			}
+		}
+	}
+}
+
+// CacheInvalidator is implemented by repository that cache lookup. key has the same format as payload of NotifyChannel
+type CacheInvalidator interface {
+	InvalidateKey(key string)
+	InvalidateAll()
+}
+
+// cachedConfiguration is api.Repository that cache lookup of client by subs, active global and global by id from next repository.
+// write through it invalidate the matching entries, write inside transaction invalidate after the transaction committed
+type cachedConfiguration struct {
+	next  api.Repository
+	cache *configurationCache
+
+	// pending is not nil inside transaction, it collect invalidation to be applied after commit
+	pending *pendingInvalidation
+}
+
+type pendingInvalidation struct {
+	keys     []string
+	prefixes []string
+}
+
+// NewCachedConfiguration create api.Repository that cache lookup of next for ttl
+func NewCachedConfiguration(next api.Repository, ttl time.Duration) api.Repository {
+	return &cachedConfiguration{
+		next:  next,
+		cache: &configurationCache{ttl: ttl, entries: make(map[string]cacheEntry)},
+	}
+}
+
+func (repo *cachedConfiguration) invalidate(keys []string, prefixes []string) {
+	if repo.pending != nil {
+		repo.pending.keys = append(repo.pending.keys, keys...)
+		repo.pending.prefixes = append(repo.pending.prefixes, prefixes...)
+		return
+	}
+
+	repo.cache.invalidate(keys, prefixes)
+}
+
+// readThrough is true when lookup must not use the cache. lookup inside transaction may see uncommitted data, and
+// request with api.WithReadPrimary need data it just wrote, not the cached one
+func (repo *cachedConfiguration) readThrough(ctx context.Context) bool {
+	return repo.pending != nil || api.IsReadPrimary(ctx)
+}
+
+// this function will run fn in transaction of next repository. lookup inside transaction is not cached, because it may see uncommitted data
+func (repo *cachedConfiguration) WithinTx(ctx context.Context, fn func(api.Repository) error) error {
+	if repo.pending != nil {
+		return fn(repo)
+	}
+
+	pending := &pendingInvalidation{}
+	err := repo.next.WithinTx(ctx, func(txRepo api.Repository) error {
+		return fn(&cachedConfiguration{next: txRepo, cache: repo.cache, pending: pending})
+	})
+
+	if err == nil && (len(pending.keys) > 0 || len(pending.prefixes) > 0) {
+		repo.cache.invalidate(pending.keys, pending.prefixes)
+	}
+
+	return err
+}
+
+func (repo *cachedConfiguration) GetConfigurationClientBySubs(ctx context.Context, clientSubsID string) (*pb.ConfigurationClient, error) {
+	if repo.readThrough(ctx) {
+		return repo.next.GetConfigurationClientBySubs(ctx, clientSubsID)
+	}
+
+	value, err := repo.cache.load(api.ClientKey(clientSubsID), func() (proto.Message, error) {
+		res, err := repo.next.GetConfigurationClientBySubs(ctx, clientSubsID)
+		if err != nil || res == nil {
+			return nil, err
+		}
+
+		return res, nil
+	})
+
+	if err != nil || value == nil {
+		return nil, err
+	}
+
+	return value.(*pb.ConfigurationClient), nil
+}
+
+func (repo *cachedConfiguration) GetConfigurationGlobalActive(ctx context.Context) (*pb.ConfigurationGlobal, error) {
+	if repo.readThrough(ctx) {
+		return repo.next.GetConfigurationGlobalActive(ctx)
+	}
+
+	return repo.loadGlobal(api.GlobalActiveKey, func() (*pb.ConfigurationGlobal, error) {
+		return repo.next.GetConfigurationGlobalActive(ctx)
+	})
+}
+
+func (repo *cachedConfiguration) GetConfigurationGlobalByID(ctx context.Context, configGlobalID int32) (*pb.ConfigurationGlobal, error) {
+	if repo.readThrough(ctx) {
+		return repo.next.GetConfigurationGlobalByID(ctx, configGlobalID)
+	}
+
+	return repo.loadGlobal(api.GlobalKey(configGlobalID), func() (*pb.ConfigurationGlobal, error) {
+		return repo.next.GetConfigurationGlobalByID(ctx, configGlobalID)
+	})
+}
+
+func (repo *cachedConfiguration) loadGlobal(key string, fn func() (*pb.ConfigurationGlobal, error)) (*pb.ConfigurationGlobal, error) {
+	value, err := repo.cache.load(key, func() (proto.Message, error) {
+		res, err := fn()
+		if err != nil || res == nil {
+			return nil, err
+		}
+
+		return res, nil
+	})
+
+	if err != nil || value == nil {
+		return nil, err
+	}
+
+	return value.(*pb.ConfigurationGlobal), nil
+}
+
+// lookup by uuid is only used before and after update, it is not cached
+func (repo *cachedConfiguration) GetConfigurationClientByUUID(ctx context.Context, configClientUUID string) (*pb.ConfigurationClient, error) {
+	return repo.next.GetConfigurationClientByUUID(ctx, configClientUUID)
+}
+
+func (repo *cachedConfiguration) GetConfigurationClient(ctx context.Context) ([]*pb.ConfigurationClient, error) {
+	return repo.next.GetConfigurationClient(ctx)
+}
+
+func (repo *cachedConfiguration) GetConfigurationGlobal(ctx context.Context) ([]*pb.ConfigurationGlobal, error) {
+	return repo.next.GetConfigurationGlobal(ctx)
+}
+
+func (repo *cachedConfiguration) AddConfigurationClient(ctx context.Context, cc *pb.ConfigurationClient) (bool, error) {
+	defer repo.invalidate([]string{api.ClientKey(cc.CompanySubsId)}, nil)
+
+	return repo.next.AddConfigurationClient(ctx, cc)
+}
+
+func (repo *cachedConfiguration) UpsertConfigurationClientBySubs(ctx context.Context, cc *pb.ConfigurationClient) (bool, error) {
+	defer repo.invalidate([]string{api.ClientKey(cc.CompanySubsId)}, nil)
+
+	return repo.next.UpsertConfigurationClientBySubs(ctx, cc)
+}
+
+// client is updated by uuid and company_subs_id can be changed, so entries of all client are invalidated
+func (repo *cachedConfiguration) UpdateConfigurationClientBySubs(ctx context.Context, cc *pb.ConfigurationClient) (bool, error) {
+	defer repo.invalidate(nil, []string{cacheKeyClientPrefix})
+
+	return repo.next.UpdateConfigurationClientBySubs(ctx, cc)
+}
+
+func (repo *cachedConfiguration) DeleteConfigurationClientBySubs(ctx context.Context, cc *pb.ConfigurationClient) (bool, error) {
+	defer repo.invalidate([]string{api.ClientKey(cc.CompanySubsId)}, nil)
+
+	return repo.next.DeleteConfigurationClientBySubs(ctx, cc)
+}
+
+func (repo *cachedConfiguration) GetDuplicateConfigurationClient(ctx context.Context) ([]*pb.ConfigurationClient, error) {
+	return repo.next.GetDuplicateConfigurationClient(ctx)
+}
+
+// repair is rare, so all of cached client is evicted instead of the repaired company_subs_id
+func (repo *cachedConfiguration) RepairDuplicateConfigurationClient(ctx context.Context) ([]*pb.ConfigurationClient, error) {
+	defer repo.invalidate(nil, []string{cacheKeyClientPrefix})
+
+	return repo.next.RepairDuplicateConfigurationClient(ctx)
+}
+
+func (repo *cachedConfiguration) AddConfigurationGlobal(ctx context.Context, cg *pb.ConfigurationGlobal) (bool, error) {
+	return repo.next.AddConfigurationGlobal(ctx, cg)
+}
+
+func (repo *cachedConfiguration) UpdateConfigurationGlobal(ctx context.Context, cg *pb.ConfigurationGlobal) (bool, error) {
+	defer repo.invalidate([]string{api.GlobalKey(cg.ConfigGlobalId), api.GlobalActiveKey}, nil)
+
+	return repo.next.UpdateConfigurationGlobal(ctx, cg)
+}
+
+func (repo *cachedConfiguration) DeleteConfiguration(ctx context.Context, configGlobalID int32) (bool, error) {
+	defer repo.invalidate([]string{api.GlobalKey(configGlobalID), api.GlobalActiveKey}, nil)
+
+	return repo.next.DeleteConfiguration(ctx, configGlobalID)
+}
+
+// activation change is_active of other global too, so entries of all global are invalidated
+func (repo *cachedConfiguration) SetConfigurationGlobalActive(ctx context.Context, configGlobalID int32) (bool, error) {
+	defer repo.invalidate(nil, []string{cacheKeyGlobalPrefix})
+
+	return repo.next.SetConfigurationGlobalActive(ctx, configGlobalID)
+}
+
+// webhook and its delivery are never cached, they are only passed to next repository
+func (repo *cachedConfiguration) AddWebhook(ctx context.Context, wh *pb.Webhook) (bool, error) {
+	return repo.next.AddWebhook(ctx, wh)
+}
+
+func (repo *cachedConfiguration) DeleteWebhook(ctx context.Context, webhookID int64) (bool, error) {
+	return repo.next.DeleteWebhook(ctx, webhookID)
+}
+
+func (repo *cachedConfiguration) GetWebhooks(ctx context.Context) ([]*pb.Webhook, error) {
+	return repo.next.GetWebhooks(ctx)
+}
+
+func (repo *cachedConfiguration) AddWebhookDelivery(ctx context.Context, d *pb.WebhookDelivery) error {
+	return repo.next.AddWebhookDelivery(ctx, d)
+}
+
+func (repo *cachedConfiguration) PendingWebhookDeliveries(ctx context.Context, now int64, limit int) ([]*pb.WebhookDelivery, error) {
+	return repo.next.PendingWebhookDeliveries(ctx, now, limit)
+}
+
+func (repo *cachedConfiguration) ClaimWebhookDelivery(ctx context.Context, d *pb.WebhookDelivery, until int64) (bool, error) {
+	return repo.next.ClaimWebhookDelivery(ctx, d, until)
+}
+
+func (repo *cachedConfiguration) UpdateWebhookDelivery(ctx context.Context, d *pb.WebhookDelivery) error {
+	return repo.next.UpdateWebhookDelivery(ctx, d)
+}
+
+func (repo *cachedConfiguration) GetWebhookDeliveries(ctx context.Context, status string) ([]*pb.WebhookDelivery, error) {
+	return repo.next.GetWebhookDeliveries(ctx, status)
+}
+
+func (repo *cachedConfiguration) RedeliverWebhookDelivery(ctx context.Context, deliveryID int64, now int64) (bool, error) {
+	return repo.next.RedeliverWebhookDelivery(ctx, deliveryID, now)
+}
+
+// sync is not cached, revision window is different for every caller
+func (repo *cachedConfiguration) SyncConfiguration(ctx context.Context, since int64) (*pb.ResponseSync, error) {
+	return repo.next.SyncConfiguration(ctx, since)
+}
+
+// event of outbox is never cached, it is only passed to next repository
+func (repo *cachedConfiguration) AddOutboxEvent(ctx context.Context, ev *api.OutboxEvent) error {
+	return repo.next.AddOutboxEvent(ctx, ev)
+}
+
+func (repo *cachedConfiguration) outbox() (api.Outbox, error) {
+	outbox, ok := repo.next.(api.Outbox)
+	if !ok {
+		return nil, errors.New("Repository has no outbox")
+	}
+
+	return outbox, nil
+}
+
+func (repo *cachedConfiguration) LockOutbox(ctx context.Context) (func(), bool, error) {
+	outbox, err := repo.outbox()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return outbox.LockOutbox(ctx)
+}
+
+func (repo *cachedConfiguration) PendingOutboxEvents(ctx context.Context, now time.Time, limit int) ([]*api.OutboxEvent, error) {
+	outbox, err := repo.outbox()
+	if err != nil {
+		return nil, err
+	}
+
+	return outbox.PendingOutboxEvents(ctx, now, limit)
+}
+
+func (repo *cachedConfiguration) DeleteOutboxEvent(ctx context.Context, id int64) error {
+	outbox, err := repo.outbox()
+	if err != nil {
+		return err
+	}
+
+	return outbox.DeleteOutboxEvent(ctx, id)
+}
+
+func (repo *cachedConfiguration) RetryOutboxEvent(ctx context.Context, id int64, nextAttemptAt time.Time, reason string) error {
+	outbox, err := repo.outbox()
+	if err != nil {
+		return err
+	}
+
+	return outbox.RetryOutboxEvent(ctx, id, nextAttemptAt, reason)
+}
+
+func (repo *cachedConfiguration) DeadOutboxEvent(ctx context.Context, id int64, reason string) error {
+	outbox, err := repo.outbox()
+	if err != nil {
+		return err
+	}
+
+	return outbox.DeadOutboxEvent(ctx, id, reason)
+}
+
+// InvalidateKey evict cached entry of key
+func (repo *cachedConfiguration) InvalidateKey(key string) {
+	repo.cache.invalidate([]string{key}, nil)
+}
+
+// InvalidateAll evict all of cached entry
+func (repo *cachedConfiguration) InvalidateAll() {
+	repo.cache.invalidate(nil, []string{""})
+}
@@ -0,0 +1,253 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+	"github.com/muhammadhidayah/configuration-service/api/mocks"
+	repo "github.com/muhammadhidayah/configuration-service/api/repository"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCachedConfigurationClientBySubs(t *testing.T) {
+	mockConfigClient := &pb.ConfigurationClient{
+		ConfigClientId:   1,
+		ConfigClientUuid: "a6e2745e-c930-4717-a9d1-d1cfb2a64aa4",
+		ReportTitle:      "Client Satu",
+		CompanySubsId:    "012-031-234-542",
+	}
+
+	t.Run("Second lookup is served from cache", func(t *testing.T) {
+		mockConfigRepo := new(mocks.Repository)
+		mockConfigRepo.On("GetConfigurationClientBySubs", mock.Anything, "012-031-234-542").Return(mockConfigClient, nil).Once()
+
+		cachedRepo := repo.NewCachedConfiguration(mockConfigRepo, time.Minute)
+		for i := 0; i < 3; i++ {
+			res, err := cachedRepo.GetConfigurationClientBySubs(context.TODO(), "012-031-234-542")
+			assert.NoError(t, err)
+			assert.Equal(t, "Client Satu", res.ReportTitle)
+
+			// caller change its copy, cached value stay the same
+			res.ReportTitle = "Changed by caller"
+		}
+
+		mockConfigRepo.AssertExpectations(t)
+	})
+
+	t.Run("Error is not cached", func(t *testing.T) {
+		mockConfigRepo := new(mocks.Repository)
+		mockConfigRepo.On("GetConfigurationClientBySubs", mock.Anything, "012-031-234-542").Return(nil, errors.New("Data Not Found")).Twice()
+
+		cachedRepo := repo.NewCachedConfiguration(mockConfigRepo, time.Minute)
+		for i := 0; i < 2; i++ {
+			res, err := cachedRepo.GetConfigurationClientBySubs(context.TODO(), "012-031-234-542")
+			assert.Error(t, err)
+			assert.Nil(t, res)
+		}
+
+		mockConfigRepo.AssertExpectations(t)
+	})
+
+	t.Run("Entry expire after ttl", func(t *testing.T) {
+		mockConfigRepo := new(mocks.Repository)
+		mockConfigRepo.On("GetConfigurationClientBySubs", mock.Anything, "012-031-234-542").Return(mockConfigClient, nil).Twice()
+
+		cachedRepo := repo.NewCachedConfiguration(mockConfigRepo, 20*time.Millisecond)
+		_, err := cachedRepo.GetConfigurationClientBySubs(context.TODO(), "012-031-234-542")
+		assert.NoError(t, err)
+
+		time.Sleep(30 * time.Millisecond)
+
+		_, err = cachedRepo.GetConfigurationClientBySubs(context.TODO(), "012-031-234-542")
+		assert.NoError(t, err)
+
+		mockConfigRepo.AssertExpectations(t)
+	})
+
+	t.Run("Write invalidate entry", func(t *testing.T) {
+		mockConfigRepo := new(mocks.Repository)
+		mockConfigRepo.On("GetConfigurationClientBySubs", mock.Anything, "012-031-234-542").Return(mockConfigClient, nil).Twice()
+		mockConfigRepo.On("UpdateConfigurationClientBySubs", mock.Anything, mock.Anything).Return(true, nil).Once()
+
+		cachedRepo := repo.NewCachedConfiguration(mockConfigRepo, time.Minute)
+		_, err := cachedRepo.GetConfigurationClientBySubs(context.TODO(), "012-031-234-542")
+		assert.NoError(t, err)
+
+		_, err = cachedRepo.UpdateConfigurationClientBySubs(context.TODO(), &pb.ConfigurationClient{ConfigClientUuid: mockConfigClient.ConfigClientUuid, CompanySubsId: "012-031-234-999"})
+		assert.NoError(t, err)
+
+		_, err = cachedRepo.GetConfigurationClientBySubs(context.TODO(), "012-031-234-542")
+		assert.NoError(t, err)
+
+		mockConfigRepo.AssertExpectations(t)
+	})
+
+	t.Run("Read primary is not served from cache", func(t *testing.T) {
+		mockConfigRepo := new(mocks.Repository)
+		mockConfigRepo.On("GetConfigurationClientBySubs", mock.Anything, "012-031-234-542").Return(mockConfigClient, nil).Times(3)
+
+		cachedRepo := repo.NewCachedConfiguration(mockConfigRepo, time.Minute)
+		_, err := cachedRepo.GetConfigurationClientBySubs(context.TODO(), "012-031-234-542")
+		assert.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			_, err := cachedRepo.GetConfigurationClientBySubs(api.WithReadPrimary(context.TODO()), "012-031-234-542")
+			assert.NoError(t, err)
+		}
+
+		mockConfigRepo.AssertExpectations(t)
+	})
+
+	t.Run("Lookup after invalidation does not join load started before it", func(t *testing.T) {
+		updatedConfigClient := &pb.ConfigurationClient{ConfigClientId: 1, CompanySubsId: "012-031-234-542", ReportTitle: "Client Updated"}
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+		mockConfigRepo := new(mocks.Repository)
+		mockConfigRepo.On("GetConfigurationClientBySubs", mock.Anything, "012-031-234-542").Return(mockConfigClient, nil).Run(func(mock.Arguments) {
+			close(started)
+			<-release
+		}).Once()
+		mockConfigRepo.On("GetConfigurationClientBySubs", mock.Anything, "012-031-234-542").Return(updatedConfigClient, nil).Once()
+
+		cachedRepo := repo.NewCachedConfiguration(mockConfigRepo, time.Minute)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			cachedRepo.GetConfigurationClientBySubs(context.TODO(), "012-031-234-542")
+		}()
+
+		<-started
+		cachedRepo.(repo.CacheInvalidator).InvalidateKey(api.ClientKey("012-031-234-542"))
+
+		// lookup that join the old load would wait until it is released
+		result := make(chan *pb.ConfigurationClient, 1)
+		go func() {
+			res, err := cachedRepo.GetConfigurationClientBySubs(context.TODO(), "012-031-234-542")
+			assert.NoError(t, err)
+			result <- res
+		}()
+
+		select {
+		case res := <-result:
+			assert.Equal(t, "Client Updated", res.ReportTitle)
+		case <-time.After(time.Second):
+			t.Error("lookup after invalidation joined load started before it")
+		}
+
+		close(release)
+		<-done
+
+		res, err := cachedRepo.GetConfigurationClientBySubs(context.TODO(), "012-031-234-542")
+		assert.NoError(t, err)
+		assert.Equal(t, "Client Updated", res.ReportTitle)
+
+		mockConfigRepo.AssertExpectations(t)
+	})
+
+	t.Run("Concurrent miss of same key call repository once", func(t *testing.T) {
+		mockConfigRepo := new(mocks.Repository)
+		mockConfigRepo.On("GetConfigurationClientBySubs", mock.Anything, "012-031-234-542").Return(mockConfigClient, nil).After(50 * time.Millisecond).Once()
+
+		cachedRepo := repo.NewCachedConfiguration(mockConfigRepo, time.Minute)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				res, err := cachedRepo.GetConfigurationClientBySubs(context.TODO(), "012-031-234-542")
+				assert.NoError(t, err)
+				assert.Equal(t, int64(1), res.ConfigClientId)
+			}()
+		}
+		wg.Wait()
+
+		mockConfigRepo.AssertExpectations(t)
+	})
+}
+
+func TestCachedConfigurationGlobal(t *testing.T) {
+	mockConfigGlobal := &pb.ConfigurationGlobal{ConfigGlobalId: 3, ServerSmpt: "mail.google.com", IsActive: true}
+
+	t.Run("Not found global is not cached", func(t *testing.T) {
+		mockConfigRepo := new(mocks.Repository)
+		mockConfigRepo.On("GetConfigurationGlobalByID", mock.Anything, int32(4)).Return(nil, nil).Twice()
+
+		cachedRepo := repo.NewCachedConfiguration(mockConfigRepo, time.Minute)
+		for i := 0; i < 2; i++ {
+			res, err := cachedRepo.GetConfigurationGlobalByID(context.TODO(), 4)
+			assert.NoError(t, err)
+			assert.Nil(t, res)
+		}
+
+		mockConfigRepo.AssertExpectations(t)
+	})
+
+	t.Run("Activation invalidate active and by id entries", func(t *testing.T) {
+		mockConfigRepo := new(mocks.Repository)
+		mockConfigRepo.On("GetConfigurationGlobalActive", mock.Anything).Return(mockConfigGlobal, nil).Twice()
+		mockConfigRepo.On("GetConfigurationGlobalByID", mock.Anything, int32(3)).Return(mockConfigGlobal, nil).Twice()
+		mockConfigRepo.On("SetConfigurationGlobalActive", mock.Anything, int32(5)).Return(true, nil).Once()
+
+		cachedRepo := repo.NewCachedConfiguration(mockConfigRepo, time.Minute)
+		for i := 0; i < 2; i++ {
+			_, err := cachedRepo.GetConfigurationGlobalActive(context.TODO())
+			assert.NoError(t, err)
+			_, err = cachedRepo.GetConfigurationGlobalByID(context.TODO(), 3)
+			assert.NoError(t, err)
+		}
+
+		_, err := cachedRepo.SetConfigurationGlobalActive(context.TODO(), 5)
+		assert.NoError(t, err)
+
+		_, err = cachedRepo.GetConfigurationGlobalActive(context.TODO())
+		assert.NoError(t, err)
+		_, err = cachedRepo.GetConfigurationGlobalByID(context.TODO(), 3)
+		assert.NoError(t, err)
+
+		mockConfigRepo.AssertExpectations(t)
+	})
+
+	t.Run("Write inside transaction invalidate after commit only", func(t *testing.T) {
+		mockConfigRepo := new(mocks.Repository)
+		withinTx := func(ctx context.Context, fn func(api.Repository) error) error { return fn(mockConfigRepo) }
+
+		mockConfigRepo.On("GetConfigurationGlobalActive", mock.Anything).Return(mockConfigGlobal, nil).Twice()
+		mockConfigRepo.On("WithinTx", mock.Anything, mock.Anything).Return(withinTx).Twice()
+		mockConfigRepo.On("SetConfigurationGlobalActive", mock.Anything, int32(5)).Return(true, nil).Twice()
+
+		cachedRepo := repo.NewCachedConfiguration(mockConfigRepo, time.Minute)
+		_, err := cachedRepo.GetConfigurationGlobalActive(context.TODO())
+		assert.NoError(t, err)
+
+		// rolled back transaction keep the cache
+		err = cachedRepo.WithinTx(context.TODO(), func(txRepo api.Repository) error {
+			txRepo.SetConfigurationGlobalActive(context.TODO(), 5)
+			return errors.New("rollback")
+		})
+		assert.Error(t, err)
+
+		_, err = cachedRepo.GetConfigurationGlobalActive(context.TODO())
+		assert.NoError(t, err)
+
+		err = cachedRepo.WithinTx(context.TODO(), func(txRepo api.Repository) error {
+			_, err := txRepo.SetConfigurationGlobalActive(context.TODO(), 5)
+			return err
+		})
+		assert.NoError(t, err)
+
+		_, err = cachedRepo.GetConfigurationGlobalActive(context.TODO())
+		assert.NoError(t, err)
+
+		mockConfigRepo.AssertExpectations(t)
+	})
+}
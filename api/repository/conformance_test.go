@@ -0,0 +1,115 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+	repo "github.com/muhammadhidayah/configuration-service/api/repository"
+	"github.com/muhammadhidayah/configuration-service/api/repository/repotest"
+	"github.com/muhammadhidayah/configuration-service/migration"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+)
+
+func TestMemoryConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) api.Repository {
+		return repo.NewMemoryConfiguration()
+	}, repotest.WithAddDuplicate(func(t *testing.T, r api.Repository, cc *pb.ConfigurationClient) {
+		repo.AddMemoryDuplicateClient(r, cc)
+	}))
+}
+
+func TestCachedConformance(t *testing.T) {
+	// memory repository under the cache, duplicate is added directly to it
+	memories := map[api.Repository]api.Repository{}
+
+	repotest.Run(t, func(t *testing.T) api.Repository {
+		memory := repo.NewMemoryConfiguration()
+		cached := repo.NewCachedConfiguration(memory, time.Minute)
+		memories[cached] = memory
+
+		return cached
+	}, repotest.WithAddDuplicate(func(t *testing.T, r api.Repository, cc *pb.ConfigurationClient) {
+		repo.AddMemoryDuplicateClient(memories[r], cc)
+	}))
+}
+
+func TestSqliteConformance(t *testing.T) {
+	dbs := make(map[api.Repository]*sql.DB)
+	defer func() {
+		for _, db := range dbs {
+			db.Close()
+		}
+	}()
+
+	repotest.Run(t, func(t *testing.T) api.Repository {
+		db := newSqliteDB(t)
+		r := repo.NewSqliteConfiguration(db)
+		dbs[r] = db
+
+		return r
+	}, repotest.WithAddDuplicate(func(t *testing.T, r api.Repository, cc *pb.ConfigurationClient) {
+		// duplicate can only exist in database created before the unique index
+		if _, err := dbs[r].Exec("DROP INDEX IF EXISTS configuration_client_company_subs_id_uq"); err != nil {
+			t.Fatalf("an error '%s' was not expected when dropping unique index", err)
+		}
+
+		if _, err := r.AddConfigurationClient(context.TODO(), cc); err != nil {
+			t.Fatalf("an error '%s' was not expected when adding duplicate client", err)
+		}
+	}))
+}
+
+// TestPostgresConformance run only when TEST_POSTGRES_DSN is set. data in the database will be deleted
+func TestPostgresConformance(t *testing.T) {
+	db := openConformanceDB(t, "postgres", "TEST_POSTGRES_DSN", migration.PostgresDialect, migration.Postgres)
+	defer db.Close()
+
+	repotest.Run(t, func(t *testing.T) api.Repository {
+		truncateConfiguration(t, db)
+		return repo.NewPgConfiguration(db)
+	})
+}
+
+// TestMysqlConformance run only when TEST_MYSQL_DSN is set, the DSN must contain parseTime=true. data in the database will be deleted
+func TestMysqlConformance(t *testing.T) {
+	db := openConformanceDB(t, "mysql", "TEST_MYSQL_DSN", migration.MySQLDialect, migration.MySQL)
+	defer db.Close()
+
+	repotest.Run(t, func(t *testing.T) api.Repository {
+		truncateConfiguration(t, db)
+		return repo.NewMysqlConfiguration(db)
+	})
+}
+
+// openConformanceDB open database from DSN in environment env and migrate it, the test is skipped when env is empty
+func openConformanceDB(t *testing.T, driver, env string, dialect migration.Dialect, migrations []migration.Migration) *sql.DB {
+	dsn := os.Getenv(env)
+	if dsn == "" {
+		t.Skipf("%s is not set", env)
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening %s database", err, driver)
+	}
+
+	if _, err := migration.NewMigrator(db, dialect, migrations).Up(context.TODO()); err != nil {
+		db.Close()
+		t.Fatalf("an error '%s' was not expected when migrating %s database", err, driver)
+	}
+
+	return db
+}
+
+// revision is not reset, sync test only rely on revision increased after its own change
+func truncateConfiguration(t *testing.T, db *sql.DB) {
+	for _, table := range []string{"configuration_client", "configuration_global", "configuration_global_tombstone", "outbox", "webhook", "webhook_delivery"} {
+		if _, err := db.Exec("DELETE FROM " + table); err != nil {
+			t.Fatalf("an error '%s' was not expected when cleaning table %s", err, table)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"strconv"
+	"strings"
+)
+
+// dialect describe how query is written for one database. query in this package is written with placeholder ? and identifier quoted with ",
+// then rebind to placeholder and identifier quote of the database
+type dialect struct {
+	name string
+
+	// placeholder return bind variable for argument n, start from 1
+	placeholder func(n int) string
+
+	// quote is character to quote identifier that is reserved word, like ssl in mysql
+	quote rune
+
+	// returning is true when database support INSERT ... RETURNING, otherwise id of inserted row is taken from LastInsertId
+	returning bool
+
+	// uniqueViolation is true when err is violation of unique index or primary key
+	uniqueViolation func(err error) bool
+
+	// relayLock take lock of outbox relay without waiting and return whether it is taken, relayUnlock release it.
+	// both is empty when database need no lock, sqlite is only used by one process
+	relayLock   string
+	relayUnlock string
+
+	// onConflict return clause of INSERT that update columns to the inserted value when row with the same unique key exists.
+	// target is conflict target of postgres and sqlite, mysql update on any unique key
+	onConflict func(target string, columns []string) string
+}
+
+var (
+	postgresDialect = dialect{
+		name:            "postgres",
+		placeholder:     func(n int) string { return "$" + strconv.Itoa(n) },
+		quote:           '"',
+		returning:       true,
+		uniqueViolation: pgUniqueViolation,
+		relayLock:       "SELECT pg_try_advisory_lock(7317100517)",
+		relayUnlock:     "SELECT pg_advisory_unlock(7317100517)",
+		onConflict:      onConflictDoUpdate,
+	}
+
+	mysqlDialect = dialect{
+		name:            "mysql",
+		placeholder:     func(n int) string { return "?" },
+		quote:           '`',
+		uniqueViolation: mysqlUniqueViolation,
+		relayLock:       "SELECT GET_LOCK('configuration-service-outbox', 0)",
+		relayUnlock:     "SELECT RELEASE_LOCK('configuration-service-outbox')",
+		onConflict:      onDuplicateKeyUpdate,
+	}
+
+	sqliteDialect = dialect{
+		name:            "sqlite",
+		placeholder:     func(n int) string { return "?" },
+		quote:           '"',
+		uniqueViolation: sqliteUniqueViolation,
+		onConflict:      onConflictDoUpdate,
+	}
+)
+
+// this function will replace every placeholder ? in query with placeholder of dialect, and every " with identifier quote of dialect
+func (d dialect) rebind(query string) string {
+	if d.placeholder(1) == "?" && d.quote == '"' {
+		return query
+	}
+
+	var builder strings.Builder
+	n := 0
+	for _, char := range query {
+		switch char {
+		case '?':
+			n++
+			builder.WriteString(d.placeholder(n))
+		case '"':
+			builder.WriteRune(d.quote)
+		default:
+			builder.WriteRune(char)
+		}
+	}
+
+	return builder.String()
+}
+
+// onConflictDoUpdate is upsert of postgres and sqlite, excluded is the row that failed to be inserted
+func onConflictDoUpdate(target string, columns []string) string {
+	set := make([]string, 0, len(columns))
+	for _, column := range columns {
+		set = append(set, column+" = excluded."+column)
+	}
+
+	return " ON CONFLICT " + target + " DO UPDATE SET " + strings.Join(set, ", ")
+}
+
+// onDuplicateKeyUpdate is upsert of mysql, VALUES(column) is the value that failed to be inserted
+func onDuplicateKeyUpdate(target string, columns []string) string {
+	set := make([]string, 0, len(columns))
+	for _, column := range columns {
+		set = append(set, column+" = VALUES("+column+")")
+	}
+
+	return " ON DUPLICATE KEY UPDATE " + strings.Join(set, ", ")
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+)
+
+// this function will change error of database driver into error of kind in api. unique violation become api.ErrAlreadyExists,
+// and connection failure become api.ErrUnavailable. other error is returned as is
+func (d dialect) translate(err error) error {
+	if err == nil || api.KindOf(err) != nil {
+		return err
+	}
+
+	if d.uniqueViolation != nil && d.uniqueViolation(err) {
+		return api.WrapError(api.ErrAlreadyExists, "Data Already Exists", err)
+	}
+
+	var netErr net.Error
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) || errors.Is(err, errStmtCacheClosed) || errors.As(err, &netErr) {
+		return api.WrapError(api.ErrUnavailable, "Database Unavailable", err)
+	}
+
+	return err
+}
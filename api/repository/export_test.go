@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/muhammadhidayah/configuration-service/api"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+)
+
+// AddMemoryDuplicateClient add cc to memory repository r like AddConfigurationClient, without checking company_subs_id of client that is not deleted
+func AddMemoryDuplicateClient(r api.Repository, cc *pb.ConfigurationClient) {
+	repo := r.(*memoryConfiguration)
+	repo.writeRevision(func(store *memoryStore, revision int64) error {
+		store.lastClientID++
+		store.clientRevisions[store.lastClientID] = revision
+
+		row := proto.Clone(cc).(*pb.ConfigurationClient)
+		row.ConfigClientId = store.lastClientID
+		row.Revision = revision
+		store.clients = append(store.clients, row)
+		cc.ConfigClientId = row.ConfigClientId
+
+		return nil
+	})
+}
@@ -0,0 +1,603 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/muhammadhidayah/configuration-service/api"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+)
+
+// memoryStore contain all rows of configuration_client and configuration_global, ordered by id like table in database
+type memoryStore struct {
+	clients      []*pb.ConfigurationClient
+	globals      []*pb.ConfigurationGlobal
+	lastClientID int64
+	lastGlobalID int32
+
+	outbox       []*api.OutboxEvent
+	lastOutboxID int64
+
+	// deadOutbox is reason of event that is dead by id
+	deadOutbox map[int64]string
+
+	webhooks       []*pb.Webhook
+	deliveries     []*pb.WebhookDelivery
+	lastWebhookID  int64
+	lastDeliveryID int64
+
+	// revision is head revision, clientRevisions and globalRevisions is revision of row by id
+	revision         int64
+	clientRevisions  map[int64]int64
+	globalRevisions  map[int32]int64
+	globalTombstones []*pb.GlobalTombstone
+}
+
+func newMemoryStore() *memoryStore {
+	// same as configuration_revision after migration
+	return &memoryStore{revision: 1, clientRevisions: map[int64]int64{}, globalRevisions: map[int32]int64{}, deadOutbox: map[int64]string{}}
+}
+
+func (store *memoryStore) clone() *memoryStore {
+	cloned := &memoryStore{
+		clients:      make([]*pb.ConfigurationClient, 0, len(store.clients)),
+		globals:      make([]*pb.ConfigurationGlobal, 0, len(store.globals)),
+		lastClientID: store.lastClientID,
+		lastGlobalID: store.lastGlobalID,
+		outbox:       make([]*api.OutboxEvent, 0, len(store.outbox)),
+		lastOutboxID: store.lastOutboxID,
+		deadOutbox:   make(map[int64]string, len(store.deadOutbox)),
+
+		webhooks:       make([]*pb.Webhook, 0, len(store.webhooks)),
+		deliveries:     make([]*pb.WebhookDelivery, 0, len(store.deliveries)),
+		lastWebhookID:  store.lastWebhookID,
+		lastDeliveryID: store.lastDeliveryID,
+
+		revision:         store.revision,
+		clientRevisions:  make(map[int64]int64, len(store.clientRevisions)),
+		globalRevisions:  make(map[int32]int64, len(store.globalRevisions)),
+		globalTombstones: make([]*pb.GlobalTombstone, 0, len(store.globalTombstones)),
+	}
+
+	for id, reason := range store.deadOutbox {
+		cloned.deadOutbox[id] = reason
+	}
+
+	for id, rev := range store.clientRevisions {
+		cloned.clientRevisions[id] = rev
+	}
+
+	for id, rev := range store.globalRevisions {
+		cloned.globalRevisions[id] = rev
+	}
+
+	for _, t := range store.globalTombstones {
+		cloned.globalTombstones = append(cloned.globalTombstones, proto.Clone(t).(*pb.GlobalTombstone))
+	}
+
+	for _, cc := range store.clients {
+		cloned.clients = append(cloned.clients, proto.Clone(cc).(*pb.ConfigurationClient))
+	}
+
+	for _, cg := range store.globals {
+		cloned.globals = append(cloned.globals, proto.Clone(cg).(*pb.ConfigurationGlobal))
+	}
+
+	for _, ev := range store.outbox {
+		copied := *ev
+		cloned.outbox = append(cloned.outbox, &copied)
+	}
+
+	for _, wh := range store.webhooks {
+		cloned.webhooks = append(cloned.webhooks, proto.Clone(wh).(*pb.Webhook))
+	}
+
+	for _, d := range store.deliveries {
+		cloned.deliveries = append(cloned.deliveries, proto.Clone(d).(*pb.WebhookDelivery))
+	}
+
+	return cloned
+}
+
+// memoryConfiguration is api.Repository that keep data in memory. it has same behavior with pgConfiguration, and safe to be used by many goroutine
+type memoryConfiguration struct {
+	mu    *sync.RWMutex
+	store *memoryStore
+
+	// inTx is true when repository used inside WithinTx, lock already held by WithinTx
+	inTx bool
+
+	// relayLocked is 1 while relay of outbox hold the lock, it is shared with repository of transaction
+	relayLocked *int32
+}
+
+func NewMemoryConfiguration() api.Repository {
+	return &memoryConfiguration{
+		mu:          &sync.RWMutex{},
+		store:       newMemoryStore(),
+		relayLocked: new(int32),
+	}
+}
+
+// read call fn while holding read lock
+func (repo *memoryConfiguration) read(fn func(*memoryStore)) {
+	if !repo.inTx {
+		repo.mu.RLock()
+		defer repo.mu.RUnlock()
+	}
+
+	fn(repo.store)
+}
+
+// write call fn while holding write lock
+func (repo *memoryConfiguration) write(fn func(*memoryStore) error) error {
+	if !repo.inTx {
+		repo.mu.Lock()
+		defer repo.mu.Unlock()
+	}
+
+	return fn(repo.store)
+}
+
+// writeRevision call fn with the next revision while holding write lock. head revision is only increased when fn return nil
+func (repo *memoryConfiguration) writeRevision(fn func(store *memoryStore, revision int64) error) error {
+	return repo.write(func(store *memoryStore) error {
+		if err := fn(store, store.revision+1); err != nil {
+			return err
+		}
+
+		store.revision++
+		return nil
+	})
+}
+
+// this function will run fn on copy of data. the copy replace the data when fn return nil, and will be dropped when fn return error
+func (repo *memoryConfiguration) WithinTx(ctx context.Context, fn func(api.Repository) error) error {
+	// already inside transaction, so fn just join the transaction
+	if repo.inTx {
+		return fn(repo)
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	txRepo := &memoryConfiguration{mu: repo.mu, store: repo.store.clone(), inTx: true, relayLocked: repo.relayLocked}
+	if err := fn(txRepo); err != nil {
+		return err
+	}
+
+	*repo.store = *txRepo.store
+
+	return nil
+}
+
+func (repo *memoryConfiguration) AddConfigurationClient(ctx context.Context, cc *pb.ConfigurationClient) (bool, error) {
+	err := repo.writeRevision(func(store *memoryStore, revision int64) error {
+		// same as unique index of company_subs_id for client that is not deleted
+		if store.activeClient(cc.CompanySubsId, 0) != nil {
+			return api.Errorf(api.ErrAlreadyExists, "Data Already Exists")
+		}
+
+		store.lastClientID++
+		store.clientRevisions[store.lastClientID] = revision
+
+		row := proto.Clone(cc).(*pb.ConfigurationClient)
+		row.ConfigClientId = store.lastClientID
+		row.Revision = revision
+		store.clients = append(store.clients, row)
+		cc.ConfigClientId = row.ConfigClientId
+
+		return nil
+	})
+
+	return err == nil, err
+}
+
+func (repo *memoryConfiguration) UpsertConfigurationClientBySubs(ctx context.Context, cc *pb.ConfigurationClient) (bool, error) {
+	var created bool
+	err := repo.writeRevision(func(store *memoryStore, revision int64) error {
+		if row := store.activeClient(cc.CompanySubsId, 0); row != nil {
+			store.clientRevisions[row.ConfigClientId] = revision
+			row.Revision = revision
+			row.MultipleLanguageId = cc.MultipleLanguageId
+			row.Appname = cc.Appname
+			row.ReportTitle = cc.ReportTitle
+
+			cc.ConfigClientId, cc.ConfigClientUuid, cc.IsConfigDeleted = row.ConfigClientId, row.ConfigClientUuid, 0
+			return nil
+		}
+
+		store.lastClientID++
+		store.clientRevisions[store.lastClientID] = revision
+
+		row := proto.Clone(cc).(*pb.ConfigurationClient)
+		row.ConfigClientId = store.lastClientID
+		row.Revision = revision
+		row.IsConfigDeleted = 0
+		store.clients = append(store.clients, row)
+		cc.ConfigClientId, cc.IsConfigDeleted = row.ConfigClientId, 0
+		created = true
+
+		return nil
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return created, nil
+}
+
+func (repo *memoryConfiguration) UpdateConfigurationClientBySubs(ctx context.Context, cc *pb.ConfigurationClient) (bool, error) {
+	err := repo.writeRevision(func(store *memoryStore, revision int64) error {
+		rowsAffected := 0
+		for _, row := range store.clients {
+			if row.ConfigClientUuid != cc.ConfigClientUuid {
+				continue
+			}
+
+			if row.IsConfigDeleted == 0 && store.activeClient(cc.CompanySubsId, row.ConfigClientId) != nil {
+				return api.Errorf(api.ErrAlreadyExists, "Data Already Exists")
+			}
+
+			store.clientRevisions[row.ConfigClientId] = revision
+			row.Revision = revision
+			row.MultipleLanguageId = cc.MultipleLanguageId
+			row.Appname = cc.Appname
+			row.ReportTitle = cc.ReportTitle
+			row.CompanySubsId = cc.CompanySubsId
+			rowsAffected++
+		}
+
+		if rowsAffected == 0 {
+			return api.Errorf(api.ErrNotFound, "Data Not Found to Update")
+		}
+
+		return nil
+	})
+
+	return err == nil, err
+}
+
+// activeClient return client of subsID that is not deleted, client of exceptID is skipped
+func (store *memoryStore) activeClient(subsID string, exceptID int64) *pb.ConfigurationClient {
+	for _, row := range store.clients {
+		if row.CompanySubsId == subsID && row.IsConfigDeleted == 0 && row.ConfigClientId != exceptID {
+			return row
+		}
+	}
+
+	return nil
+}
+
+func (repo *memoryConfiguration) GetDuplicateConfigurationClient(ctx context.Context) ([]*pb.ConfigurationClient, error) {
+	var duplicates []*pb.ConfigurationClient
+	repo.read(func(store *memoryStore) {
+		duplicates = store.duplicateClients()
+	})
+
+	return duplicates, nil
+}
+
+// add and update of memory repository refuse duplicate, so there is nothing to repair unless store is filled directly.
+// it is repaired the same way as sql: duplicate is soft deleted, and it and the kept client of the same company_subs_id get new revision,
+// so sync return the kept client together with the tombstone. revision is not increased when there is nothing to repair
+func (repo *memoryConfiguration) RepairDuplicateConfigurationClient(ctx context.Context) ([]*pb.ConfigurationClient, error) {
+	var duplicates []*pb.ConfigurationClient
+	err := repo.write(func(store *memoryStore) error {
+		duplicates = store.duplicateClients()
+		if len(duplicates) == 0 {
+			return nil
+		}
+
+		store.revision++
+		for _, cc := range duplicates {
+			for _, row := range store.clients {
+				if row.CompanySubsId != cc.CompanySubsId || row.IsConfigDeleted != 0 {
+					continue
+				}
+
+				if row.ConfigClientId == cc.ConfigClientId {
+					row.IsConfigDeleted = 1
+				}
+				row.Revision = store.revision
+				store.clientRevisions[row.ConfigClientId] = store.revision
+			}
+			cc.IsConfigDeleted = 1
+			cc.Revision = store.revision
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return duplicates, nil
+}
+
+// duplicateClients return copy of client that is not deleted while older client of the same company_subs_id is not deleted
+func (store *memoryStore) duplicateClients() []*pb.ConfigurationClient {
+	var duplicates []*pb.ConfigurationClient
+	oldest := map[string]bool{}
+	for _, row := range store.clients {
+		if row.IsConfigDeleted != 0 {
+			continue
+		}
+
+		if oldest[row.CompanySubsId] {
+			duplicates = append(duplicates, proto.Clone(row).(*pb.ConfigurationClient))
+			continue
+		}
+		oldest[row.CompanySubsId] = true
+	}
+
+	return duplicates
+}
+
+func (repo *memoryConfiguration) DeleteConfigurationClientBySubs(ctx context.Context, cc *pb.ConfigurationClient) (bool, error) {
+	err := repo.writeRevision(func(store *memoryStore, revision int64) error {
+		rowsAffected := 0
+		for _, row := range store.clients {
+			if row.CompanySubsId != cc.CompanySubsId || row.IsConfigDeleted != 0 {
+				continue
+			}
+
+			store.clientRevisions[row.ConfigClientId] = revision
+			row.Revision = revision
+			row.IsConfigDeleted = 1
+			rowsAffected++
+		}
+
+		if rowsAffected == 0 {
+			return api.Errorf(api.ErrNotFound, "Data Not Found to Delete")
+		}
+
+		return nil
+	})
+
+	return err == nil, err
+}
+
+func (repo *memoryConfiguration) GetConfigurationClientBySubs(ctx context.Context, clientSubsID string) (*pb.ConfigurationClient, error) {
+	var res *pb.ConfigurationClient
+	repo.read(func(store *memoryStore) {
+		for _, row := range store.clients {
+			if row.CompanySubsId == clientSubsID && row.IsConfigDeleted == 0 {
+				res = proto.Clone(row).(*pb.ConfigurationClient)
+				return
+			}
+		}
+	})
+
+	if res == nil {
+		return nil, api.ErrNotFound
+	}
+
+	return res, nil
+}
+
+func (repo *memoryConfiguration) GetConfigurationClientByUUID(ctx context.Context, configClientUUID string) (*pb.ConfigurationClient, error) {
+	var res *pb.ConfigurationClient
+	repo.read(func(store *memoryStore) {
+		for _, row := range store.clients {
+			if row.ConfigClientUuid == configClientUUID {
+				res = proto.Clone(row).(*pb.ConfigurationClient)
+				return
+			}
+		}
+	})
+
+	if res == nil {
+		return nil, api.ErrNotFound
+	}
+
+	return res, nil
+}
+
+func (repo *memoryConfiguration) GetConfigurationClient(ctx context.Context) ([]*pb.ConfigurationClient, error) {
+	res := make([]*pb.ConfigurationClient, 0)
+	repo.read(func(store *memoryStore) {
+		for _, row := range store.clients {
+			res = append(res, proto.Clone(row).(*pb.ConfigurationClient))
+		}
+	})
+
+	if len(res) == 0 {
+		return nil, api.Errorf(api.ErrNotFound, "Data Not Found")
+	}
+
+	return res, nil
+}
+
+func (repo *memoryConfiguration) AddConfigurationGlobal(ctx context.Context, cg *pb.ConfigurationGlobal) (bool, error) {
+	err := repo.writeRevision(func(store *memoryStore, revision int64) error {
+		store.lastGlobalID++
+		store.globalRevisions[store.lastGlobalID] = revision
+
+		// is_active is not inserted, so it use default value of column
+		row := proto.Clone(cg).(*pb.ConfigurationGlobal)
+		row.ConfigGlobalId = store.lastGlobalID
+		row.IsActive = false
+		store.globals = append(store.globals, row)
+		cg.ConfigGlobalId = row.ConfigGlobalId
+
+		return nil
+	})
+
+	return err == nil, err
+}
+
+func (repo *memoryConfiguration) UpdateConfigurationGlobal(ctx context.Context, cg *pb.ConfigurationGlobal) (bool, error) {
+	err := repo.writeRevision(func(store *memoryStore, revision int64) error {
+		for _, row := range store.globals {
+			if row.ConfigGlobalId != cg.ConfigGlobalId {
+				continue
+			}
+
+			store.globalRevisions[row.ConfigGlobalId] = revision
+			row.Footertext = cg.Footertext
+			row.ServerSmpt = cg.ServerSmpt
+			row.Ssl = cg.Ssl
+			row.Port = cg.Port
+			row.IsAuth = cg.IsAuth
+			row.Username = cg.Username
+			row.Password = cg.Password
+
+			return nil
+		}
+
+		return api.Errorf(api.ErrNotFound, "No Data to Update")
+	})
+
+	return err == nil, err
+}
+
+func (repo *memoryConfiguration) DeleteConfiguration(ctx context.Context, configGlobalID int32) (bool, error) {
+	err := repo.writeRevision(func(store *memoryStore, revision int64) error {
+		for i, row := range store.globals {
+			if row.ConfigGlobalId != configGlobalID {
+				continue
+			}
+
+			store.globals = append(store.globals[:i], store.globals[i+1:]...)
+			delete(store.globalRevisions, configGlobalID)
+			store.globalTombstones = append(store.globalTombstones, &pb.GlobalTombstone{ConfigGlobalId: configGlobalID, Revision: revision})
+
+			return nil
+		}
+
+		return api.Errorf(api.ErrNotFound, "No Data to Delete From DB")
+	})
+
+	return err == nil, err
+}
+
+func (repo *memoryConfiguration) GetConfigurationGlobal(ctx context.Context) ([]*pb.ConfigurationGlobal, error) {
+	res := make([]*pb.ConfigurationGlobal, 0)
+	repo.read(func(store *memoryStore) {
+		for _, row := range store.globals {
+			res = append(res, proto.Clone(row).(*pb.ConfigurationGlobal))
+		}
+	})
+
+	return res, nil
+}
+
+func (repo *memoryConfiguration) GetConfigurationGlobalByID(ctx context.Context, configGlobalID int32) (*pb.ConfigurationGlobal, error) {
+	var res *pb.ConfigurationGlobal
+	repo.read(func(store *memoryStore) {
+		for _, row := range store.globals {
+			if row.ConfigGlobalId == configGlobalID {
+				res = proto.Clone(row).(*pb.ConfigurationGlobal)
+				return
+			}
+		}
+	})
+
+	if res == nil {
+		return nil, api.ErrNotFound
+	}
+
+	return res, nil
+}
+
+func (repo *memoryConfiguration) GetConfigurationGlobalActive(ctx context.Context) (*pb.ConfigurationGlobal, error) {
+	var res *pb.ConfigurationGlobal
+	repo.read(func(store *memoryStore) {
+		for _, row := range store.globals {
+			if row.IsActive {
+				res = proto.Clone(row).(*pb.ConfigurationGlobal)
+				return
+			}
+		}
+	})
+
+	return res, nil
+}
+
+func (repo *memoryConfiguration) SetConfigurationGlobalActive(ctx context.Context, configGlobalID int32) (bool, error) {
+	err := repo.writeRevision(func(store *memoryStore, revision int64) error {
+		var target *pb.ConfigurationGlobal
+		for _, row := range store.globals {
+			if row.ConfigGlobalId == configGlobalID {
+				target = row
+			}
+		}
+
+		// keep the old active configuration when target not exists
+		if target == nil {
+			return api.Errorf(api.ErrNotFound, "No Data to Activate")
+		}
+
+		// like sql, only row that is changed get the revision
+		for _, row := range store.globals {
+			if row == target || row.IsActive {
+				store.globalRevisions[row.ConfigGlobalId] = revision
+			}
+
+			row.IsActive = row == target
+		}
+
+		return nil
+	})
+
+	return err == nil, err
+}
+
+func (repo *memoryConfiguration) SyncConfiguration(ctx context.Context, since int64) (*pb.ResponseSync, error) {
+	res := &pb.ResponseSync{
+		Configclients:  make([]*pb.ConfigurationClient, 0),
+		Configglobals:  make([]*pb.ConfigurationGlobal, 0),
+		DeletedClients: make([]*pb.ClientTombstone, 0),
+		DeletedGlobals: make([]*pb.GlobalTombstone, 0),
+	}
+
+	repo.read(func(store *memoryStore) {
+		res.Revision = store.revision
+
+		for _, row := range store.clients {
+			rev := store.clientRevisions[row.ConfigClientId]
+			if rev <= since {
+				continue
+			}
+
+			if row.IsConfigDeleted != 0 {
+				res.DeletedClients = append(res.DeletedClients, &pb.ClientTombstone{CompanySubsId: row.CompanySubsId, Revision: rev})
+				continue
+			}
+
+			res.Configclients = append(res.Configclients, proto.Clone(row).(*pb.ConfigurationClient))
+		}
+
+		for _, row := range store.globals {
+			if store.globalRevisions[row.ConfigGlobalId] > since {
+				res.Configglobals = append(res.Configglobals, proto.Clone(row).(*pb.ConfigurationGlobal))
+			}
+		}
+
+		for _, t := range store.globalTombstones {
+			if t.Revision > since {
+				res.DeletedGlobals = append(res.DeletedGlobals, proto.Clone(t).(*pb.GlobalTombstone))
+			}
+		}
+
+		// same order with sql, by revision then id. tombstone is appended by revision already
+		sort.SliceStable(res.Configclients, func(i, j int) bool {
+			return store.clientRevisions[res.Configclients[i].ConfigClientId] < store.clientRevisions[res.Configclients[j].ConfigClientId]
+		})
+
+		sort.SliceStable(res.Configglobals, func(i, j int) bool {
+			return store.globalRevisions[res.Configglobals[i].ConfigGlobalId] < store.globalRevisions[res.Configglobals[j].ConfigGlobalId]
+		})
+
+		sort.SliceStable(res.DeletedClients, func(i, j int) bool {
+			return res.DeletedClients[i].Revision < res.DeletedClients[j].Revision
+		})
+	})
+
+	return res, nil
+}
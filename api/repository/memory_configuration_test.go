@@ -0,0 +1,224 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+	repo "github.com/muhammadhidayah/configuration-service/api/repository"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryConfigurationClient(t *testing.T) {
+	configRepo := repo.NewMemoryConfiguration()
+
+	cc := &pb.ConfigurationClient{
+		ConfigClientUuid:   "a6e2745e-c930-4717-a9d1-d1cfb2a64aa4",
+		MultipleLanguageId: 2,
+		Appname:            "client1.inactsoft.com",
+		ReportTitle:        "Client Satu",
+		CompanySubsId:      "012-031-234-542",
+	}
+
+	t.Run("Get Configuration Client when no data", func(t *testing.T) {
+		res, err := configRepo.GetConfigurationClient(context.TODO())
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("Add and Get Configuration Client by subs", func(t *testing.T) {
+		created, err := configRepo.AddConfigurationClient(context.TODO(), cc)
+		assert.NoError(t, err)
+		assert.True(t, created)
+
+		res, err := configRepo.GetConfigurationClientBySubs(context.TODO(), cc.CompanySubsId)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), res.ConfigClientId)
+		assert.Equal(t, cc.Appname, res.Appname)
+
+		// data returned is a copy, changing it must not change data in repository
+		res.Appname = "changed.inactsoft.com"
+		res, _ = configRepo.GetConfigurationClientBySubs(context.TODO(), cc.CompanySubsId)
+		assert.Equal(t, cc.Appname, res.Appname)
+	})
+
+	t.Run("Update Configuration Client by uuid", func(t *testing.T) {
+		updated, err := configRepo.UpdateConfigurationClientBySubs(context.TODO(), &pb.ConfigurationClient{
+			ConfigClientUuid: cc.ConfigClientUuid,
+			Appname:          "client1-new.inactsoft.com",
+			CompanySubsId:    cc.CompanySubsId,
+		})
+		assert.NoError(t, err)
+		assert.True(t, updated)
+
+		res, _ := configRepo.GetConfigurationClientBySubs(context.TODO(), cc.CompanySubsId)
+		assert.Equal(t, "client1-new.inactsoft.com", res.Appname)
+
+		updated, err = configRepo.UpdateConfigurationClientBySubs(context.TODO(), &pb.ConfigurationClient{ConfigClientUuid: "not-exists"})
+		assert.Error(t, err)
+		assert.False(t, updated)
+	})
+
+	t.Run("Delete Configuration Client is soft delete", func(t *testing.T) {
+		deleted, err := configRepo.DeleteConfigurationClientBySubs(context.TODO(), cc)
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+
+		res, err := configRepo.GetConfigurationClientBySubs(context.TODO(), cc.CompanySubsId)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+
+		// row still exists, only flagged as deleted
+		list, err := configRepo.GetConfigurationClient(context.TODO())
+		assert.NoError(t, err)
+		assert.Len(t, list, 1)
+		assert.Equal(t, int32(1), list[0].IsConfigDeleted)
+
+		deleted, err = configRepo.DeleteConfigurationClientBySubs(context.TODO(), &pb.ConfigurationClient{CompanySubsId: "not-exists"})
+		assert.Error(t, err)
+		assert.False(t, deleted)
+	})
+}
+
+func TestMemoryConfigurationGlobal(t *testing.T) {
+	configRepo := repo.NewMemoryConfiguration()
+
+	for i := 1; i <= 3; i++ {
+		created, err := configRepo.AddConfigurationGlobal(context.TODO(), &pb.ConfigurationGlobal{
+			ServerSmpt: "mail.google.com",
+			Port:       5432,
+			Username:   fmt.Sprintf("notification%d@inactsoft.com", i),
+			IsActive:   true,
+		})
+		assert.NoError(t, err)
+		assert.True(t, created)
+	}
+
+	t.Run("Added configuration is not active", func(t *testing.T) {
+		res, err := configRepo.GetConfigurationGlobalActive(context.TODO())
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("Get Configuration Global by id", func(t *testing.T) {
+		res, err := configRepo.GetConfigurationGlobalByID(context.TODO(), 2)
+		assert.NoError(t, err)
+		assert.Equal(t, "notification2@inactsoft.com", res.Username)
+
+		res, err = configRepo.GetConfigurationGlobalByID(context.TODO(), 99)
+		assert.True(t, errors.Is(err, api.ErrNotFound), "expected api.ErrNotFound, got %v", err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("Set Configuration Global Active deactivate the others", func(t *testing.T) {
+		updated, err := configRepo.SetConfigurationGlobalActive(context.TODO(), 1)
+		assert.NoError(t, err)
+		assert.True(t, updated)
+
+		updated, err = configRepo.SetConfigurationGlobalActive(context.TODO(), 3)
+		assert.NoError(t, err)
+		assert.True(t, updated)
+
+		list, _ := configRepo.GetConfigurationGlobal(context.TODO())
+		active := 0
+		for _, cg := range list {
+			if cg.IsActive {
+				active++
+			}
+		}
+		assert.Equal(t, 1, active)
+
+		res, _ := configRepo.GetConfigurationGlobalActive(context.TODO())
+		assert.Equal(t, int32(3), res.ConfigGlobalId)
+
+		updated, err = configRepo.SetConfigurationGlobalActive(context.TODO(), 99)
+		assert.Error(t, err)
+		assert.False(t, updated)
+
+		res, _ = configRepo.GetConfigurationGlobalActive(context.TODO())
+		assert.Equal(t, int32(3), res.ConfigGlobalId)
+	})
+
+	t.Run("Update and Delete Configuration Global", func(t *testing.T) {
+		updated, err := configRepo.UpdateConfigurationGlobal(context.TODO(), &pb.ConfigurationGlobal{ConfigGlobalId: 3, ServerSmpt: "smtp.inactsoft.com"})
+		assert.NoError(t, err)
+		assert.True(t, updated)
+
+		res, _ := configRepo.GetConfigurationGlobalByID(context.TODO(), 3)
+		assert.Equal(t, "smtp.inactsoft.com", res.ServerSmpt)
+		assert.True(t, res.IsActive)
+
+		deleted, err := configRepo.DeleteConfiguration(context.TODO(), 2)
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+
+		deleted, err = configRepo.DeleteConfiguration(context.TODO(), 2)
+		assert.Error(t, err)
+		assert.False(t, deleted)
+
+		list, _ := configRepo.GetConfigurationGlobal(context.TODO())
+		assert.Len(t, list, 2)
+	})
+}
+
+func TestMemoryConfigurationWithinTx(t *testing.T) {
+	configRepo := repo.NewMemoryConfiguration()
+
+	t.Run("Rollback when unit of work return error", func(t *testing.T) {
+		err := configRepo.WithinTx(context.TODO(), func(txRepo api.Repository) error {
+			if _, err := txRepo.AddConfigurationGlobal(context.TODO(), &pb.ConfigurationGlobal{ServerSmpt: "mail.google.com"}); err != nil {
+				return err
+			}
+
+			return errors.New("Unexpected Error")
+		})
+		assert.Error(t, err)
+
+		list, _ := configRepo.GetConfigurationGlobal(context.TODO())
+		assert.Len(t, list, 0)
+	})
+
+	t.Run("Commit when unit of work success", func(t *testing.T) {
+		err := configRepo.WithinTx(context.TODO(), func(txRepo api.Repository) error {
+			if _, err := txRepo.AddConfigurationGlobal(context.TODO(), &pb.ConfigurationGlobal{ServerSmpt: "mail.google.com"}); err != nil {
+				return err
+			}
+
+			_, err := txRepo.SetConfigurationGlobalActive(context.TODO(), 1)
+			return err
+		})
+		assert.NoError(t, err)
+
+		res, _ := configRepo.GetConfigurationGlobalActive(context.TODO())
+		assert.Equal(t, int32(1), res.ConfigGlobalId)
+	})
+}
+
+func TestMemoryConfigurationConcurrent(t *testing.T) {
+	configRepo := repo.NewMemoryConfiguration()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+			configRepo.AddConfigurationClient(context.TODO(), &pb.ConfigurationClient{CompanySubsId: fmt.Sprintf("subs-%d", i)})
+		}(i)
+
+		go func(i int) {
+			defer wg.Done()
+			configRepo.GetConfigurationClientBySubs(context.TODO(), fmt.Sprintf("subs-%d", i))
+		}(i)
+	}
+
+	wg.Wait()
+
+	list, err := configRepo.GetConfigurationClient(context.TODO())
+	assert.NoError(t, err)
+	assert.Len(t, list, 50)
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+)
+
+func (repo *memoryConfiguration) AddOutboxEvent(ctx context.Context, ev *api.OutboxEvent) error {
+	return repo.write(func(store *memoryStore) error {
+		if ev.CreatedAt.IsZero() {
+			ev.CreatedAt = time.Now().UTC()
+		}
+
+		if ev.NextAttemptAt.IsZero() {
+			ev.NextAttemptAt = ev.CreatedAt
+		}
+
+		store.lastOutboxID++
+		ev.ID = store.lastOutboxID
+
+		row := *ev
+		row.Payload = append([]byte(nil), ev.Payload...)
+		store.outbox = append(store.outbox, &row)
+
+		return nil
+	})
+}
+
+// relay of memory repository is in the same process, lock only keep relay of the process from running twice at the same time
+func (repo *memoryConfiguration) LockOutbox(ctx context.Context) (func(), bool, error) {
+	if !atomic.CompareAndSwapInt32(repo.relayLocked, 0, 1) {
+		return nil, false, nil
+	}
+
+	return func() { atomic.StoreInt32(repo.relayLocked, 0) }, true, nil
+}
+
+func (repo *memoryConfiguration) PendingOutboxEvents(ctx context.Context, now time.Time, limit int) ([]*api.OutboxEvent, error) {
+	events := make([]*api.OutboxEvent, 0)
+
+	repo.read(func(store *memoryStore) {
+		// key that has event waiting for retry, event after it is skipped
+		waiting := make(map[string]bool)
+		for _, row := range store.outbox {
+			if len(events) == limit {
+				break
+			}
+
+			if _, dead := store.deadOutbox[row.ID]; dead {
+				continue
+			}
+
+			if row.NextAttemptAt.After(now) {
+				waiting[row.Key] = true
+				continue
+			}
+
+			if waiting[row.Key] {
+				continue
+			}
+
+			ev := *row
+			events = append(events, &ev)
+		}
+	})
+
+	return events, nil
+}
+
+func (repo *memoryConfiguration) DeleteOutboxEvent(ctx context.Context, id int64) error {
+	return repo.write(func(store *memoryStore) error {
+		for i, row := range store.outbox {
+			if row.ID == id {
+				store.outbox = append(store.outbox[:i], store.outbox[i+1:]...)
+				break
+			}
+		}
+
+		return nil
+	})
+}
+
+func (repo *memoryConfiguration) RetryOutboxEvent(ctx context.Context, id int64, nextAttemptAt time.Time, reason string) error {
+	return repo.write(func(store *memoryStore) error {
+		for _, row := range store.outbox {
+			if row.ID == id {
+				row.Attempts++
+				row.NextAttemptAt = nextAttemptAt.UTC()
+				return nil
+			}
+		}
+
+		return api.Errorf(api.ErrNotFound, "Data Not Found to Update")
+	})
+}
+
+func (repo *memoryConfiguration) DeadOutboxEvent(ctx context.Context, id int64, reason string) error {
+	return repo.write(func(store *memoryStore) error {
+		for _, row := range store.outbox {
+			if row.ID == id {
+				row.Attempts++
+				store.deadOutbox[id] = reason
+				return nil
+			}
+		}
+
+		return api.Errorf(api.ErrNotFound, "Data Not Found to Update")
+	})
+}
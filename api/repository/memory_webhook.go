@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/muhammadhidayah/configuration-service/api"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+)
+
+func (repo *memoryConfiguration) AddWebhook(ctx context.Context, wh *pb.Webhook) (bool, error) {
+	err := repo.write(func(store *memoryStore) error {
+		store.lastWebhookID++
+		wh.WebhookId = store.lastWebhookID
+		store.webhooks = append(store.webhooks, proto.Clone(wh).(*pb.Webhook))
+
+		return nil
+	})
+
+	return err == nil, err
+}
+
+func (repo *memoryConfiguration) DeleteWebhook(ctx context.Context, webhookID int64) (bool, error) {
+	err := repo.write(func(store *memoryStore) error {
+		for i, row := range store.webhooks {
+			if row.WebhookId == webhookID {
+				store.webhooks = append(store.webhooks[:i], store.webhooks[i+1:]...)
+				return nil
+			}
+		}
+
+		return api.Errorf(api.ErrNotFound, "Data Not Found to Delete")
+	})
+
+	return err == nil, err
+}
+
+func (repo *memoryConfiguration) GetWebhooks(ctx context.Context) ([]*pb.Webhook, error) {
+	webhooks := make([]*pb.Webhook, 0)
+
+	repo.read(func(store *memoryStore) {
+		for _, row := range store.webhooks {
+			webhooks = append(webhooks, proto.Clone(row).(*pb.Webhook))
+		}
+	})
+
+	return webhooks, nil
+}
+
+func (repo *memoryConfiguration) AddWebhookDelivery(ctx context.Context, d *pb.WebhookDelivery) error {
+	return repo.write(func(store *memoryStore) error {
+		store.lastDeliveryID++
+		d.DeliveryId = store.lastDeliveryID
+		d.Status = api.WebhookPending
+		store.deliveries = append(store.deliveries, proto.Clone(d).(*pb.WebhookDelivery))
+
+		return nil
+	})
+}
+
+func (repo *memoryConfiguration) PendingWebhookDeliveries(ctx context.Context, now int64, limit int) ([]*pb.WebhookDelivery, error) {
+	deliveries := make([]*pb.WebhookDelivery, 0)
+
+	repo.read(func(store *memoryStore) {
+		for _, row := range store.deliveries {
+			if len(deliveries) == limit {
+				break
+			}
+
+			if row.Status == api.WebhookPending && row.NextAttemptAt <= now {
+				deliveries = append(deliveries, proto.Clone(row).(*pb.WebhookDelivery))
+			}
+		}
+	})
+
+	return deliveries, nil
+}
+
+func (repo *memoryConfiguration) ClaimWebhookDelivery(ctx context.Context, d *pb.WebhookDelivery, until int64) (bool, error) {
+	claimed := false
+	err := repo.write(func(store *memoryStore) error {
+		for _, row := range store.deliveries {
+			if row.DeliveryId == d.DeliveryId && row.Status == api.WebhookPending && row.NextAttemptAt == d.NextAttemptAt {
+				row.NextAttemptAt = until
+				d.NextAttemptAt = until
+				claimed = true
+				return nil
+			}
+		}
+
+		return nil
+	})
+
+	return claimed, err
+}
+
+func (repo *memoryConfiguration) UpdateWebhookDelivery(ctx context.Context, d *pb.WebhookDelivery) error {
+	return repo.write(func(store *memoryStore) error {
+		for _, row := range store.deliveries {
+			if row.DeliveryId == d.DeliveryId {
+				row.Status = d.Status
+				row.Attempts = d.Attempts
+				row.NextAttemptAt = d.NextAttemptAt
+				row.LastError = d.LastError
+				return nil
+			}
+		}
+
+		return api.Errorf(api.ErrNotFound, "Data Not Found to Update")
+	})
+}
+
+func (repo *memoryConfiguration) GetWebhookDeliveries(ctx context.Context, status string) ([]*pb.WebhookDelivery, error) {
+	deliveries := make([]*pb.WebhookDelivery, 0)
+
+	repo.read(func(store *memoryStore) {
+		for _, row := range store.deliveries {
+			if row.Status == status {
+				deliveries = append(deliveries, proto.Clone(row).(*pb.WebhookDelivery))
+			}
+		}
+	})
+
+	return deliveries, nil
+}
+
+func (repo *memoryConfiguration) RedeliverWebhookDelivery(ctx context.Context, deliveryID int64, now int64) (bool, error) {
+	err := repo.write(func(store *memoryStore) error {
+		for _, row := range store.deliveries {
+			if row.DeliveryId == deliveryID && row.Status == api.WebhookDead {
+				row.Status = api.WebhookPending
+				row.Attempts = 0
+				row.NextAttemptAt = now
+				return nil
+			}
+		}
+
+		return api.Errorf(api.ErrNotFound, "No Dead Delivery to Redeliver")
+	})
+
+	return err == nil, err
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/muhammadhidayah/configuration-service/api"
+)
+
+// NewMysqlConfiguration create api.Repository on mysql. id of inserted row is taken from LAST_INSERT_ID because mysql has no RETURNING
+func NewMysqlConfiguration(conn *sql.DB) api.Repository {
+	return newSQLConfiguration(conn, mysqlDialect)
+}
+
+// mysqlUniqueViolation is true for ER_DUP_ENTRY
+func mysqlUniqueViolation(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1062
+}
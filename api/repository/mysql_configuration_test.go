@@ -0,0 +1,122 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	sqlMock "github.com/DATA-DOG/go-sqlmock"
+	repo "github.com/muhammadhidayah/configuration-service/api/repository"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMysqlAddConfigurationClient(t *testing.T) {
+	cc := &pb.ConfigurationClient{
+		ConfigClientUuid:   "111-111-111-111",
+		MultipleLanguageId: 3,
+		Appname:            "client1.inactsoft.com",
+		ReportTitle:        "Client 1",
+		CompanySubsId:      "180-000-123-0321",
+	}
+
+	db, mock, err := sqlMock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database conncection", err)
+	}
+
+	defer db.Close()
+
+	// mysql has no RETURNING, id of new row is taken from LAST_INSERT_ID
+	expectRevision(mock, 2)
+	prep := mock.ExpectPrepare("INSERT INTO configuration_client \\(.*\\) VALUES\\(\\?,\\?,\\?,\\?,\\?,\\?,\\?\\)$")
+	prep.ExpectExec().WithArgs(cc.ConfigClientUuid, cc.MultipleLanguageId, cc.Appname, cc.ReportTitle, cc.CompanySubsId, cc.IsConfigDeleted, 2).WillReturnResult(sqlMock.NewResult(12, 1))
+	mock.ExpectCommit()
+
+	created, err := repo.NewMysqlConfiguration(db).AddConfigurationClient(context.TODO(), cc)
+	assert.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, int64(12), cc.ConfigClientId)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlAddConfigurationGlobal(t *testing.T) {
+	cg := &pb.ConfigurationGlobal{
+		Footertext: "Technical support",
+		ServerSmpt: "mail.google.com",
+		Ssl:        true,
+		Port:       1234,
+		IsAuth:     true,
+		Username:   "notification@inactsoft.com",
+		Password:   "123456789087654",
+	}
+
+	db, mock, err := sqlMock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database conncection", err)
+	}
+
+	defer db.Close()
+
+	t.Run("Add Configuration Global return id of new row", func(t *testing.T) {
+		expectRevision(mock, 3)
+		// ssl is reserved word of mysql, it must be quoted with backtick
+		prep := mock.ExpectPrepare("INSERT INTO configuration_global \\(footertext, server_smpt, `ssl`, port, .*\\?\\)$")
+		prep.ExpectExec().WithArgs(cg.Footertext, cg.ServerSmpt, cg.Ssl, cg.Port, cg.IsAuth, cg.Username, cg.Password, 3).WillReturnResult(sqlMock.NewResult(5, 1))
+		mock.ExpectCommit()
+
+		created, err := repo.NewMysqlConfiguration(db).AddConfigurationGlobal(context.TODO(), cg)
+		assert.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, int32(5), cg.ConfigGlobalId)
+	})
+
+	t.Run("Add Configuration Global, but LAST_INSERT_ID failed", func(t *testing.T) {
+		expectRevision(mock, 4)
+		prep := mock.ExpectPrepare("INSERT INTO configuration_global")
+		prep.ExpectExec().WillReturnResult(sqlMock.NewErrorResult(fmt.Errorf("LastInsertId is not supported")))
+		mock.ExpectRollback()
+
+		created, err := repo.NewMysqlConfiguration(db).AddConfigurationGlobal(context.TODO(), cg)
+		assert.Error(t, err)
+		assert.False(t, created)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMysqlGetConfigurationGlobalByID(t *testing.T) {
+	db, mock, err := sqlMock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database conncection", err)
+	}
+
+	defer db.Close()
+
+	rows := sqlMock.NewRows([]string{"config_global_id", "footertext", "server_smpt", "ssl", "port", "is_auth", "username", "password", "is_active"}).AddRow(3, "Technical support", "mail.google.com", 1, 465, 1, "notification@inactsoft.com", "secret", 0)
+	mock.ExpectPrepare("SELECT .*, `ssl`, .* FROM configuration_global WHERE config_global_id = \\?$").ExpectQuery().WithArgs(int32(3)).WillReturnRows(rows)
+
+	res, err := repo.NewMysqlConfiguration(db).GetConfigurationGlobalByID(context.TODO(), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), res.ConfigGlobalId)
+	assert.True(t, res.Ssl)
+	assert.False(t, res.IsActive)
+}
+
+func TestMysqlSetConfigurationGlobalActive(t *testing.T) {
+	db, mock, err := sqlMock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database conncection", err)
+	}
+
+	defer db.Close()
+
+	expectRevision(mock, 5)
+	mock.ExpectPrepare("UPDATE configuration_global SET is_active = \\?, revision = \\? WHERE is_active = \\? AND config_global_id <> \\?").ExpectExec().WithArgs(false, 5, true, int32(3)).WillReturnResult(sqlMock.NewResult(0, 1))
+	mock.ExpectPrepare("UPDATE configuration_global SET is_active = \\?, revision = \\? WHERE config_global_id = \\?").ExpectExec().WithArgs(true, 5, int32(3)).WillReturnResult(sqlMock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	activated, err := repo.NewMysqlConfiguration(db).SetConfigurationGlobalActive(context.TODO(), 3)
+	assert.Error(t, err)
+	assert.False(t, activated)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
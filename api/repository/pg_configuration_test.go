@@ -2,15 +2,32 @@ package repository_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"testing"
+	"time"
 
 	sqlMock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/muhammadhidayah/configuration-service/api"
 	repo "github.com/muhammadhidayah/configuration-service/api/repository"
 	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
 	"github.com/stretchr/testify/assert"
 )
 
+// expectRevision expect transaction that is begun by write, then the revision is increased to rev
+func expectRevision(mock sqlMock.Sqlmock, rev int64) {
+	mock.ExpectBegin()
+	expectNextRevision(mock, rev)
+}
+
+// expectNextRevision expect revision is increased to rev in transaction that is already begun
+func expectNextRevision(mock sqlMock.Sqlmock, rev int64) {
+	mock.ExpectPrepare("UPDATE configuration_revision SET revision = revision \\+ 1 WHERE id = 1").ExpectExec().WillReturnResult(sqlMock.NewResult(0, 1))
+	mock.ExpectPrepare("SELECT revision FROM configuration_revision WHERE id = 1").ExpectQuery().WillReturnRows(sqlMock.NewRows([]string{"revision"}).AddRow(rev))
+}
+
 // Testing Store Configuration to Table
 func TestAddConfigurationClient(t *testing.T) {
 	cc := &pb.ConfigurationClient{
@@ -29,14 +46,148 @@ func TestAddConfigurationClient(t *testing.T) {
 
 	defer db.Close()
 
-	prep := mock.ExpectPrepare("INSERT configuration_client")
-	prep.ExpectExec().WithArgs(cc.ConfigClientUuid, cc.MultipleLanguageId, cc.Appname, cc.ReportTitle, cc.CompanySubsId, cc.IsConfigDeleted).WillReturnResult(sqlMock.NewResult(1, 1))
+	expectRevision(mock, 2)
+	prep := mock.ExpectPrepare("INSERT INTO configuration_client \\(.*\\) VALUES\\(\\$1,\\$2,\\$3,\\$4,\\$5,\\$6,\\$7\\) RETURNING config_client_id")
+	prep.ExpectQuery().WithArgs(cc.ConfigClientUuid, cc.MultipleLanguageId, cc.Appname, cc.ReportTitle, cc.CompanySubsId, cc.IsConfigDeleted, 2).WillReturnRows(sqlMock.NewRows([]string{"config_client_id"}).AddRow(7))
+	mock.ExpectCommit()
 
 	clientRepo := repo.NewPgConfiguration(db)
 	created, err := clientRepo.AddConfigurationClient(context.TODO(), cc)
 
 	assert.NoError(t, err)
 	assert.True(t, created)
+	assert.Equal(t, int64(7), cc.ConfigClientId)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUpsertConfigurationClientBySubs(t *testing.T) {
+	db, mock, err := sqlMock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database conncection", err)
+	}
+
+	defer db.Close()
+
+	query := "INSERT INTO configuration_client \\(.*\\) VALUES\\(\\$1,\\$2,\\$3,\\$4,\\$5,\\$6,\\$7\\) " +
+		"ON CONFLICT \\(company_subs_id\\) WHERE is_config_deleted = 0 DO UPDATE SET multiple_language_id = excluded.multiple_language_id, appname = excluded.appname, report_title = excluded.report_title, revision = excluded.revision " +
+		"RETURNING config_client_id, config_client_uuid"
+
+	t.Run("Client is created", func(t *testing.T) {
+		cc := &pb.ConfigurationClient{ConfigClientUuid: "a6e2745e-c930-4717-a9d1-d1cfb2a64aa4", MultipleLanguageId: 2, Appname: "client1.inactsoft.com", ReportTitle: "Client 1", CompanySubsId: "180-000-123-0321"}
+
+		expectRevision(mock, 3)
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(cc.ConfigClientUuid, cc.MultipleLanguageId, cc.Appname, cc.ReportTitle, cc.CompanySubsId, 0, 3).
+			WillReturnRows(sqlMock.NewRows([]string{"config_client_id", "config_client_uuid"}).AddRow(7, cc.ConfigClientUuid))
+		mock.ExpectCommit()
+
+		created, err := repo.NewPgConfiguration(db).UpsertConfigurationClientBySubs(context.TODO(), cc)
+
+		assert.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, int64(7), cc.ConfigClientId)
+	})
+
+	t.Run("Existing client is updated and keep its uuid", func(t *testing.T) {
+		cc := &pb.ConfigurationClient{ConfigClientUuid: "a6e2745e-c930-4717-a9d1-d1cfb2a64ab2", Appname: "client1.inactsoft.com", ReportTitle: "Client Updated", CompanySubsId: "180-000-123-0321"}
+
+		expectRevision(mock, 4)
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(cc.ConfigClientUuid, cc.MultipleLanguageId, cc.Appname, cc.ReportTitle, cc.CompanySubsId, 0, 4).
+			WillReturnRows(sqlMock.NewRows([]string{"config_client_id", "config_client_uuid"}).AddRow(7, "a6e2745e-c930-4717-a9d1-d1cfb2a64aa4"))
+		mock.ExpectCommit()
+
+		created, err := repo.NewPgConfiguration(db).UpsertConfigurationClientBySubs(context.TODO(), cc)
+
+		assert.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, int64(7), cc.ConfigClientId)
+		assert.Equal(t, "a6e2745e-c930-4717-a9d1-d1cfb2a64aa4", cc.ConfigClientUuid)
+	})
+
+	t.Run("Error is rolled back", func(t *testing.T) {
+		cc := &pb.ConfigurationClient{ConfigClientUuid: "a6e2745e-c930-4717-a9d1-d1cfb2a64ac3", CompanySubsId: "180-000-123-0321"}
+
+		expectRevision(mock, 5)
+		mock.ExpectPrepare(query).ExpectQuery().WillReturnError(fmt.Errorf("Unexpected error"))
+		mock.ExpectRollback()
+
+		created, err := repo.NewPgConfiguration(db).UpsertConfigurationClientBySubs(context.TODO(), cc)
+
+		assert.Error(t, err)
+		assert.False(t, created)
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// Testing insert of company_subs_id that already has client, unique index is violated
+func TestAddConfigurationClientDuplicateSubs(t *testing.T) {
+	cc := &pb.ConfigurationClient{ConfigClientUuid: "111-111-111-111", CompanySubsId: "180-000-123-0321"}
+
+	db, mock, err := sqlMock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database conncection", err)
+	}
+
+	defer db.Close()
+
+	expectRevision(mock, 2)
+	mock.ExpectPrepare("INSERT INTO configuration_client").ExpectQuery().WillReturnError(&pq.Error{Code: "23505", Constraint: "configuration_client_company_subs_id_uq"})
+	mock.ExpectRollback()
+
+	created, err := repo.NewPgConfiguration(db).AddConfigurationClient(context.TODO(), cc)
+
+	assert.True(t, errors.Is(err, api.ErrAlreadyExists), "expected api.ErrAlreadyExists, got %v", err)
+	assert.False(t, created)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRepairDuplicateConfigurationClient(t *testing.T) {
+	db, mock, err := sqlMock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database conncection", err)
+	}
+
+	defer db.Close()
+
+	duplicateQuery := "(?s)SELECT c.config_client_id, .* FROM configuration_client c WHERE c.is_config_deleted = 0 AND EXISTS .* ORDER BY c.config_client_id"
+	columns := []string{"config_client_id", "config_client_uuid", "multiple_language_id", "appname", "report_title", "company_subs_id", "is_config_deleted", "revision"}
+	duplicateRows := func() *sqlMock.Rows {
+		return sqlMock.NewRows(columns).AddRow(4, "a6e2745e-c930-4717-a9d1-d1cfb2a64ab2", 2, "client1.inactsoft.com", "Client Satu Lagi", "180-000-123-0321", 0, 1)
+	}
+
+	t.Run("Duplicate is soft deleted and kept client get the same revision", func(t *testing.T) {
+		mock.ExpectPrepare(duplicateQuery).ExpectQuery().WillReturnRows(duplicateRows())
+		expectRevision(mock, 9)
+		mock.ExpectPrepare(duplicateQuery).ExpectQuery().WillReturnRows(duplicateRows())
+		mock.ExpectPrepare("UPDATE configuration_client SET is_config_deleted = 1, revision = \\$1 WHERE config_client_id = \\$2").ExpectExec().WithArgs(9, 4).WillReturnResult(sqlMock.NewResult(0, 1))
+		mock.ExpectPrepare("UPDATE configuration_client SET revision = \\$1 WHERE company_subs_id = \\$2 AND is_config_deleted = 0").ExpectExec().WithArgs(9, "180-000-123-0321").WillReturnResult(sqlMock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		repaired, err := repo.NewPgConfiguration(db).RepairDuplicateConfigurationClient(context.TODO())
+
+		assert.NoError(t, err)
+		if assert.Len(t, repaired, 1) {
+			assert.Equal(t, int64(4), repaired[0].ConfigClientId)
+			assert.Equal(t, int32(1), repaired[0].IsConfigDeleted)
+		}
+	})
+
+	t.Run("Revision is not increased without duplicate", func(t *testing.T) {
+		mock.ExpectPrepare(duplicateQuery).ExpectQuery().WillReturnRows(sqlMock.NewRows(columns))
+
+		repaired, err := repo.NewPgConfiguration(db).RepairDuplicateConfigurationClient(context.TODO())
+
+		assert.NoError(t, err)
+		assert.Empty(t, repaired)
+	})
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -61,8 +212,10 @@ func TestUpdateConfigurationClientByConfigClientIdSubs(t *testing.T) {
 
 	defer db.Close()
 
+	expectRevision(mock, 3)
 	prep := mock.ExpectPrepare("UPDATE configuration_client")
-	prep.ExpectExec().WithArgs(cc.MultipleLanguageId, cc.Appname, cc.ReportTitle, cc.CompanySubsId, cc.ConfigClientUuid).WillReturnResult(sqlMock.NewResult(0, 1))
+	prep.ExpectExec().WithArgs(cc.MultipleLanguageId, cc.Appname, cc.ReportTitle, cc.CompanySubsId, 3, cc.ConfigClientUuid).WillReturnResult(sqlMock.NewResult(0, 1))
+	mock.ExpectCommit()
 
 	clientRepo := repo.NewPgConfiguration(db)
 	updated, err := clientRepo.UpdateConfigurationClientBySubs(context.TODO(), cc)
@@ -71,6 +224,7 @@ func TestUpdateConfigurationClientByConfigClientIdSubs(t *testing.T) {
 	}
 
 	assert.True(t, updated)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 // Testing failed to update table configuration_client
@@ -91,14 +245,19 @@ func TestFailUpdateConfigurationClientBySubs(t *testing.T) {
 
 	defer db.Close()
 
+	expectRevision(mock, 3)
 	prep := mock.ExpectPrepare("UPDATE configuration_client")
-	prep.ExpectExec().WithArgs(cc.MultipleLanguageId, cc.Appname, cc.ReportTitle, cc.CompanySubsId, cc.ConfigClientUuid).WillReturnResult(sqlMock.NewResult(0, 0))
+	prep.ExpectExec().WithArgs(cc.MultipleLanguageId, cc.Appname, cc.ReportTitle, cc.CompanySubsId, 3, cc.ConfigClientUuid).WillReturnResult(sqlMock.NewResult(0, 0))
+
+	// revision is rolled back when no row is updated
+	mock.ExpectRollback()
 
 	clientRepo := repo.NewPgConfiguration(db)
 	updated, err := clientRepo.UpdateConfigurationClientBySubs(context.TODO(), cc)
 
 	assert.Error(t, err)
 	assert.False(t, updated)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 // Testing success to Delete (actually update flags is_deleted)
@@ -114,13 +273,16 @@ func TestDeleteConfigurationClientBySubs(t *testing.T) {
 
 	defer db.Close()
 
-	prepare := mock.ExpectPrepare("UPDATE configuration_client")
-	prepare.ExpectExec().WithArgs(cc.CompanySubsId).WillReturnResult(sqlMock.NewResult(0, 1))
+	expectRevision(mock, 4)
+	prepare := mock.ExpectPrepare("UPDATE configuration_client SET is_config_deleted = 1, revision = \\$1 WHERE company_subs_id = \\$2 AND is_config_deleted = 0")
+	prepare.ExpectExec().WithArgs(4, cc.CompanySubsId).WillReturnResult(sqlMock.NewResult(0, 1))
+	mock.ExpectCommit()
 
 	clientRepo := repo.NewPgConfiguration(db)
 	deleted, err := clientRepo.DeleteConfigurationClientBySubs(context.TODO(), cc)
 	assert.NoError(t, err)
 	assert.True(t, deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 // Testing success to Delete (actually update flags is_deleted)
@@ -136,13 +298,16 @@ func TestFailedDeleteConfigurationClientBySubs(t *testing.T) {
 
 	defer db.Close()
 
+	expectRevision(mock, 4)
 	prepare := mock.ExpectPrepare("UPDATE configuration_client")
-	prepare.ExpectExec().WithArgs(cc.CompanySubsId).WillReturnResult(sqlMock.NewResult(0, 0))
+	prepare.ExpectExec().WithArgs(4, cc.CompanySubsId).WillReturnResult(sqlMock.NewResult(0, 0))
+	mock.ExpectRollback()
 
 	clientRepo := repo.NewPgConfiguration(db)
 	deleted, err := clientRepo.DeleteConfigurationClientBySubs(context.TODO(), cc)
 	assert.Error(t, err)
 	assert.False(t, deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestGetConfigurationClientBySubs(t *testing.T) {
@@ -172,9 +337,9 @@ func TestGetConfigurationClientBySubs(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	rows := sqlMock.NewRows([]string{"config_client_id", "config_client_uuid", "multiple_language_id", "appname", "report_title", "company_subs_id", "is_config_deleted"}).AddRow(mockConfigurationClient[1].ConfigClientId, mockConfigurationClient[1].ConfigClientUuid, mockConfigurationClient[1].MultipleLanguageId, mockConfigurationClient[1].Appname, mockConfigurationClient[1].ReportTitle, mockConfigurationClient[1].CompanySubsId, mockConfigurationClient[1].IsConfigDeleted)
+	rows := sqlMock.NewRows([]string{"config_client_id", "config_client_uuid", "multiple_language_id", "appname", "report_title", "company_subs_id", "is_config_deleted", "revision"}).AddRow(mockConfigurationClient[1].ConfigClientId, mockConfigurationClient[1].ConfigClientUuid, mockConfigurationClient[1].MultipleLanguageId, mockConfigurationClient[1].Appname, mockConfigurationClient[1].ReportTitle, mockConfigurationClient[1].CompanySubsId, mockConfigurationClient[1].IsConfigDeleted, mockConfigurationClient[1].Revision)
 
-	mock.ExpectQuery("SELECT config_client_id, config_client_uuid, multiple_language_id, appname, report_title, company_subs_id, is_config_deleted FROM configuration_client WHERE company_subs_id = \\? AND is_config_deleted = 0").WillReturnRows(rows)
+	mock.ExpectPrepare("SELECT config_client_id, config_client_uuid, multiple_language_id, appname, report_title, company_subs_id, is_config_deleted, revision FROM configuration_client WHERE company_subs_id = \\$1 AND is_config_deleted = 0").ExpectQuery().WillReturnRows(rows)
 
 	clientRepo := repo.NewPgConfiguration(db)
 
@@ -191,9 +356,9 @@ func TestGetConfigurationClientBySubsNoData(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	rows := sqlMock.NewRows([]string{"config_client_id", "config_client_uuid", "multiple_language_id", "appname", "report_title", "company_subs_id", "is_config_deleted"})
+	rows := sqlMock.NewRows([]string{"config_client_id", "config_client_uuid", "multiple_language_id", "appname", "report_title", "company_subs_id", "is_config_deleted", "revision"})
 
-	mock.ExpectQuery("SELECT config_client_id, config_client_uuid, multiple_language_id, appname, report_title, company_subs_id, is_config_deleted FROM configuration_client WHERE company_subs_id = \\? AND is_config_deleted = 0").WillReturnRows(rows)
+	mock.ExpectPrepare("SELECT config_client_id, config_client_uuid, multiple_language_id, appname, report_title, company_subs_id, is_config_deleted, revision FROM configuration_client WHERE company_subs_id = \\$1 AND is_config_deleted = 0").ExpectQuery().WillReturnRows(rows)
 
 	clientRepo := repo.NewPgConfiguration(db)
 
@@ -230,9 +395,9 @@ func TestGetConfigurationClient(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	rows := sqlMock.NewRows([]string{"config_client_id", "config_client_uuid", "multiple_language_id", "appname", "report_title", "company_subs_id", "is_config_deleted"}).AddRow(mockConfigurationClient[0].ConfigClientId, mockConfigurationClient[0].ConfigClientUuid, mockConfigurationClient[0].MultipleLanguageId, mockConfigurationClient[0].Appname, mockConfigurationClient[0].ReportTitle, mockConfigurationClient[0].CompanySubsId, mockConfigurationClient[0].IsConfigDeleted).AddRow(mockConfigurationClient[1].ConfigClientId, mockConfigurationClient[1].ConfigClientUuid, mockConfigurationClient[1].MultipleLanguageId, mockConfigurationClient[1].Appname, mockConfigurationClient[1].ReportTitle, mockConfigurationClient[1].CompanySubsId, mockConfigurationClient[1].IsConfigDeleted)
+	rows := sqlMock.NewRows([]string{"config_client_id", "config_client_uuid", "multiple_language_id", "appname", "report_title", "company_subs_id", "is_config_deleted", "revision"}).AddRow(mockConfigurationClient[0].ConfigClientId, mockConfigurationClient[0].ConfigClientUuid, mockConfigurationClient[0].MultipleLanguageId, mockConfigurationClient[0].Appname, mockConfigurationClient[0].ReportTitle, mockConfigurationClient[0].CompanySubsId, mockConfigurationClient[0].IsConfigDeleted, mockConfigurationClient[0].Revision).AddRow(mockConfigurationClient[1].ConfigClientId, mockConfigurationClient[1].ConfigClientUuid, mockConfigurationClient[1].MultipleLanguageId, mockConfigurationClient[1].Appname, mockConfigurationClient[1].ReportTitle, mockConfigurationClient[1].CompanySubsId, mockConfigurationClient[1].IsConfigDeleted, mockConfigurationClient[1].Revision)
 
-	mock.ExpectQuery("SELECT config_client_id, config_client_uuid, multiple_language_id, appname, report_title, company_subs_id, is_config_deleted FROM configuration_client").WillReturnRows(rows)
+	mock.ExpectPrepare("SELECT config_client_id, config_client_uuid, multiple_language_id, appname, report_title, company_subs_id, is_config_deleted, revision FROM configuration_client").ExpectQuery().WillReturnRows(rows)
 
 	clientRepo := repo.NewPgConfiguration(db)
 	res, err := clientRepo.GetConfigurationClient(context.TODO())
@@ -246,9 +411,9 @@ func TestGetConfigurationClientNoData(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	rows := sqlMock.NewRows([]string{"config_client_id", "config_client_uuid", "multiple_language_id", "appname", "report_title", "company_subs_id", "is_config_deleted"})
+	rows := sqlMock.NewRows([]string{"config_client_id", "config_client_uuid", "multiple_language_id", "appname", "report_title", "company_subs_id", "is_config_deleted", "revision"})
 
-	mock.ExpectQuery("SELECT config_client_id, config_client_uuid, multiple_language_id, appname, report_title, company_subs_id, is_config_deleted FROM configuration_client").WillReturnRows(rows)
+	mock.ExpectPrepare("SELECT config_client_id, config_client_uuid, multiple_language_id, appname, report_title, company_subs_id, is_config_deleted, revision FROM configuration_client").ExpectQuery().WillReturnRows(rows)
 
 	clientRepo := repo.NewPgConfiguration(db)
 	res, err := clientRepo.GetConfigurationClient(context.TODO())
@@ -274,13 +439,17 @@ func TestAddConfigurationGlobal(t *testing.T) {
 
 	defer db.Close()
 
-	prep := mock.ExpectPrepare("INSERT configuration_global")
-	prep.ExpectExec().WithArgs(cg.Footertext, cg.ServerSmpt, cg.Ssl, cg.Port, cg.IsAuth, cg.Username, cg.Password).WillReturnResult(sqlMock.NewResult(1, 1))
+	expectRevision(mock, 5)
+	prep := mock.ExpectPrepare("INSERT INTO configuration_global .* RETURNING config_global_id")
+	prep.ExpectQuery().WithArgs(cg.Footertext, cg.ServerSmpt, cg.Ssl, cg.Port, cg.IsAuth, cg.Username, cg.Password, 5).WillReturnRows(sqlMock.NewRows([]string{"config_global_id"}).AddRow(4))
+	mock.ExpectCommit()
 
 	clientRepo := repo.NewPgConfiguration(db)
 	created, err := clientRepo.AddConfigurationGlobal(context.TODO(), cg)
 	assert.True(t, created)
 	assert.NoError(t, err)
+	assert.Equal(t, int32(4), cg.ConfigGlobalId)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestUpdateConfigurationGlobal(t *testing.T) {
@@ -302,8 +471,10 @@ func TestUpdateConfigurationGlobal(t *testing.T) {
 
 	defer db.Close()
 
+	expectRevision(mock, 6)
 	prep := mock.ExpectPrepare("UPDATE configuration_global")
-	prep.ExpectExec().WithArgs(cg.Footertext, cg.ServerSmpt, cg.Ssl, cg.Port, cg.IsAuth, cg.Username, cg.Password, cg.ConfigGlobalId).WillReturnResult(sqlMock.NewResult(1, 1))
+	prep.ExpectExec().WithArgs(cg.Footertext, cg.ServerSmpt, cg.Ssl, cg.Port, cg.IsAuth, cg.Username, cg.Password, 6, cg.ConfigGlobalId).WillReturnResult(sqlMock.NewResult(1, 1))
+	mock.ExpectCommit()
 
 	configRepo := repo.NewPgConfiguration(db)
 	updated, err := configRepo.UpdateConfigurationGlobal(context.TODO(), cg)
@@ -330,8 +501,10 @@ func TestUpdateConfigurationGlobalNoData(t *testing.T) {
 
 	defer db.Close()
 
+	expectRevision(mock, 6)
 	prep := mock.ExpectPrepare("UPDATE configuration_global")
-	prep.ExpectExec().WithArgs(cg.Footertext, cg.ServerSmpt, cg.Ssl, cg.Port, cg.IsAuth, cg.Username, cg.Password, cg.ConfigGlobalId).WillReturnResult(sqlMock.NewResult(0, 0))
+	prep.ExpectExec().WithArgs(cg.Footertext, cg.ServerSmpt, cg.Ssl, cg.Port, cg.IsAuth, cg.Username, cg.Password, 6, cg.ConfigGlobalId).WillReturnResult(sqlMock.NewResult(0, 0))
+	mock.ExpectRollback()
 
 	configRepo := repo.NewPgConfiguration(db)
 	updated, err := configRepo.UpdateConfigurationGlobal(context.TODO(), cg)
@@ -349,14 +522,18 @@ func TestDeleteConfiguration(t *testing.T) {
 
 	cg := &pb.ConfigurationGlobal{ConfigGlobalId: 1}
 
+	expectRevision(mock, 7)
 	prep := mock.ExpectPrepare("DELETE FROM configuration_global")
 	prep.ExpectExec().WithArgs(cg.ConfigGlobalId).WillReturnResult(sqlMock.NewResult(0, 1))
+	mock.ExpectPrepare("INSERT INTO configuration_global_tombstone").ExpectExec().WithArgs(cg.ConfigGlobalId, 7).WillReturnResult(sqlMock.NewResult(0, 1))
+	mock.ExpectCommit()
 
 	configRepo := repo.NewPgConfiguration(db)
 	deleted, err := configRepo.DeleteConfiguration(context.TODO(), cg.ConfigGlobalId)
 
 	assert.True(t, deleted)
 	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestFailDeleteConfigurationNoData(t *testing.T) {
@@ -369,8 +546,10 @@ func TestFailDeleteConfigurationNoData(t *testing.T) {
 
 	cg := &pb.ConfigurationGlobal{ConfigGlobalId: 1}
 
+	expectRevision(mock, 7)
 	prep := mock.ExpectPrepare("DELETE FROM configuration_global")
 	prep.ExpectExec().WillReturnResult(sqlMock.NewResult(0, 0))
+	mock.ExpectRollback()
 
 	configRepo := repo.NewPgConfiguration(db)
 	deleted, err := configRepo.DeleteConfiguration(context.TODO(), cg.ConfigGlobalId)
@@ -389,8 +568,10 @@ func TestFailDeleteConfigurationSyntaxErr(t *testing.T) {
 
 	cg := &pb.ConfigurationGlobal{ConfigGlobalId: 1}
 
+	expectRevision(mock, 7)
 	prep := mock.ExpectPrepare("DELETE FROM configuration_global")
 	prep.ExpectExec().WillReturnError(fmt.Errorf("configuration_global_id not exists"))
+	mock.ExpectRollback()
 
 	configRepo := repo.NewPgConfiguration(db)
 	deleted, err := configRepo.DeleteConfiguration(context.TODO(), cg.ConfigGlobalId)
@@ -435,7 +616,7 @@ func TestGetConfigurationGlobal(t *testing.T) {
 	}
 
 	db, mock, err := sqlMock.New()
-	query := "SELECT config_global_id, footertext, server_smpt, ssl, port, is_auth, username, password, is_active FROM configuration_global"
+	query := "SELECT config_global_id, footertext, server_smpt, \"ssl\", port, is_auth, username, password, is_active FROM configuration_global"
 
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database conncection", err)
@@ -446,7 +627,7 @@ func TestGetConfigurationGlobal(t *testing.T) {
 	t.Run("Get Configuration Global Return all data", func(t *testing.T) {
 		rows := sqlMock.NewRows([]string{"config_global_id", "footertext", "server_smpt", "ssl", "port", "is_auth", "username", "password", "is_active"}).AddRow(mockConfigurationGlobal[0].ConfigGlobalId, mockConfigurationGlobal[0].Footertext, mockConfigurationGlobal[0].ServerSmpt, mockConfigurationGlobal[0].Ssl, mockConfigurationGlobal[0].Port, mockConfigurationGlobal[0].IsAuth, mockConfigurationGlobal[0].Username, mockConfigurationGlobal[0].Password, mockConfigurationGlobal[0].IsActive).AddRow(mockConfigurationGlobal[1].ConfigGlobalId, mockConfigurationGlobal[1].Footertext, mockConfigurationGlobal[1].ServerSmpt, mockConfigurationGlobal[1].Ssl, mockConfigurationGlobal[1].Port, mockConfigurationGlobal[1].IsAuth, mockConfigurationGlobal[1].Username, mockConfigurationGlobal[1].Password, mockConfigurationGlobal[1].IsActive).AddRow(mockConfigurationGlobal[2].ConfigGlobalId, mockConfigurationGlobal[2].Footertext, mockConfigurationGlobal[2].ServerSmpt, mockConfigurationGlobal[2].Ssl, mockConfigurationGlobal[2].Port, mockConfigurationGlobal[2].IsAuth, mockConfigurationGlobal[2].Username, mockConfigurationGlobal[2].Password, mockConfigurationGlobal[2].IsActive)
 
-		sqlrows := mock.ExpectQuery(query)
+		sqlrows := mock.ExpectPrepare(query).ExpectQuery()
 		sqlrows.WillReturnRows(rows)
 
 		configRepo := repo.NewPgConfiguration(db)
@@ -456,7 +637,7 @@ func TestGetConfigurationGlobal(t *testing.T) {
 	})
 
 	t.Run("Get Configuration Global, but error failed query", func(t *testing.T) {
-		sqlRows := mock.ExpectQuery(query)
+		sqlRows := mock.ExpectPrepare(query).ExpectQuery()
 		sqlRows.WillReturnError(fmt.Errorf("table or column doesnt exists"))
 
 		clientRepo := repo.NewPgConfiguration(db)
@@ -468,7 +649,7 @@ func TestGetConfigurationGlobal(t *testing.T) {
 	t.Run("Get Configuration, but error scan because nil data", func(t *testing.T) {
 		rows := sqlMock.NewRows([]string{"config_global_id", "footertext", "server_smpt", "ssl", "port", "is_auth", "username", "password", "is_active"}).AddRow(mockConfigurationGlobal[0].ConfigGlobalId, nil, mockConfigurationGlobal[0].ServerSmpt, mockConfigurationGlobal[0].Ssl, mockConfigurationGlobal[0].Port, mockConfigurationGlobal[0].IsAuth, mockConfigurationGlobal[0].Username, mockConfigurationGlobal[0].Password, mockConfigurationGlobal[0].IsActive)
 
-		sqlRows := mock.ExpectQuery(query)
+		sqlRows := mock.ExpectPrepare(query).ExpectQuery()
 		sqlRows.WillReturnRows(rows)
 
 		clientRepo := repo.NewPgConfiguration(db)
@@ -515,7 +696,7 @@ func TestGetConfigurationGlobalByID(t *testing.T) {
 	}
 
 	db, mock, err := sqlMock.New()
-	query := "SELECT config_global_id, footertext, server_smpt, ssl, port, is_auth, username, password, is_active FROM configuration_global WHERE config_global_id = \\?"
+	query := "SELECT config_global_id, footertext, server_smpt, \"ssl\", port, is_auth, username, password, is_active FROM configuration_global WHERE config_global_id = \\$1"
 
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database conncection", err)
@@ -526,7 +707,7 @@ func TestGetConfigurationGlobalByID(t *testing.T) {
 	t.Run("Get Configuration Global using condition configuration id", func(t *testing.T) {
 		rows := sqlMock.NewRows([]string{"config_global_id", "footertext", "server_smpt", "ssl", "port", "is_auth", "username", "password", "is_active"}).AddRow(mockConfigurationGlobal[0].ConfigGlobalId, mockConfigurationGlobal[0].Footertext, mockConfigurationGlobal[0].ServerSmpt, mockConfigurationGlobal[0].Ssl, mockConfigurationGlobal[0].Port, mockConfigurationGlobal[0].IsAuth, mockConfigurationGlobal[0].Username, mockConfigurationGlobal[0].Password, mockConfigurationGlobal[0].IsActive)
 
-		sqlRows := mock.ExpectQuery(query)
+		sqlRows := mock.ExpectPrepare(query).ExpectQuery()
 		sqlRows.WithArgs(mockConfigurationGlobal[0].ConfigGlobalId).WillReturnRows(rows)
 
 		clientRepo := repo.NewPgConfiguration(db)
@@ -538,17 +719,17 @@ func TestGetConfigurationGlobalByID(t *testing.T) {
 	t.Run("Get Configuration Global using condition configuration id, when no data", func(t *testing.T) {
 		rows := sqlMock.NewRows([]string{"config_global_id", "footertext", "server_smpt", "ssl", "port", "is_auth", "username", "password", "is_active"})
 
-		sqlRows := mock.ExpectQuery(query)
+		sqlRows := mock.ExpectPrepare(query).ExpectQuery()
 		sqlRows.WithArgs(mockConfigurationGlobal[0].ConfigGlobalId).WillReturnRows(rows)
 
 		clientRepo := repo.NewPgConfiguration(db)
 		data, err := clientRepo.GetConfigurationGlobalByID(context.TODO(), mockConfigurationGlobal[0].ConfigGlobalId)
-		assert.NoError(t, err)
+		assert.True(t, errors.Is(err, api.ErrNotFound), "expected api.ErrNotFound, got %v", err)
 		assert.Nil(t, data)
 	})
 
 	t.Run("Get Configuration Global using condition configuration id, then syntax error", func(t *testing.T) {
-		sqlRows := mock.ExpectQuery(query)
+		sqlRows := mock.ExpectPrepare(query).ExpectQuery()
 		sqlRows.WithArgs(mockConfigurationGlobal[0].ConfigGlobalId).WillReturnError(fmt.Errorf("Sql syntax error"))
 
 		clientRepo := repo.NewPgConfiguration(db)
@@ -594,7 +775,7 @@ func TestGetConfigurationGlobalActive(t *testing.T) {
 	}
 
 	db, mock, err := sqlMock.New()
-	query := "SELECT config_global_id, footertext, server_smpt, ssl, port, is_auth, username, password, is_active FROM configuration_global WHERE is_active = \\?"
+	query := "SELECT config_global_id, footertext, server_smpt, \"ssl\", port, is_auth, username, password, is_active FROM configuration_global WHERE is_active = \\$1"
 
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database conncection", err)
@@ -605,7 +786,7 @@ func TestGetConfigurationGlobalActive(t *testing.T) {
 	t.Run("Get Configuration Global Active (Success)", func(t *testing.T) {
 		rows := sqlMock.NewRows([]string{"config_global_id", "footertext", "server_smpt", "ssl", "port", "is_auth", "username", "password", "is_active"}).AddRow(mockConfigurationGlobal[2].ConfigGlobalId, mockConfigurationGlobal[2].Footertext, mockConfigurationGlobal[2].ServerSmpt, mockConfigurationGlobal[2].Ssl, mockConfigurationGlobal[2].Port, mockConfigurationGlobal[2].IsAuth, mockConfigurationGlobal[2].Username, mockConfigurationGlobal[2].Password, mockConfigurationGlobal[2].IsActive)
 
-		queryExpect := mock.ExpectQuery(query)
+		queryExpect := mock.ExpectPrepare(query).ExpectQuery()
 		queryExpect.WithArgs(mockConfigurationGlobal[2].IsActive).WillReturnRows(rows)
 
 		clientRepo := repo.NewPgConfiguration(db)
@@ -615,7 +796,7 @@ func TestGetConfigurationGlobalActive(t *testing.T) {
 	})
 
 	t.Run("Get Configuration Global Active. SQL syntax error", func(t *testing.T) {
-		queryExpect := mock.ExpectQuery(query)
+		queryExpect := mock.ExpectPrepare(query).ExpectQuery()
 		queryExpect.WithArgs(mockConfigurationGlobal[2].IsActive).WillReturnError(fmt.Errorf("SQL syntax error"))
 
 		clientRepo := repo.NewPgConfiguration(db)
@@ -627,7 +808,7 @@ func TestGetConfigurationGlobalActive(t *testing.T) {
 	t.Run("Get Configuration Global Active. Data Nil Fom DB", func(t *testing.T) {
 		rows := sqlMock.NewRows([]string{"config_global_id", "footertext", "server_smpt", "ssl", "port", "is_auth", "username", "password", "is_active"}).AddRow(mockConfigurationGlobal[2].ConfigGlobalId, mockConfigurationGlobal[2].Footertext, mockConfigurationGlobal[2].ServerSmpt, mockConfigurationGlobal[2].Ssl, mockConfigurationGlobal[2].Port, mockConfigurationGlobal[2].IsAuth, nil, mockConfigurationGlobal[2].Password, mockConfigurationGlobal[2].IsActive)
 
-		queryExpect := mock.ExpectQuery(query)
+		queryExpect := mock.ExpectPrepare(query).ExpectQuery()
 		queryExpect.WithArgs(mockConfigurationGlobal[2].IsActive).WillReturnRows(rows)
 
 		clientRepo := repo.NewPgConfiguration(db)
@@ -639,7 +820,7 @@ func TestGetConfigurationGlobalActive(t *testing.T) {
 	t.Run("Get Configuration Global Active. No DataData", func(t *testing.T) {
 		rows := sqlMock.NewRows([]string{"config_global_id", "footertext", "server_smpt", "ssl", "port", "is_auth", "username", "password", "is_active"})
 
-		queryExpect := mock.ExpectQuery(query)
+		queryExpect := mock.ExpectPrepare(query).ExpectQuery()
 		queryExpect.WithArgs(mockConfigurationGlobal[2].IsActive).WillReturnRows(rows)
 
 		clientRepo := repo.NewPgConfiguration(db)
@@ -649,3 +830,283 @@ func TestGetConfigurationGlobalActive(t *testing.T) {
 	})
 
 }
+
+func TestSetConfigurationGlobalActive(t *testing.T) {
+	db, mock, err := sqlMock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database conncection", err)
+	}
+
+	defer db.Close()
+
+	deactivateQuery := "UPDATE configuration_global SET is_active = \\$1, revision = \\$2 WHERE is_active = \\$3 AND config_global_id <> \\$4"
+	activateQuery := "UPDATE configuration_global SET is_active = \\$1, revision = \\$2 WHERE config_global_id = \\$3"
+
+	t.Run("Set Configuration Global Active in one transaction", func(t *testing.T) {
+		expectRevision(mock, 8)
+		mock.ExpectPrepare(deactivateQuery).ExpectExec().WithArgs(false, 8, true, int32(3)).WillReturnResult(sqlMock.NewResult(0, 1))
+		mock.ExpectPrepare(activateQuery).ExpectExec().WithArgs(true, 8, int32(3)).WillReturnResult(sqlMock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		configRepo := repo.NewPgConfiguration(db)
+		updated, err := configRepo.SetConfigurationGlobalActive(context.TODO(), 3)
+		assert.NoError(t, err)
+		assert.True(t, updated)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Set Configuration Global Active, rollback when data not found", func(t *testing.T) {
+		expectRevision(mock, 8)
+		mock.ExpectPrepare(deactivateQuery).ExpectExec().WithArgs(false, 8, true, int32(9)).WillReturnResult(sqlMock.NewResult(0, 1))
+		mock.ExpectPrepare(activateQuery).ExpectExec().WithArgs(true, 8, int32(9)).WillReturnResult(sqlMock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		configRepo := repo.NewPgConfiguration(db)
+		updated, err := configRepo.SetConfigurationGlobalActive(context.TODO(), 9)
+		assert.Error(t, err)
+		assert.False(t, updated)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Set Configuration Global Active, rollback when unique index violated", func(t *testing.T) {
+		expectRevision(mock, 8)
+		mock.ExpectPrepare(deactivateQuery).ExpectExec().WithArgs(false, 8, true, int32(3)).WillReturnResult(sqlMock.NewResult(0, 1))
+		mock.ExpectPrepare(activateQuery).ExpectExec().WithArgs(true, 8, int32(3)).WillReturnError(fmt.Errorf("duplicate key value violates unique constraint \"configuration_global_is_active_uq\""))
+		mock.ExpectRollback()
+
+		configRepo := repo.NewPgConfiguration(db)
+		updated, err := configRepo.SetConfigurationGlobalActive(context.TODO(), 3)
+		assert.Error(t, err)
+		assert.False(t, updated)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestWithinTx(t *testing.T) {
+	db, mock, err := sqlMock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database conncection", err)
+	}
+
+	defer db.Close()
+
+	cc := &pb.ConfigurationClient{
+		CompanySubsId: "180-000-123-0321",
+	}
+
+	t.Run("Commit when all of repository call success", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectNextRevision(mock, 2)
+		mock.ExpectPrepare("UPDATE configuration_client").ExpectExec().WithArgs(2, cc.CompanySubsId).WillReturnResult(sqlMock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		configRepo := repo.NewPgConfiguration(db)
+		err := configRepo.WithinTx(context.TODO(), func(txRepo api.Repository) error {
+			if _, err := txRepo.DeleteConfigurationClientBySubs(context.TODO(), cc); err != nil {
+				return err
+			}
+
+			// nested unit of work join the transaction, so no other transaction begin
+			return txRepo.WithinTx(context.TODO(), func(api.Repository) error {
+				return nil
+			})
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Rollback when one of repository call failed", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectNextRevision(mock, 2)
+		mock.ExpectPrepare("UPDATE configuration_client").ExpectExec().WithArgs(2, cc.CompanySubsId).WillReturnResult(sqlMock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		configRepo := repo.NewPgConfiguration(db)
+		err := configRepo.WithinTx(context.TODO(), func(txRepo api.Repository) error {
+			_, err := txRepo.DeleteConfigurationClientBySubs(context.TODO(), cc)
+			return err
+		})
+
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Rollback when unit of work panic", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		configRepo := repo.NewPgConfiguration(db)
+		assert.Panics(t, func() {
+			configRepo.WithinTx(context.TODO(), func(api.Repository) error {
+				panic("unexpected")
+			})
+		})
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPreparedStatementCache(t *testing.T) {
+	db, mock, err := sqlMock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database conncection", err)
+	}
+
+	defer db.Close()
+
+	query := "SELECT config_client_id, config_client_uuid, multiple_language_id, appname, report_title, company_subs_id, is_config_deleted, revision FROM configuration_client WHERE company_subs_id = \\$1 AND is_config_deleted = 0"
+	columns := []string{"config_client_id", "config_client_uuid", "multiple_language_id", "appname", "report_title", "company_subs_id", "is_config_deleted", "revision"}
+
+	// query is prepared only once, then the statement is reused until repository closed
+	prep := mock.ExpectPrepare(query)
+	for i := 0; i < 10; i++ {
+		prep.ExpectQuery().WithArgs("180-000-123-0321").WillReturnRows(sqlMock.NewRows(columns).AddRow(1, "a6e2745e-c930-4717-a9d1-d1cfb2a64aa4", 3, "client1.inactsoft.com", "Client 1", "180-000-123-0321", 0, 1))
+	}
+	prep.WillBeClosed()
+
+	configRepo := repo.NewPgConfiguration(db)
+	for i := 0; i < 10; i++ {
+		res, err := configRepo.GetConfigurationClientBySubs(context.TODO(), "180-000-123-0321")
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+	}
+
+	closer, ok := configRepo.(io.Closer)
+	if !ok {
+		t.Fatalf("repository must implement io.Closer")
+	}
+
+	assert.NoError(t, closer.Close())
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	res, err := configRepo.GetConfigurationClientBySubs(context.TODO(), "180-000-123-0321")
+	assert.Error(t, err)
+	assert.Nil(t, res)
+}
+
+func TestAddOutboxEvent(t *testing.T) {
+	db, mock, err := sqlMock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database conncection", err)
+	}
+
+	defer db.Close()
+
+	ev := &api.OutboxEvent{Topic: api.TopicClientCreated, Key: "client:111-111-111-111", Payload: []byte{10, 1, 49}}
+
+	mock.ExpectBegin()
+	prep := mock.ExpectPrepare("INSERT INTO outbox .* RETURNING id")
+	prep.ExpectQuery().WithArgs(ev.Topic, ev.Key, ev.Payload, 0, sqlMock.AnyArg(), sqlMock.AnyArg()).WillReturnRows(sqlMock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectCommit()
+
+	clientRepo := repo.NewPgConfiguration(db)
+	err = clientRepo.WithinTx(context.TODO(), func(txRepo api.Repository) error {
+		return txRepo.AddOutboxEvent(context.TODO(), ev)
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), ev.ID)
+	assert.False(t, ev.CreatedAt.IsZero())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLockOutbox(t *testing.T) {
+	db, mock, err := sqlMock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database conncection", err)
+	}
+
+	defer db.Close()
+
+	outbox := repo.NewPgConfiguration(db).(api.Outbox)
+
+	t.Run("Lock is taken then released", func(t *testing.T) {
+		mock.ExpectQuery("SELECT pg_try_advisory_lock\\(7317100517\\)").WillReturnRows(sqlMock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(true))
+		mock.ExpectExec("SELECT pg_advisory_unlock\\(7317100517\\)").WillReturnResult(sqlMock.NewResult(0, 0))
+
+		unlock, locked, err := outbox.LockOutbox(context.TODO())
+		assert.NoError(t, err)
+		assert.True(t, locked)
+
+		unlock()
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Lock is held by other relay", func(t *testing.T) {
+		mock.ExpectQuery("SELECT pg_try_advisory_lock\\(7317100517\\)").WillReturnRows(sqlMock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(false))
+
+		_, locked, err := outbox.LockOutbox(context.TODO())
+		assert.NoError(t, err)
+		assert.False(t, locked)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPendingOutboxEvents(t *testing.T) {
+	db, mock, err := sqlMock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database conncection", err)
+	}
+
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectPrepare("WHERE o.dead_at IS NULL AND o.next_attempt_at <= \\$1\\s+AND NOT EXISTS \\(.* w.next_attempt_at > \\$2\\)\\s+ORDER BY o.id LIMIT \\$3").ExpectQuery().
+		WithArgs(now.UTC(), now.UTC(), 10).
+		WillReturnRows(sqlMock.NewRows([]string{"id", "topic", "event_key", "payload", "attempts", "next_attempt_at", "created_at"}).AddRow(7, api.TopicClientCreated, "client:a", []byte{1}, 0, now, now))
+
+	pending, err := repo.NewPgConfiguration(db).(api.Outbox).PendingOutboxEvents(context.TODO(), now, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, pending, 1) {
+		assert.Equal(t, int64(7), pending[0].ID)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSyncConfiguration(t *testing.T) {
+	db, mock, err := sqlMock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database conncection", err)
+	}
+
+	defer db.Close()
+
+	t.Run("Sync return change between since and head revision", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectPrepare("SELECT revision FROM configuration_revision WHERE id = 1").ExpectQuery().WillReturnRows(sqlMock.NewRows([]string{"revision"}).AddRow(12))
+		mock.ExpectPrepare("FROM configuration_client WHERE is_config_deleted = 0 AND revision > \\$1 AND revision <= \\$2").ExpectQuery().WithArgs(10, 12).
+			WillReturnRows(sqlMock.NewRows([]string{"config_client_id", "config_client_uuid", "multiple_language_id", "appname", "report_title", "company_subs_id", "is_config_deleted", "revision"}).AddRow(1, "a6e2745e-c930-4717-a9d1-d1cfb2a64aa4", 3, "client1.inactsoft.com", "Client 1", "180-000-123-0321", 0, 1))
+		mock.ExpectPrepare("SELECT company_subs_id, revision FROM configuration_client WHERE is_config_deleted = 1").ExpectQuery().WithArgs(10, 12).
+			WillReturnRows(sqlMock.NewRows([]string{"company_subs_id", "revision"}).AddRow("180-000-123-0322", 11))
+		mock.ExpectPrepare("FROM configuration_global WHERE revision > \\$1 AND revision <= \\$2").ExpectQuery().WithArgs(10, 12).
+			WillReturnRows(sqlMock.NewRows([]string{"config_global_id", "footertext", "server_smpt", "ssl", "port", "is_auth", "username", "password", "is_active"}))
+		mock.ExpectPrepare("SELECT config_global_id, revision FROM configuration_global_tombstone").ExpectQuery().WithArgs(10, 12).
+			WillReturnRows(sqlMock.NewRows([]string{"config_global_id", "revision"}).AddRow(4, 12))
+		mock.ExpectCommit()
+
+		res, err := repo.NewPgConfiguration(db).SyncConfiguration(context.TODO(), 10)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12), res.Revision)
+		assert.Len(t, res.Configclients, 1)
+		assert.Empty(t, res.Configglobals)
+		assert.Equal(t, []*pb.ClientTombstone{{CompanySubsId: "180-000-123-0322", Revision: 11}}, res.DeletedClients)
+		assert.Equal(t, []*pb.GlobalTombstone{{ConfigGlobalId: 4, Revision: 12}}, res.DeletedGlobals)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Sync with head revision only read the head", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectPrepare("SELECT revision FROM configuration_revision WHERE id = 1").ExpectQuery().WillReturnRows(sqlMock.NewRows([]string{"revision"}).AddRow(12))
+		mock.ExpectCommit()
+
+		res, err := repo.NewPgConfiguration(db).SyncConfiguration(context.TODO(), 12)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12), res.Revision)
+		assert.Empty(t, res.Configclients)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
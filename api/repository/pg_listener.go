@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// NotifyChannel is postgres channel that receive key of changed configuration, it is notified by trigger of migration notify_configuration_changed
+const NotifyChannel = "configuration_changed"
+
+// EvictOnNotification evict entry of cache for every notification, until ctx done or notifications closed.
+// nil notification is sent when connection reestablished, notification may be lost while disconnected so all entries are evicted
+func EvictOnNotification(ctx context.Context, notifications <-chan *pq.Notification, cache CacheInvalidator) {
+	for {
+		select {
+		case n, ok := <-notifications:
+			if !ok {
+				return
+			}
+
+			if n == nil {
+				cache.InvalidateAll()
+				continue
+			}
+
+			cache.InvalidateKey(n.Extra)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// PgInvalidationListener listen NotifyChannel and evict cache of other instance write. pq.Listener reconnect automatically when connection dropped
+type PgInvalidationListener struct {
+	listener *pq.Listener
+	cancel   context.CancelFunc
+}
+
+// NewPgInvalidationListener start listening NotifyChannel of database dsn in background
+func NewPgInvalidationListener(dsn string, cache CacheInvalidator) (*PgInvalidationListener, error) {
+	listener := pq.NewListener(dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("configuration cache listener: %v", err)
+		}
+	})
+
+	if err := listener.Listen(NotifyChannel); err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go EvictOnNotification(ctx, listener.Notify, cache)
+
+	// ping detect connection that dropped silently, so the listener reconnect
+	go func() {
+		ticker := time.NewTicker(30 * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				listener.Ping()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return &PgInvalidationListener{listener: listener, cancel: cancel}, nil
+}
+
+// Close stop listening
+func (l *PgInvalidationListener) Close() error {
+	l.cancel()
+	return l.listener.Close()
+}
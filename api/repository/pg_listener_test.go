@@ -0,0 +1,50 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/muhammadhidayah/configuration-service/api/mocks"
+	repo "github.com/muhammadhidayah/configuration-service/api/repository"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEvictOnNotification(t *testing.T) {
+	mockConfigRepo := new(mocks.Repository)
+	mockConfigRepo.On("GetConfigurationClientBySubs", mock.Anything, "012-031-234-542").Return(&pb.ConfigurationClient{CompanySubsId: "012-031-234-542"}, nil).Times(3)
+	mockConfigRepo.On("GetConfigurationGlobalActive", mock.Anything).Return(&pb.ConfigurationGlobal{ConfigGlobalId: 3, IsActive: true}, nil).Twice()
+
+	cachedRepo := repo.NewCachedConfiguration(mockConfigRepo, time.Minute)
+	lookup := func() {
+		_, err := cachedRepo.GetConfigurationClientBySubs(context.TODO(), "012-031-234-542")
+		assert.NoError(t, err)
+		_, err = cachedRepo.GetConfigurationGlobalActive(context.TODO())
+		assert.NoError(t, err)
+	}
+
+	// notify send n to EvictOnNotification, then wait until it is handled
+	notify := func(n *pq.Notification) {
+		notifications := make(chan *pq.Notification, 1)
+		notifications <- n
+		close(notifications)
+
+		repo.EvictOnNotification(context.TODO(), notifications, cachedRepo.(repo.CacheInvalidator))
+	}
+
+	lookup()
+	lookup()
+
+	// only client entry is evicted, active global still cached
+	notify(&pq.Notification{Channel: repo.NotifyChannel, Extra: "client:012-031-234-542"})
+	lookup()
+
+	// nil notification is sent after reconnect, all entries evicted
+	notify(nil)
+	lookup()
+
+	mockConfigRepo.AssertExpectations(t)
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Column is one column that read or written by repository. Types is data_type of information_schema that can be scanned by repository
+type Column struct {
+	Table string
+	Name  string
+	Types []string
+}
+
+// PgExpectedColumns is every column used by pgConfiguration
+var PgExpectedColumns = []Column{
+	{Table: "configuration_client", Name: "config_client_id", Types: []string{"integer", "bigint"}},
+	{Table: "configuration_client", Name: "config_client_uuid", Types: []string{"character varying", "text", "uuid"}},
+	{Table: "configuration_client", Name: "multiple_language_id", Types: []string{"integer", "smallint"}},
+	{Table: "configuration_client", Name: "appname", Types: []string{"character varying", "text"}},
+	{Table: "configuration_client", Name: "report_title", Types: []string{"character varying", "text"}},
+	{Table: "configuration_client", Name: "company_subs_id", Types: []string{"character varying", "text"}},
+	{Table: "configuration_client", Name: "is_config_deleted", Types: []string{"integer", "smallint"}},
+	{Table: "configuration_client", Name: "revision", Types: []string{"bigint"}},
+
+	{Table: "configuration_global", Name: "config_global_id", Types: []string{"integer"}},
+	{Table: "configuration_global", Name: "footertext", Types: []string{"text", "character varying"}},
+	{Table: "configuration_global", Name: "server_smpt", Types: []string{"text", "character varying"}},
+	{Table: "configuration_global", Name: "ssl", Types: []string{"boolean"}},
+	{Table: "configuration_global", Name: "port", Types: []string{"bigint", "integer"}},
+	{Table: "configuration_global", Name: "is_auth", Types: []string{"boolean"}},
+	{Table: "configuration_global", Name: "username", Types: []string{"character varying", "text"}},
+	{Table: "configuration_global", Name: "password", Types: []string{"character varying", "text"}},
+	{Table: "configuration_global", Name: "is_active", Types: []string{"boolean"}},
+	{Table: "configuration_global", Name: "revision", Types: []string{"bigint"}},
+
+	{Table: "configuration_global_tombstone", Name: "config_global_id", Types: []string{"integer"}},
+	{Table: "configuration_global_tombstone", Name: "revision", Types: []string{"bigint"}},
+
+	{Table: "configuration_revision", Name: "id", Types: []string{"integer", "smallint"}},
+	{Table: "configuration_revision", Name: "revision", Types: []string{"bigint"}},
+
+	{Table: "outbox", Name: "id", Types: []string{"bigint"}},
+	{Table: "outbox", Name: "topic", Types: []string{"character varying", "text"}},
+	{Table: "outbox", Name: "event_key", Types: []string{"character varying", "text"}},
+	{Table: "outbox", Name: "payload", Types: []string{"bytea"}},
+	{Table: "outbox", Name: "attempts", Types: []string{"integer"}},
+	{Table: "outbox", Name: "next_attempt_at", Types: []string{"timestamp with time zone", "timestamp without time zone"}},
+	{Table: "outbox", Name: "last_error", Types: []string{"text", "character varying"}},
+	{Table: "outbox", Name: "created_at", Types: []string{"timestamp with time zone", "timestamp without time zone"}},
+	{Table: "outbox", Name: "dead_at", Types: []string{"timestamp with time zone", "timestamp without time zone"}},
+
+	{Table: "webhook", Name: "webhook_id", Types: []string{"bigint"}},
+	{Table: "webhook", Name: "url", Types: []string{"text", "character varying"}},
+	{Table: "webhook", Name: "secret", Types: []string{"character varying", "text"}},
+	{Table: "webhook", Name: "topics", Types: []string{"text", "character varying"}},
+
+	{Table: "webhook_delivery", Name: "delivery_id", Types: []string{"bigint"}},
+	{Table: "webhook_delivery", Name: "webhook_id", Types: []string{"bigint"}},
+	{Table: "webhook_delivery", Name: "event_id", Types: []string{"character varying", "text"}},
+	{Table: "webhook_delivery", Name: "topic", Types: []string{"character varying", "text"}},
+	{Table: "webhook_delivery", Name: "payload", Types: []string{"text"}},
+	{Table: "webhook_delivery", Name: "status", Types: []string{"character varying", "text"}},
+	{Table: "webhook_delivery", Name: "attempts", Types: []string{"integer"}},
+	{Table: "webhook_delivery", Name: "next_attempt_at", Types: []string{"bigint"}},
+	{Table: "webhook_delivery", Name: "last_error", Types: []string{"text", "character varying"}},
+}
+
+// MistypedColumn is column exists in database but the type cannot be used by repository
+type MistypedColumn struct {
+	Column
+	Actual string
+}
+
+// SchemaDriftError is returned by VerifyPgSchema when database not match with columns expected by repository
+type SchemaDriftError struct {
+	Missing  []Column
+	Mistyped []MistypedColumn
+}
+
+func (e *SchemaDriftError) Error() string {
+	var buf bytes.Buffer
+	buf.WriteString("database schema does not match repository:")
+
+	for _, c := range e.Missing {
+		fmt.Fprintf(&buf, "\n  - %s.%s: missing, expected %s", c.Table, c.Name, strings.Join(c.Types, " or "))
+	}
+
+	for _, c := range e.Mistyped {
+		fmt.Fprintf(&buf, "\n  ~ %s.%s: expected %s, found %s", c.Table, c.Name, strings.Join(c.Types, " or "), c.Actual)
+	}
+
+	return buf.String()
+}
+
+// VerifyPgSchema compare columns in information_schema with PgExpectedColumns. it return *SchemaDriftError when column is missing or has wrong type
+func VerifyPgSchema(ctx context.Context, db *sql.DB) error {
+	// only tables of PgExpectedColumns are read, so table added to it is checked without changing the query
+	tables := pgExpectedTables()
+	placeholders := make([]string, 0, len(tables))
+	args := make([]interface{}, 0, len(tables))
+	for i, table := range tables {
+		placeholders = append(placeholders, postgresDialect.placeholder(i+1))
+		args = append(args, table)
+	}
+
+	query := "SELECT table_name, column_name, data_type FROM information_schema.columns WHERE table_schema = current_schema() AND table_name IN (" + strings.Join(placeholders, ", ") + ")"
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	// map of "table.column" to data_type
+	actual := make(map[string]string)
+	for rows.Next() {
+		var table, column, dataType string
+		if err := rows.Scan(&table, &column, &dataType); err != nil {
+			return err
+		}
+
+		actual[table+"."+column] = dataType
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	drift := &SchemaDriftError{}
+	for _, c := range PgExpectedColumns {
+		dataType, ok := actual[c.Table+"."+c.Name]
+		if !ok {
+			drift.Missing = append(drift.Missing, c)
+			continue
+		}
+
+		if !containsString(c.Types, dataType) {
+			drift.Mistyped = append(drift.Mistyped, MistypedColumn{Column: c, Actual: dataType})
+		}
+	}
+
+	if len(drift.Missing) > 0 || len(drift.Mistyped) > 0 {
+		return drift
+	}
+
+	return nil
+}
+
+// pgExpectedTables return distinct table of PgExpectedColumns in the order it is listed
+func pgExpectedTables() []string {
+	tables := make([]string, 0)
+	for _, c := range PgExpectedColumns {
+		if !containsString(tables, c.Table) {
+			tables = append(tables, c.Table)
+		}
+	}
+
+	return tables
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
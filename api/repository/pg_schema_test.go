@@ -0,0 +1,77 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	sqlMock "github.com/DATA-DOG/go-sqlmock"
+	repo "github.com/muhammadhidayah/configuration-service/api/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyPgSchema(t *testing.T) {
+	db, mock, err := sqlMock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	defer db.Close()
+
+	query := "SELECT table_name, column_name, data_type FROM information_schema.columns"
+	columns := []string{"table_name", "column_name", "data_type"}
+
+	t.Run("Schema match with repository", func(t *testing.T) {
+		rows := sqlMock.NewRows(columns)
+		for _, c := range repo.PgExpectedColumns {
+			rows.AddRow(c.Table, c.Name, c.Types[0])
+		}
+
+		// every table of expected columns is read, not only configuration_client and configuration_global
+		mock.ExpectQuery(query+" WHERE table_schema = current_schema\\(\\) AND table_name IN \\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$7\\)$").
+			WithArgs("configuration_client", "configuration_global", "configuration_global_tombstone", "configuration_revision", "outbox", "webhook", "webhook_delivery").
+			WillReturnRows(rows)
+
+		err := repo.VerifyPgSchema(context.TODO(), db)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Schema has missing and mistyped column", func(t *testing.T) {
+		rows := sqlMock.NewRows(columns)
+		for _, c := range repo.PgExpectedColumns {
+			switch c.Name {
+			case "footertext":
+				rows.AddRow(c.Table, "footer_text", "text")
+			case "is_config_deleted":
+				rows.AddRow(c.Table, c.Name, "boolean")
+			default:
+				rows.AddRow(c.Table, c.Name, c.Types[0])
+			}
+		}
+
+		mock.ExpectQuery(query).WillReturnRows(rows)
+
+		err := repo.VerifyPgSchema(context.TODO(), db)
+		assert.Error(t, err)
+
+		drift, ok := err.(*repo.SchemaDriftError)
+		assert.True(t, ok)
+		assert.Len(t, drift.Missing, 1)
+		assert.Equal(t, "footertext", drift.Missing[0].Name)
+		assert.Len(t, drift.Mistyped, 1)
+		assert.Equal(t, "boolean", drift.Mistyped[0].Actual)
+		assert.Contains(t, err.Error(), "configuration_global.footertext: missing")
+		assert.Contains(t, err.Error(), "configuration_client.is_config_deleted: expected integer or smallint, found boolean")
+	})
+
+	t.Run("Failed query information_schema", func(t *testing.T) {
+		mock.ExpectQuery(query).WillReturnError(fmt.Errorf("permission denied"))
+
+		err := repo.VerifyPgSchema(context.TODO(), db)
+		assert.Error(t, err)
+
+		_, ok := err.(*repo.SchemaDriftError)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+)
+
+// PoolHealth is health of one database pool used by repository
+type PoolHealth struct {
+	Name            string `json:"name"`
+	Primary         bool   `json:"primary"`
+	Healthy         bool   `json:"healthy"`
+	Error           string `json:"error,omitempty"`
+	OpenConnections int    `json:"open_connections"`
+	InUse           int    `json:"in_use"`
+	Idle            int    `json:"idle"`
+}
+
+// HealthChecker is implemented by repository that use database pool
+type HealthChecker interface {
+	Health(context.Context) []PoolHealth
+}
+
+// replicaPool is one read replica with its own statement cache. healthy is 1 when reads can be routed to the replica
+type replicaPool struct {
+	name    string
+	db      *sql.DB
+	stmts   *stmtCache
+	healthy int32
+}
+
+// replicaSet choose replica for read in round robin, unhealthy replica is skipped
+type replicaSet struct {
+	pools []*replicaPool
+	next  uint32
+}
+
+func newReplicaSet(replicas []*sql.DB) *replicaSet {
+	if len(replicas) == 0 {
+		return nil
+	}
+
+	set := &replicaSet{}
+	for i, db := range replicas {
+		set.pools = append(set.pools, &replicaPool{name: fmt.Sprintf("replica-%d", i+1), db: db, stmts: newStmtCache(db), healthy: 1})
+	}
+
+	return set
+}
+
+// this function will return next healthy replica, or nil when no replica is healthy
+func (set *replicaSet) pick() *replicaPool {
+	n := uint32(len(set.pools))
+	start := atomic.AddUint32(&set.next, 1)
+
+	for i := uint32(0); i < n; i++ {
+		pool := set.pools[(start+i)%n]
+		if atomic.LoadInt32(&pool.healthy) == 1 {
+			return pool
+		}
+	}
+
+	return nil
+}
+
+// NewPgConfigurationWithReplicas create api.Repository that write to primary and read from replicas.
+// read is routed to primary when context is created by api.WithReadPrimary, inside transaction, or when no replica is healthy
+func NewPgConfigurationWithReplicas(primary *sql.DB, replicas ...*sql.DB) api.Repository {
+	repo := newSQLConfiguration(primary, postgresDialect)
+	repo.replicas = newReplicaSet(replicas)
+
+	return repo
+}
+
+// this function will execute read query on replica, and fall back to primary when replica can not be reached
+func (repo *sqlConfiguration) queryRead(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if repo.tx == nil && repo.replicas != nil && !api.IsReadPrimary(ctx) {
+		if pool := repo.replicas.pick(); pool != nil {
+			rows, err := queryStmt(ctx, pool.stmts, repo.dialect.rebind(query), args...)
+			if err == nil {
+				return rows, nil
+			}
+
+			// error of query is returned as is, only unreachable replica is taken out from routing
+			if pingErr := pool.db.PingContext(ctx); pingErr == nil {
+				return nil, repo.dialect.translate(err)
+			}
+
+			atomic.StoreInt32(&pool.healthy, 0)
+		}
+	}
+
+	stmt, err := repo.prepare(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	return rows, repo.dialect.translate(err)
+}
+
+func queryStmt(ctx context.Context, stmts *stmtCache, query string, args ...interface{}) (*sql.Rows, error) {
+	stmt, err := stmts.prepare(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return stmt.QueryContext(ctx, args...)
+}
+
+// Health ping primary and every replica. replica that answer the ping is routed again
+func (repo *sqlConfiguration) Health(ctx context.Context) []PoolHealth {
+	health := []PoolHealth{poolHealth(ctx, "primary", repo.db)}
+	health[0].Primary = true
+
+	if repo.replicas == nil {
+		return health
+	}
+
+	for _, pool := range repo.replicas.pools {
+		h := poolHealth(ctx, pool.name, pool.db)
+		if h.Healthy {
+			atomic.StoreInt32(&pool.healthy, 1)
+		} else {
+			atomic.StoreInt32(&pool.healthy, 0)
+		}
+
+		health = append(health, h)
+	}
+
+	return health
+}
+
+func poolHealth(ctx context.Context, name string, db *sql.DB) PoolHealth {
+	stats := db.Stats()
+	h := PoolHealth{Name: name, Healthy: true, OpenConnections: stats.OpenConnections, InUse: stats.InUse, Idle: stats.Idle}
+
+	if err := db.PingContext(ctx); err != nil {
+		h.Healthy = false
+		h.Error = err.Error()
+	}
+
+	return h
+}
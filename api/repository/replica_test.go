@@ -0,0 +1,100 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	sqlMock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/muhammadhidayah/configuration-service/api"
+	repo "github.com/muhammadhidayah/configuration-service/api/repository"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPgConfigurationWithReplicas(t *testing.T) {
+	query := "SELECT config_global_id, footertext, server_smpt, \"ssl\", port, is_auth, username, password, is_active FROM configuration_global WHERE is_active = \\$1"
+	columns := []string{"config_global_id", "footertext", "server_smpt", "ssl", "port", "is_auth", "username", "password", "is_active"}
+
+	newDB := func(t *testing.T) (*sql.DB, sqlMock.Sqlmock) {
+		db, mock, err := sqlMock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database conncection", err)
+		}
+
+		return db, mock
+	}
+
+	t.Run("Read go to replica and write go to primary", func(t *testing.T) {
+		primary, primaryMock := newDB(t)
+		defer primary.Close()
+		replica, replicaMock := newDB(t)
+		defer replica.Close()
+
+		replicaMock.ExpectPrepare(query).ExpectQuery().WithArgs(true).WillReturnRows(sqlMock.NewRows(columns).AddRow(3, "footer", "mail.google.com", true, 465, true, "notification@inactsoft.com", "secret", true))
+		expectRevision(primaryMock, 2)
+		primaryMock.ExpectPrepare("UPDATE configuration_global").ExpectExec().WillReturnResult(sqlMock.NewResult(0, 1))
+		primaryMock.ExpectCommit()
+
+		configRepo := repo.NewPgConfigurationWithReplicas(primary, replica)
+
+		active, err := configRepo.GetConfigurationGlobalActive(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), active.ConfigGlobalId)
+
+		updated, err := configRepo.UpdateConfigurationGlobal(context.TODO(), &pb.ConfigurationGlobal{ConfigGlobalId: 3})
+		assert.NoError(t, err)
+		assert.True(t, updated)
+
+		assert.NoError(t, primaryMock.ExpectationsWereMet())
+		assert.NoError(t, replicaMock.ExpectationsWereMet())
+	})
+
+	t.Run("Read go to primary when context force it", func(t *testing.T) {
+		primary, primaryMock := newDB(t)
+		defer primary.Close()
+		replica, replicaMock := newDB(t)
+		defer replica.Close()
+
+		primaryMock.ExpectPrepare(query).ExpectQuery().WithArgs(true).WillReturnRows(sqlMock.NewRows(columns))
+
+		configRepo := repo.NewPgConfigurationWithReplicas(primary, replica)
+
+		active, err := configRepo.GetConfigurationGlobalActive(api.WithReadPrimary(context.TODO()))
+		assert.NoError(t, err)
+		assert.Nil(t, active)
+
+		assert.NoError(t, primaryMock.ExpectationsWereMet())
+		assert.NoError(t, replicaMock.ExpectationsWereMet())
+	})
+
+	t.Run("Read fall back to primary when replica unreachable", func(t *testing.T) {
+		primary, primaryMock := newDB(t)
+		defer primary.Close()
+		replica, _ := newDB(t)
+		replica.Close()
+
+		prep := primaryMock.ExpectPrepare(query)
+		prep.ExpectQuery().WithArgs(true).WillReturnRows(sqlMock.NewRows(columns))
+		prep.ExpectQuery().WithArgs(true).WillReturnRows(sqlMock.NewRows(columns))
+
+		configRepo := repo.NewPgConfigurationWithReplicas(primary, replica)
+
+		for i := 0; i < 2; i++ {
+			_, err := configRepo.GetConfigurationGlobalActive(context.TODO())
+			assert.NoError(t, err)
+		}
+
+		assert.NoError(t, primaryMock.ExpectationsWereMet())
+
+		health := configRepo.(repo.HealthChecker).Health(context.TODO())
+		if assert.Len(t, health, 2) {
+			assert.Equal(t, "primary", health[0].Name)
+			assert.True(t, health[0].Primary)
+			assert.True(t, health[0].Healthy)
+			assert.Equal(t, "replica-1", health[1].Name)
+			assert.False(t, health[1].Healthy)
+			assert.NotEmpty(t, health[1].Error)
+		}
+	})
+}
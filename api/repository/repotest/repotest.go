@@ -0,0 +1,759 @@
+// Package repotest is conformance test suite of api.Repository. every backend run the same suite, so it is proven to behave the same as the others
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/muhammadhidayah/configuration-service/api"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Factory return empty repository for one test. it is called once by every subtest, so data of one subtest never seen by another
+type Factory func(t *testing.T) api.Repository
+
+// AddDuplicate add cc to repo even when client of the same company_subs_id is not deleted, like database created before the unique index
+type AddDuplicate func(t *testing.T, repo api.Repository, cc *pb.ConfigurationClient)
+
+// Option configure conformance test run by Run
+type Option func(*options)
+
+type options struct {
+	addDuplicate AddDuplicate
+}
+
+// WithAddDuplicate set how duplicate client is added to repository, test of repairing duplicate is skipped without it
+func WithAddDuplicate(fn AddDuplicate) Option {
+	return func(o *options) {
+		o.addDuplicate = fn
+	}
+}
+
+// Run run all of conformance test against repository created by newRepo
+func Run(t *testing.T, newRepo Factory, opts ...Option) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	tests := []struct {
+		name string
+		fn   func(*testing.T, api.Repository)
+	}{
+		{"ClientCRUD", testClientCRUD},
+		{"ClientSoftDelete", testClientSoftDelete},
+		{"ClientNotFound", testClientNotFound},
+		{"ClientUniqueSubs", testClientUniqueSubs},
+		{"ClientUpsert", testClientUpsert},
+		{"GlobalCRUD", testGlobalCRUD},
+		{"GlobalNotFound", testGlobalNotFound},
+		{"SingleActiveGlobal", testSingleActiveGlobal},
+		{"WithinTxRollback", testWithinTxRollback},
+		{"Outbox", testOutbox},
+		{"Webhook", testWebhook},
+		{"WebhookDelivery", testWebhookDelivery},
+		{"Sync", testSync},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepo(t))
+		})
+	}
+
+	t.Run("RepairDuplicateClient", func(t *testing.T) {
+		if o.addDuplicate == nil {
+			t.Skip("duplicate client cannot be added to this repository")
+		}
+
+		testRepairDuplicateClient(t, newRepo(t), o.addDuplicate)
+	})
+}
+
+func newClient(uuid, subs string) *pb.ConfigurationClient {
+	return &pb.ConfigurationClient{
+		ConfigClientUuid:   uuid,
+		MultipleLanguageId: 2,
+		Appname:            "client.inactsoft.com",
+		ReportTitle:        "Client " + subs,
+		CompanySubsId:      subs,
+	}
+}
+
+func newGlobal(username string) *pb.ConfigurationGlobal {
+	return &pb.ConfigurationGlobal{
+		Footertext: "Technical support",
+		ServerSmpt: "mail.google.com",
+		Ssl:        true,
+		Port:       465,
+		IsAuth:     true,
+		Username:   username,
+		Password:   "123456789087654",
+	}
+}
+
+func testClientCRUD(t *testing.T, repo api.Repository) {
+	ctx := context.TODO()
+
+	cc := newClient("a6e2745e-c930-4717-a9d1-d1cfb2a64aa4", "180-000-123-0321")
+	created, err := repo.AddConfigurationClient(ctx, cc)
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.NotZero(t, cc.ConfigClientId, "id of new row must be set to the added client")
+
+	res, err := repo.GetConfigurationClientBySubs(ctx, cc.CompanySubsId)
+	require.NoError(t, err)
+	assert.Equal(t, cc.ConfigClientId, res.ConfigClientId)
+	assert.Equal(t, cc.ConfigClientUuid, res.ConfigClientUuid)
+	assert.Equal(t, cc.MultipleLanguageId, res.MultipleLanguageId)
+	assert.Equal(t, cc.Appname, res.Appname)
+	assert.Equal(t, cc.ReportTitle, res.ReportTitle)
+	assert.Equal(t, int32(0), res.IsConfigDeleted)
+
+	// update is matched by uuid, company_subs_id can be changed
+	cc.ReportTitle = "Client Updated"
+	cc.CompanySubsId = "180-000-123-9999"
+	updated, err := repo.UpdateConfigurationClientBySubs(ctx, cc)
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	res, err = repo.GetConfigurationClientBySubs(ctx, "180-000-123-9999")
+	require.NoError(t, err)
+	assert.Equal(t, "Client Updated", res.ReportTitle)
+
+	res, err = repo.GetConfigurationClientByUUID(ctx, cc.ConfigClientUuid)
+	require.NoError(t, err)
+	assert.Equal(t, cc.ConfigClientId, res.ConfigClientId)
+	assert.Equal(t, "180-000-123-9999", res.CompanySubsId)
+
+	_, err = repo.GetConfigurationClientBySubs(ctx, "180-000-123-0321")
+	assert.Error(t, err)
+
+	second := newClient("b7f3856f-d041-4828-b0e2-e2d0c3b75bb5", "180-000-123-0322")
+	_, err = repo.AddConfigurationClient(ctx, second)
+	require.NoError(t, err)
+	assert.NotEqual(t, cc.ConfigClientId, second.ConfigClientId)
+
+	list, err := repo.GetConfigurationClient(ctx)
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+}
+
+func testClientSoftDelete(t *testing.T, repo api.Repository) {
+	ctx := context.TODO()
+
+	cc := newClient("a6e2745e-c930-4717-a9d1-d1cfb2a64aa4", "180-000-123-0321")
+	_, err := repo.AddConfigurationClient(ctx, cc)
+	require.NoError(t, err)
+
+	deleted, err := repo.DeleteConfigurationClientBySubs(ctx, cc)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+
+	// deleted client is hidden from lookup by subs, but still listed with flag is_config_deleted
+	res, err := repo.GetConfigurationClientBySubs(ctx, cc.CompanySubsId)
+	assert.True(t, errors.Is(err, api.ErrNotFound), "expected api.ErrNotFound, got %v", err)
+	assert.Nil(t, res)
+
+	list, err := repo.GetConfigurationClient(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, int32(1), list[0].IsConfigDeleted)
+
+	// lookup by uuid return deleted client too, update by uuid can change it
+	res, err = repo.GetConfigurationClientByUUID(ctx, cc.ConfigClientUuid)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), res.IsConfigDeleted)
+
+	// client already deleted is not found, and revision is not increased
+	before, err := repo.SyncConfiguration(ctx, 0)
+	require.NoError(t, err)
+
+	deleted, err = repo.DeleteConfigurationClientBySubs(ctx, cc)
+	assert.True(t, errors.Is(err, api.ErrNotFound), "expected api.ErrNotFound, got %v", err)
+	assert.False(t, deleted)
+
+	after, err := repo.SyncConfiguration(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, before.Revision, after.Revision)
+}
+
+func testClientNotFound(t *testing.T, repo api.Repository) {
+	ctx := context.TODO()
+
+	list, err := repo.GetConfigurationClient(ctx)
+	assert.True(t, errors.Is(err, api.ErrNotFound), "expected api.ErrNotFound, got %v", err)
+	assert.Nil(t, list)
+
+	res, err := repo.GetConfigurationClientBySubs(ctx, "000-000-000-0000")
+	assert.True(t, errors.Is(err, api.ErrNotFound), "expected api.ErrNotFound, got %v", err)
+	assert.Nil(t, res)
+
+	res, err = repo.GetConfigurationClientByUUID(ctx, "00000000-0000-0000-0000-000000000000")
+	assert.True(t, errors.Is(err, api.ErrNotFound), "expected api.ErrNotFound, got %v", err)
+	assert.Nil(t, res)
+
+	missing := newClient("00000000-0000-0000-0000-000000000000", "000-000-000-0000")
+
+	updated, err := repo.UpdateConfigurationClientBySubs(ctx, missing)
+	assert.True(t, errors.Is(err, api.ErrNotFound), "expected api.ErrNotFound, got %v", err)
+	assert.False(t, updated)
+
+	deleted, err := repo.DeleteConfigurationClientBySubs(ctx, missing)
+	assert.True(t, errors.Is(err, api.ErrNotFound), "expected api.ErrNotFound, got %v", err)
+	assert.False(t, deleted)
+}
+
+func testClientUniqueSubs(t *testing.T, repo api.Repository) {
+	ctx := context.TODO()
+
+	first := newClient("a6e2745e-c930-4717-a9d1-d1cfb2a64aa4", "012-031-234-542")
+	other := newClient("a6e2745e-c930-4717-a9d1-d1cfb2a64ab2", "012-031-234-543")
+	for _, cc := range []*pb.ConfigurationClient{first, other} {
+		_, err := repo.AddConfigurationClient(ctx, cc)
+		require.NoError(t, err)
+	}
+
+	created, err := repo.AddConfigurationClient(ctx, newClient("a6e2745e-c930-4717-a9d1-d1cfb2a64ac3", first.CompanySubsId))
+	assert.True(t, errors.Is(err, api.ErrAlreadyExists), "expected api.ErrAlreadyExists, got %v", err)
+	assert.False(t, created)
+
+	// company_subs_id cannot be changed to company_subs_id of other client
+	moved := newClient(other.ConfigClientUuid, first.CompanySubsId)
+	updated, err := repo.UpdateConfigurationClientBySubs(ctx, moved)
+	assert.True(t, errors.Is(err, api.ErrAlreadyExists), "expected api.ErrAlreadyExists, got %v", err)
+	assert.False(t, updated)
+
+	duplicates, err := repo.GetDuplicateConfigurationClient(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, duplicates)
+
+	// deleted client does not hold company_subs_id
+	_, err = repo.DeleteConfigurationClientBySubs(ctx, first)
+	require.NoError(t, err)
+
+	created, err = repo.AddConfigurationClient(ctx, newClient("a6e2745e-c930-4717-a9d1-d1cfb2a64ac3", first.CompanySubsId))
+	assert.NoError(t, err)
+	assert.True(t, created)
+
+	// revision is not increased when there is nothing to repair
+	before, err := repo.SyncConfiguration(ctx, 0)
+	require.NoError(t, err)
+
+	repaired, err := repo.RepairDuplicateConfigurationClient(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, repaired)
+
+	after, err := repo.SyncConfiguration(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, before.Revision, after.Revision)
+}
+
+func testRepairDuplicateClient(t *testing.T, repo api.Repository, addDuplicate AddDuplicate) {
+	ctx := context.TODO()
+
+	oldest := newClient("a6e2745e-c930-4717-a9d1-d1cfb2a64aa4", "012-031-234-542")
+	_, err := repo.AddConfigurationClient(ctx, oldest)
+	require.NoError(t, err)
+
+	other := newClient("a6e2745e-c930-4717-a9d1-d1cfb2a64ac3", "012-031-234-543")
+	_, err = repo.AddConfigurationClient(ctx, other)
+	require.NoError(t, err)
+
+	addDuplicate(t, repo, newClient("a6e2745e-c930-4717-a9d1-d1cfb2a64ab2", oldest.CompanySubsId))
+	addDuplicate(t, repo, newClient("a6e2745e-c930-4717-a9d1-d1cfb2a64ad4", oldest.CompanySubsId))
+
+	duplicates, err := repo.GetDuplicateConfigurationClient(ctx)
+	require.NoError(t, err)
+	assert.Len(t, duplicates, 2)
+
+	before, err := repo.SyncConfiguration(ctx, 0)
+	require.NoError(t, err)
+
+	repaired, err := repo.RepairDuplicateConfigurationClient(ctx)
+	require.NoError(t, err)
+	require.Len(t, repaired, 2)
+	for _, cc := range repaired {
+		assert.Equal(t, oldest.CompanySubsId, cc.CompanySubsId)
+		assert.Equal(t, int32(1), cc.IsConfigDeleted)
+	}
+
+	// the oldest client is kept
+	res, err := repo.GetConfigurationClientBySubs(ctx, oldest.CompanySubsId)
+	require.NoError(t, err)
+	assert.Equal(t, oldest.ConfigClientId, res.ConfigClientId)
+
+	// tombstone is applied before changed client, so the kept client is sent again with the revision of tombstone
+	sync, err := repo.SyncConfiguration(ctx, before.Revision)
+	require.NoError(t, err)
+	assert.Equal(t, before.Revision+1, sync.Revision)
+	require.Len(t, sync.DeletedClients, 2)
+	for _, tombstone := range sync.DeletedClients {
+		assert.Equal(t, oldest.CompanySubsId, tombstone.CompanySubsId)
+		assert.Equal(t, sync.Revision, tombstone.Revision)
+	}
+	if assert.Len(t, sync.Configclients, 1) {
+		assert.Equal(t, oldest.ConfigClientId, sync.Configclients[0].ConfigClientId)
+		assert.Equal(t, sync.DeletedClients[0].Revision, sync.Configclients[0].Revision)
+	}
+
+	repaired, err = repo.RepairDuplicateConfigurationClient(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, repaired)
+}
+
+func testClientUpsert(t *testing.T, repo api.Repository) {
+	ctx := context.TODO()
+
+	cc := newClient("a6e2745e-c930-4717-a9d1-d1cfb2a64aa4", "012-031-234-542")
+	created, err := repo.UpsertConfigurationClientBySubs(ctx, cc)
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.NotZero(t, cc.ConfigClientId)
+	assert.Equal(t, "a6e2745e-c930-4717-a9d1-d1cfb2a64aa4", cc.ConfigClientUuid)
+
+	// uuid of the existing client is kept, and set to the upserted client
+	again := newClient("a6e2745e-c930-4717-a9d1-d1cfb2a64ab2", "012-031-234-542")
+	again.ReportTitle = "Client Updated"
+	created, err = repo.UpsertConfigurationClientBySubs(ctx, again)
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, cc.ConfigClientId, again.ConfigClientId)
+	assert.Equal(t, cc.ConfigClientUuid, again.ConfigClientUuid)
+
+	res, err := repo.GetConfigurationClientBySubs(ctx, "012-031-234-542")
+	require.NoError(t, err)
+	assert.Equal(t, "Client Updated", res.ReportTitle)
+	assert.Equal(t, cc.ConfigClientUuid, res.ConfigClientUuid)
+
+	// deleted client is not updated, new client is created
+	_, err = repo.DeleteConfigurationClientBySubs(ctx, cc)
+	require.NoError(t, err)
+
+	recreated := newClient("a6e2745e-c930-4717-a9d1-d1cfb2a64ac3", "012-031-234-542")
+	created, err = repo.UpsertConfigurationClientBySubs(ctx, recreated)
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.NotEqual(t, cc.ConfigClientId, recreated.ConfigClientId)
+
+	list, err := repo.GetConfigurationClient(ctx)
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+}
+
+func testGlobalCRUD(t *testing.T, repo api.Repository) {
+	ctx := context.TODO()
+
+	cg := newGlobal("notification1@inactsoft.com")
+	created, err := repo.AddConfigurationGlobal(ctx, cg)
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.NotZero(t, cg.ConfigGlobalId, "id of new row must be set to the added global")
+
+	res, err := repo.GetConfigurationGlobalByID(ctx, cg.ConfigGlobalId)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	assert.Equal(t, cg.Footertext, res.Footertext)
+	assert.Equal(t, cg.ServerSmpt, res.ServerSmpt)
+	assert.Equal(t, cg.Ssl, res.Ssl)
+	assert.Equal(t, cg.Port, res.Port)
+	assert.Equal(t, cg.IsAuth, res.IsAuth)
+	assert.Equal(t, cg.Username, res.Username)
+	assert.Equal(t, cg.Password, res.Password)
+	assert.False(t, res.IsActive, "new global must not be active")
+
+	cg.Ssl = false
+	cg.Port = 587
+	updated, err := repo.UpdateConfigurationGlobal(ctx, cg)
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	res, err = repo.GetConfigurationGlobalByID(ctx, cg.ConfigGlobalId)
+	require.NoError(t, err)
+	assert.False(t, res.Ssl)
+	assert.Equal(t, int64(587), res.Port)
+
+	_, err = repo.AddConfigurationGlobal(ctx, newGlobal("notification2@inactsoft.com"))
+	require.NoError(t, err)
+
+	list, err := repo.GetConfigurationGlobal(ctx)
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+
+	deleted, err := repo.DeleteConfiguration(ctx, cg.ConfigGlobalId)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+
+	res, err = repo.GetConfigurationGlobalByID(ctx, cg.ConfigGlobalId)
+	assert.True(t, errors.Is(err, api.ErrNotFound), "expected api.ErrNotFound, got %v", err)
+	assert.Nil(t, res)
+}
+
+func testGlobalNotFound(t *testing.T, repo api.Repository) {
+	ctx := context.TODO()
+
+	list, err := repo.GetConfigurationGlobal(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+
+	res, err := repo.GetConfigurationGlobalByID(ctx, 404)
+	assert.True(t, errors.Is(err, api.ErrNotFound), "expected api.ErrNotFound, got %v", err)
+	assert.Nil(t, res)
+
+	active, err := repo.GetConfigurationGlobalActive(ctx)
+	assert.NoError(t, err)
+	assert.Nil(t, active)
+
+	missing := newGlobal("missing@inactsoft.com")
+	missing.ConfigGlobalId = 404
+
+	updated, err := repo.UpdateConfigurationGlobal(ctx, missing)
+	assert.True(t, errors.Is(err, api.ErrNotFound), "expected api.ErrNotFound, got %v", err)
+	assert.False(t, updated)
+
+	deleted, err := repo.DeleteConfiguration(ctx, 404)
+	assert.True(t, errors.Is(err, api.ErrNotFound), "expected api.ErrNotFound, got %v", err)
+	assert.False(t, deleted)
+}
+
+func testSingleActiveGlobal(t *testing.T, repo api.Repository) {
+	ctx := context.TODO()
+
+	first := newGlobal("notification1@inactsoft.com")
+	second := newGlobal("notification2@inactsoft.com")
+	for _, cg := range []*pb.ConfigurationGlobal{first, second} {
+		_, err := repo.AddConfigurationGlobal(ctx, cg)
+		require.NoError(t, err)
+	}
+
+	activated, err := repo.SetConfigurationGlobalActive(ctx, first.ConfigGlobalId)
+	require.NoError(t, err)
+	assert.True(t, activated)
+	assertActive(t, repo, first.ConfigGlobalId)
+
+	activated, err = repo.SetConfigurationGlobalActive(ctx, second.ConfigGlobalId)
+	require.NoError(t, err)
+	assert.True(t, activated)
+	assertActive(t, repo, second.ConfigGlobalId)
+
+	// activating the active configuration again keep it active
+	_, err = repo.SetConfigurationGlobalActive(ctx, second.ConfigGlobalId)
+	require.NoError(t, err)
+	assertActive(t, repo, second.ConfigGlobalId)
+
+	// failed activation must not deactivate the current one
+	activated, err = repo.SetConfigurationGlobalActive(ctx, 404)
+	assert.Error(t, err)
+	assert.False(t, activated)
+	assertActive(t, repo, second.ConfigGlobalId)
+}
+
+// assertActive check that only configuration global with id is active
+func assertActive(t *testing.T, repo api.Repository, id int32) {
+	t.Helper()
+
+	active, err := repo.GetConfigurationGlobalActive(context.TODO())
+	require.NoError(t, err)
+	require.NotNil(t, active)
+	assert.Equal(t, id, active.ConfigGlobalId)
+
+	list, err := repo.GetConfigurationGlobal(context.TODO())
+	require.NoError(t, err)
+	for _, cg := range list {
+		assert.Equal(t, cg.ConfigGlobalId == id, cg.IsActive, "configuration global %d", cg.ConfigGlobalId)
+	}
+}
+
+func testWithinTxRollback(t *testing.T, repo api.Repository) {
+	ctx := context.TODO()
+	errAbort := errors.New("abort")
+
+	err := repo.WithinTx(ctx, func(txRepo api.Repository) error {
+		if _, err := txRepo.AddConfigurationClient(ctx, newClient("a6e2745e-c930-4717-a9d1-d1cfb2a64aa4", "180-000-123-0321")); err != nil {
+			return err
+		}
+
+		return errAbort
+	})
+	assert.Equal(t, errAbort, err)
+
+	res, err := repo.GetConfigurationClientBySubs(ctx, "180-000-123-0321")
+	assert.Error(t, err)
+	assert.Nil(t, res)
+
+	err = repo.WithinTx(ctx, func(txRepo api.Repository) error {
+		_, err := txRepo.AddConfigurationClient(ctx, newClient("a6e2745e-c930-4717-a9d1-d1cfb2a64aa4", "180-000-123-0321"))
+		return err
+	})
+	require.NoError(t, err)
+
+	res, err = repo.GetConfigurationClientBySubs(ctx, "180-000-123-0321")
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+}
+
+func testOutbox(t *testing.T, repo api.Repository) {
+	ctx := context.TODO()
+	outbox, ok := repo.(api.Outbox)
+	require.True(t, ok, "repository must implement api.Outbox")
+
+	// event of rolled back transaction is not saved
+	errAbort := errors.New("abort")
+	err := repo.WithinTx(ctx, func(txRepo api.Repository) error {
+		if err := txRepo.AddOutboxEvent(ctx, &api.OutboxEvent{Topic: api.TopicClientCreated, Key: "client:a", Payload: []byte{1}}); err != nil {
+			return err
+		}
+
+		return errAbort
+	})
+	assert.Equal(t, errAbort, err)
+
+	pending, err := outbox.PendingOutboxEvents(ctx, time.Now(), 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	err = repo.WithinTx(ctx, func(txRepo api.Repository) error {
+		for _, topic := range []string{api.TopicClientCreated, api.TopicClientUpdated, api.TopicClientDeleted} {
+			if err := txRepo.AddOutboxEvent(ctx, &api.OutboxEvent{Topic: topic, Key: "client:a", Payload: []byte(topic)}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	pending, err = outbox.PendingOutboxEvents(ctx, time.Now(), 2)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, api.TopicClientCreated, pending[0].Topic)
+	assert.Equal(t, "client:a", pending[0].Key)
+	assert.Equal(t, []byte(api.TopicClientCreated), pending[0].Payload)
+	assert.Equal(t, api.TopicClientUpdated, pending[1].Topic)
+	assert.True(t, pending[0].ID < pending[1].ID)
+
+	// failed attempt is counted and postponed
+	next := time.Now().Add(time.Minute)
+	require.NoError(t, outbox.RetryOutboxEvent(ctx, pending[0].ID, next, "broker is down"))
+	assert.Error(t, outbox.RetryOutboxEvent(ctx, pending[0].ID+1000, next, "broker is down"))
+
+	require.NoError(t, outbox.DeleteOutboxEvent(ctx, pending[1].ID))
+
+	// key that is backing off is skipped until the event is due
+	require.NoError(t, repo.AddOutboxEvent(ctx, &api.OutboxEvent{Topic: api.TopicClientCreated, Key: "client:b", Payload: []byte{2}}))
+
+	pending, err = outbox.PendingOutboxEvents(ctx, time.Now(), 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "client:b", pending[0].Key)
+
+	pending, err = outbox.PendingOutboxEvents(ctx, next.Add(time.Second), 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 3)
+	assert.Equal(t, api.TopicClientCreated, pending[0].Topic)
+	assert.Equal(t, 1, pending[0].Attempts)
+	assert.WithinDuration(t, next, pending[0].NextAttemptAt, time.Second)
+	assert.Equal(t, api.TopicClientDeleted, pending[1].Topic)
+
+	// dead event is never read again, and event after it with the same key continue
+	require.NoError(t, outbox.DeadOutboxEvent(ctx, pending[0].ID, "unknown topic"))
+	assert.Error(t, outbox.DeadOutboxEvent(ctx, pending[0].ID+1000, "unknown topic"))
+
+	pending, err = outbox.PendingOutboxEvents(ctx, time.Now(), 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, api.TopicClientDeleted, pending[0].Topic)
+	assert.Equal(t, "client:b", pending[1].Key)
+
+	// only one relay hold the lock
+	unlock, locked, err := outbox.LockOutbox(ctx)
+	require.NoError(t, err)
+	require.True(t, locked)
+	unlock()
+}
+
+func testWebhook(t *testing.T, repo api.Repository) {
+	ctx := context.TODO()
+
+	all := &pb.Webhook{Url: "https://example.com/all", Secret: "s3cr3t"}
+	client := &pb.Webhook{Url: "https://example.com/client", Secret: "s3cr3t", Topics: []string{api.TopicClientCreated, api.TopicClientUpdated}}
+
+	for _, wh := range []*pb.Webhook{all, client} {
+		created, err := repo.AddWebhook(ctx, wh)
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.NotZero(t, wh.WebhookId)
+	}
+
+	webhooks, err := repo.GetWebhooks(ctx)
+	require.NoError(t, err)
+	require.Len(t, webhooks, 2)
+	assert.Equal(t, "https://example.com/all", webhooks[0].Url)
+	assert.Empty(t, webhooks[0].Topics)
+	assert.Equal(t, []string{api.TopicClientCreated, api.TopicClientUpdated}, webhooks[1].Topics)
+	assert.Equal(t, "s3cr3t", webhooks[1].Secret)
+
+	deleted, err := repo.DeleteWebhook(ctx, all.WebhookId)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+
+	_, err = repo.DeleteWebhook(ctx, all.WebhookId)
+	assert.Error(t, err)
+
+	webhooks, err = repo.GetWebhooks(ctx)
+	require.NoError(t, err)
+	require.Len(t, webhooks, 1)
+	assert.Equal(t, client.WebhookId, webhooks[0].WebhookId)
+}
+
+func testWebhookDelivery(t *testing.T, repo api.Repository) {
+	ctx := context.TODO()
+
+	first := &pb.WebhookDelivery{WebhookId: 1, EventId: "e1", Topic: api.TopicClientCreated, Payload: `{"id":"e1"}`, NextAttemptAt: 1000}
+	second := &pb.WebhookDelivery{WebhookId: 1, EventId: "e2", Topic: api.TopicClientUpdated, Payload: `{"id":"e2"}`, NextAttemptAt: 2000}
+	for _, d := range []*pb.WebhookDelivery{first, second} {
+		require.NoError(t, repo.AddWebhookDelivery(ctx, d))
+		assert.NotZero(t, d.DeliveryId)
+		assert.Equal(t, api.WebhookPending, d.Status)
+	}
+
+	// only delivery that is due is pending
+	pending, err := repo.PendingWebhookDeliveries(ctx, 1500, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "e1", pending[0].EventId)
+	assert.Equal(t, `{"id":"e1"}`, pending[0].Payload)
+
+	pending, err = repo.PendingWebhookDeliveries(ctx, 2000, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+
+	// delivery is claimed once, copy that is read before the claim cannot claim it again
+	stale := proto.Clone(pending[1]).(*pb.WebhookDelivery)
+	claimed, err := repo.ClaimWebhookDelivery(ctx, pending[1], 5000)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+	assert.Equal(t, int64(5000), pending[1].NextAttemptAt)
+
+	claimed, err = repo.ClaimWebhookDelivery(ctx, stale, 5000)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	pending, err = repo.PendingWebhookDeliveries(ctx, 2000, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "e1", pending[0].EventId)
+
+	first.Status = api.WebhookDead
+	first.Attempts = 5
+	first.LastError = "status 500"
+	require.NoError(t, repo.UpdateWebhookDelivery(ctx, first))
+
+	second.Status = api.WebhookDelivered
+	second.Attempts = 1
+	require.NoError(t, repo.UpdateWebhookDelivery(ctx, second))
+
+	pending, err = repo.PendingWebhookDeliveries(ctx, 3000, 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	dead, err := repo.GetWebhookDeliveries(ctx, api.WebhookDead)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, int32(5), dead[0].Attempts)
+	assert.Equal(t, "status 500", dead[0].LastError)
+
+	// only dead delivery can be redelivered
+	_, err = repo.RedeliverWebhookDelivery(ctx, second.DeliveryId, 3000)
+	assert.Error(t, err)
+
+	redelivered, err := repo.RedeliverWebhookDelivery(ctx, first.DeliveryId, 3000)
+	require.NoError(t, err)
+	assert.True(t, redelivered)
+
+	pending, err = repo.PendingWebhookDeliveries(ctx, 3000, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, first.DeliveryId, pending[0].DeliveryId)
+	assert.Equal(t, int32(0), pending[0].Attempts)
+}
+
+func testSync(t *testing.T, repo api.Repository) {
+	ctx := context.TODO()
+
+	start, err := repo.SyncConfiguration(ctx, 0)
+	require.NoError(t, err)
+	head := start.Revision
+
+	kept := newClient("a6e2745e-c930-4717-a9d1-d1cfb2a64aa4", "180-000-123-0321")
+	_, err = repo.AddConfigurationClient(ctx, kept)
+	require.NoError(t, err)
+
+	removed := newClient("b6e2745e-c930-4717-a9d1-d1cfb2a64aa4", "180-000-123-0322")
+	_, err = repo.AddConfigurationClient(ctx, removed)
+	require.NoError(t, err)
+
+	cg := newGlobal("notification@inactsoft.com")
+	_, err = repo.AddConfigurationGlobal(ctx, cg)
+	require.NoError(t, err)
+
+	gone := newGlobal("gone@inactsoft.com")
+	_, err = repo.AddConfigurationGlobal(ctx, gone)
+	require.NoError(t, err)
+
+	// every change increase the revision
+	res, err := repo.SyncConfiguration(ctx, head)
+	require.NoError(t, err)
+	assert.Equal(t, head+4, res.Revision)
+	assert.Len(t, res.Configclients, 2)
+	assert.Len(t, res.Configglobals, 2)
+	assert.Empty(t, res.DeletedClients)
+	assert.Empty(t, res.DeletedGlobals)
+
+	// client carry revision of its last change
+	got, err := repo.GetConfigurationClientBySubs(ctx, kept.CompanySubsId)
+	require.NoError(t, err)
+	assert.Equal(t, head+1, got.Revision)
+
+	// failed change does not use revision
+	_, err = repo.UpdateConfigurationGlobal(ctx, &pb.ConfigurationGlobal{ConfigGlobalId: cg.ConfigGlobalId + 100})
+	require.Error(t, err)
+
+	mid := res.Revision
+	_, err = repo.DeleteConfigurationClientBySubs(ctx, removed)
+	require.NoError(t, err)
+	_, err = repo.DeleteConfiguration(ctx, gone.ConfigGlobalId)
+	require.NoError(t, err)
+	_, err = repo.SetConfigurationGlobalActive(ctx, cg.ConfigGlobalId)
+	require.NoError(t, err)
+
+	// only change after since is returned, deleted row is returned as tombstone
+	res, err = repo.SyncConfiguration(ctx, mid)
+	require.NoError(t, err)
+	assert.Equal(t, mid+3, res.Revision)
+	assert.Empty(t, res.Configclients)
+	require.Len(t, res.Configglobals, 1)
+	assert.Equal(t, cg.ConfigGlobalId, res.Configglobals[0].ConfigGlobalId)
+	assert.True(t, res.Configglobals[0].IsActive)
+	require.Len(t, res.DeletedClients, 1)
+	assert.Equal(t, removed.CompanySubsId, res.DeletedClients[0].CompanySubsId)
+	assert.Equal(t, mid+1, res.DeletedClients[0].Revision)
+	require.Len(t, res.DeletedGlobals, 1)
+	assert.Equal(t, gone.ConfigGlobalId, res.DeletedGlobals[0].ConfigGlobalId)
+	assert.Equal(t, mid+2, res.DeletedGlobals[0].Revision)
+
+	// caller that is up to date get nothing
+	res, err = repo.SyncConfiguration(ctx, mid+3)
+	require.NoError(t, err)
+	assert.Equal(t, mid+3, res.Revision)
+	assert.Empty(t, res.Configclients)
+	assert.Empty(t, res.Configglobals)
+	assert.Empty(t, res.DeletedClients)
+	assert.Empty(t, res.DeletedGlobals)
+}
@@ -0,0 +1,714 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+)
+
+// sqlConfiguration is api.Repository on sql database. query is written once, and dialect make it fit to the database
+type sqlConfiguration struct {
+	db      *sql.DB
+	dialect dialect
+
+	// stmts is shared with repository of transaction, tx is only set in repository of transaction
+	stmts *stmtCache
+	tx    *sql.Tx
+
+	// replicas is nil when all of query go to db
+	replicas *replicaSet
+}
+
+func newSQLConfiguration(conn *sql.DB, d dialect) *sqlConfiguration {
+	return &sqlConfiguration{db: conn, dialect: d, stmts: newStmtCache(conn)}
+}
+
+// Close will close all of prepared statement, repository can not be used after closed
+func (repo *sqlConfiguration) Close() error {
+	err := repo.stmts.Close()
+
+	if repo.replicas != nil {
+		for _, pool := range repo.replicas.pools {
+			if closeErr := pool.stmts.Close(); closeErr != nil && err == nil {
+				err = closeErr
+			}
+		}
+	}
+
+	return err
+}
+
+// runInTx begin transaction on db and call fn. the transaction will be committed when fn return nil, and rolled back when fn return error or panic
+func runInTx(ctx context.Context, db *sql.DB, fn func(*sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// rollback transaction when fn panic, then continue the panic
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// this function will run fn in one transaction. repository passed to fn use the transaction, then commit when fn return nil, rollback when fn return error
+func (repo *sqlConfiguration) WithinTx(ctx context.Context, fn func(api.Repository) error) error {
+	return repo.withinTx(ctx, func(txRepo *sqlConfiguration) error {
+		return fn(txRepo)
+	})
+}
+
+func (repo *sqlConfiguration) withinTx(ctx context.Context, fn func(*sqlConfiguration) error) error {
+	// repository with tx is already inside transaction, so fn just join the transaction
+	if repo.tx != nil {
+		return fn(repo)
+	}
+
+	// error of begin and commit is translated too
+	err := runInTx(ctx, repo.db, func(tx *sql.Tx) error {
+		return fn(&sqlConfiguration{db: repo.db, dialect: repo.dialect, stmts: repo.stmts, tx: tx, replicas: repo.replicas})
+	})
+
+	return repo.dialect.translate(err)
+}
+
+// this function will run fn in transaction with the next revision. every row written by fn is set to the revision
+func (repo *sqlConfiguration) withRevision(ctx context.Context, fn func(txRepo *sqlConfiguration, revision int64) error) error {
+	return repo.withinTx(ctx, func(txRepo *sqlConfiguration) error {
+		revision, err := txRepo.nextRevision(ctx)
+		if err != nil {
+			return err
+		}
+
+		return fn(txRepo, revision)
+	})
+}
+
+// this function will increase revision and return it. row of configuration_revision stay locked until the transaction end,
+// so transaction with lower revision is always committed before transaction with higher revision
+func (repo *sqlConfiguration) nextRevision(ctx context.Context) (int64, error) {
+	if _, err := repo.handlingStoreQuery(ctx, "UPDATE configuration_revision SET revision = revision + 1 WHERE id = 1"); err != nil {
+		return 0, err
+	}
+
+	return repo.headRevision(ctx)
+}
+
+func (repo *sqlConfiguration) headRevision(ctx context.Context) (int64, error) {
+	stmt, err := repo.prepare(ctx, "SELECT revision FROM configuration_revision WHERE id = 1")
+	if err != nil {
+		return 0, err
+	}
+
+	var revision int64
+	err = stmt.QueryRowContext(ctx).Scan(&revision)
+
+	return revision, repo.dialect.translate(err)
+}
+
+// this function will be used to add configuration client
+func (repo *sqlConfiguration) AddConfigurationClient(ctx context.Context, cc *pb.ConfigurationClient) (bool, error) {
+	query := "INSERT INTO configuration_client (config_client_uuid, multiple_language_id, appname, report_title, company_subs_id, is_config_deleted, revision) VALUES(?,?,?,?,?,?,?)"
+
+	err := repo.withRevision(ctx, func(txRepo *sqlConfiguration, revision int64) error {
+		// insert to table configuration_client, then id of new row is set to cc
+		id, err := txRepo.insert(ctx, query, "config_client_id", cc.ConfigClientUuid, cc.MultipleLanguageId, cc.Appname, cc.ReportTitle, cc.CompanySubsId, cc.IsConfigDeleted, revision)
+		if err != nil {
+			return err
+		}
+
+		cc.ConfigClientId = id
+		return nil
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// this function will insert client, or update client that is not deleted and has the same company_subs_id in one statement.
+// uuid of cc is only stored when client is created, so created is true when uuid of the stored client is uuid of cc.
+// id and uuid of the stored client is set to cc. return true when created, false when updated
+func (repo *sqlConfiguration) UpsertConfigurationClientBySubs(ctx context.Context, cc *pb.ConfigurationClient) (bool, error) {
+	query := "INSERT INTO configuration_client (config_client_uuid, multiple_language_id, appname, report_title, company_subs_id, is_config_deleted, revision) VALUES(?,?,?,?,?,?,?)" +
+		repo.dialect.onConflict("(company_subs_id) WHERE is_config_deleted = 0", []string{"multiple_language_id", "appname", "report_title", "revision"})
+
+	var created bool
+	err := repo.withRevision(ctx, func(txRepo *sqlConfiguration, revision int64) error {
+		args := []interface{}{cc.ConfigClientUuid, cc.MultipleLanguageId, cc.Appname, cc.ReportTitle, cc.CompanySubsId, 0, revision}
+
+		var id int64
+		var uuid string
+		if txRepo.dialect.returning {
+			stmt, err := txRepo.prepare(ctx, query+" RETURNING config_client_id, config_client_uuid")
+			if err != nil {
+				return err
+			}
+
+			if err := stmt.QueryRowContext(ctx, args...).Scan(&id, &uuid); err != nil {
+				return txRepo.dialect.translate(err)
+			}
+		} else {
+			if _, err := txRepo.handlingStoreQuery(ctx, query, args...); err != nil {
+				return err
+			}
+
+			// read inside the same transaction, so it is the row just written
+			stored, err := txRepo.GetConfigurationClientBySubs(ctx, cc.CompanySubsId)
+			if err != nil {
+				return err
+			}
+
+			id, uuid = stored.ConfigClientId, stored.ConfigClientUuid
+		}
+
+		created = uuid == cc.ConfigClientUuid
+		cc.ConfigClientId, cc.ConfigClientUuid, cc.IsConfigDeleted = id, uuid, 0
+		return nil
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return created, nil
+}
+
+func (repo *sqlConfiguration) UpdateConfigurationClientBySubs(ctx context.Context, cc *pb.ConfigurationClient) (bool, error) {
+	query := "UPDATE configuration_client SET multiple_language_id = ?, appname = ?, report_title = ?, company_subs_id = ?, revision = ? WHERE config_client_uuid = ?"
+
+	err := repo.withRevision(ctx, func(txRepo *sqlConfiguration, revision int64) error {
+		res, err := txRepo.handlingStoreQuery(ctx, query, cc.MultipleLanguageId, cc.Appname, cc.ReportTitle, cc.CompanySubsId, revision, cc.ConfigClientUuid)
+		if err != nil {
+			return err
+		}
+
+		// rollback, so revision is not used when nothing changed
+		if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+			return api.Errorf(api.ErrNotFound, "Data Not Found to Update")
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (repo *sqlConfiguration) DeleteConfigurationClientBySubs(ctx context.Context, cc *pb.ConfigurationClient) (bool, error) {
+	query := "UPDATE configuration_client SET is_config_deleted = 1, revision = ? WHERE company_subs_id = ? AND is_config_deleted = 0"
+
+	// soft deleted row keep the revision of deletion, sync return it as tombstone. row already deleted is not touched,
+	// so its tombstone is not sent again
+	err := repo.withRevision(ctx, func(txRepo *sqlConfiguration, revision int64) error {
+		res, err := txRepo.handlingStoreQuery(ctx, query, revision, cc.CompanySubsId)
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+			return api.Errorf(api.ErrNotFound, "Data Not Found to Delete")
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// this function will return client that has the same company_subs_id as older client, both of them not deleted. ordered by config_client_id
+func (repo *sqlConfiguration) GetDuplicateConfigurationClient(ctx context.Context) ([]*pb.ConfigurationClient, error) {
+	query := `SELECT c.config_client_id, c.config_client_uuid, c.multiple_language_id, c.appname, c.report_title, c.company_subs_id, c.is_config_deleted, c.revision
+		FROM configuration_client c
+		WHERE c.is_config_deleted = 0 AND EXISTS (
+			SELECT 1 FROM configuration_client k WHERE k.company_subs_id = c.company_subs_id AND k.is_config_deleted = 0 AND k.config_client_id < c.config_client_id
+		)
+		ORDER BY c.config_client_id`
+
+	return repo.fetchDataConfigClient(ctx, query)
+}
+
+// this function will soft delete duplicate client, the oldest client of company_subs_id is kept. the kept client get the same revision,
+// so sync apply it after tombstone of the duplicate. it return the deleted client
+func (repo *sqlConfiguration) RepairDuplicateConfigurationClient(ctx context.Context) ([]*pb.ConfigurationClient, error) {
+	// revision is not increased when there is nothing to repair
+	duplicates, err := repo.GetDuplicateConfigurationClient(api.WithReadPrimary(ctx))
+	if err != nil || len(duplicates) == 0 {
+		return duplicates, err
+	}
+
+	err = repo.withRevision(ctx, func(txRepo *sqlConfiguration, revision int64) error {
+		// read again inside transaction, duplicate may be repaired by other request
+		duplicates, err = txRepo.GetDuplicateConfigurationClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, cc := range duplicates {
+			if _, err := txRepo.handlingStoreQuery(ctx, "UPDATE configuration_client SET is_config_deleted = 1, revision = ? WHERE config_client_id = ?", revision, cc.ConfigClientId); err != nil {
+				return err
+			}
+			cc.IsConfigDeleted = 1
+
+			if _, err := txRepo.handlingStoreQuery(ctx, "UPDATE configuration_client SET revision = ? WHERE company_subs_id = ? AND is_config_deleted = 0", revision, cc.CompanySubsId); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return duplicates, nil
+}
+
+// this function will fetch data of configurationclient with have condition company_subs_id. then this function return pointer of configurationClient and error
+func (repo *sqlConfiguration) GetConfigurationClientBySubs(ctx context.Context, clientSubsID string) (*pb.ConfigurationClient, error) {
+	query := "SELECT config_client_id, config_client_uuid, multiple_language_id, appname, report_title, company_subs_id, is_config_deleted, revision FROM configuration_client WHERE company_subs_id = ? AND is_config_deleted = 0"
+
+	// for quering, will using function fetchDataConfigClient
+	res, err := repo.fetchDataConfigClient(ctx, query, clientSubsID)
+	if err != nil {
+		return nil, err
+	}
+
+	// checking variable res, result of quering. if len res more than zero, will return only 1 data index 0
+	if len(res) > 0 {
+		return res[0], nil
+	}
+
+	// return error, if res has no data
+	return nil, api.ErrNotFound
+}
+
+func (repo *sqlConfiguration) GetConfigurationClientByUUID(ctx context.Context, configClientUUID string) (*pb.ConfigurationClient, error) {
+	query := "SELECT config_client_id, config_client_uuid, multiple_language_id, appname, report_title, company_subs_id, is_config_deleted, revision FROM configuration_client WHERE config_client_uuid = ?"
+
+	res, err := repo.fetchDataConfigClient(ctx, query, configClientUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) > 0 {
+		return res[0], nil
+	}
+
+	return nil, api.ErrNotFound
+}
+
+// this function will fetch all of data configurationclient, and will return pointer configurationclient in array, and error
+func (repo *sqlConfiguration) GetConfigurationClient(ctx context.Context) ([]*pb.ConfigurationClient, error) {
+	query := "SELECT config_client_id, config_client_uuid, multiple_language_id, appname, report_title, company_subs_id, is_config_deleted, revision FROM configuration_client"
+
+	// for quering, will using function fetchDataConfigClient
+	res, err := repo.fetchDataConfigClient(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// checking variable res, result of quering. if len res more than zero, will return all data
+	if len(res) > 0 {
+		return res, nil
+	}
+
+	// return error, if res has no data
+	return nil, api.Errorf(api.ErrNotFound, "Data Not Found")
+}
+
+// this function will store data to configuration_global, return bool and error
+func (repo *sqlConfiguration) AddConfigurationGlobal(ctx context.Context, cg *pb.ConfigurationGlobal) (bool, error) {
+	query := "INSERT INTO configuration_global (footertext, server_smpt, \"ssl\", port, is_auth, username, password, revision) VALUES(?,?,?,?,?,?,?,?)"
+
+	err := repo.withRevision(ctx, func(txRepo *sqlConfiguration, revision int64) error {
+		// insert data to table configuration_global, then id of new row is set to cg
+		id, err := txRepo.insert(ctx, query, "config_global_id", cg.Footertext, cg.ServerSmpt, cg.Ssl, cg.Port, cg.IsAuth, cg.Username, cg.Password, revision)
+		if err != nil {
+			return err
+		}
+
+		cg.ConfigGlobalId = int32(id)
+		return nil
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// this function will update data to configuration_global with condition config_global_id, return bool and error
+func (repo *sqlConfiguration) UpdateConfigurationGlobal(ctx context.Context, cg *pb.ConfigurationGlobal) (bool, error) {
+	query := "UPDATE configuration_global SET footertext = ?, server_smpt = ?, \"ssl\" = ?, port = ?, is_auth = ?, username = ?, password = ?, revision = ? WHERE config_global_id = ?"
+
+	err := repo.withRevision(ctx, func(txRepo *sqlConfiguration, revision int64) error {
+		// to execute query to update data in table configuration_global use handlingStoreQuery
+		res, err := txRepo.handlingStoreQuery(ctx, query, cg.Footertext, cg.ServerSmpt, cg.Ssl, cg.Port, cg.IsAuth, cg.Username, cg.Password, revision, cg.ConfigGlobalId)
+		if err != nil {
+			return err
+		}
+
+		// check is row affected or not. if not will return error because no data to updated
+		if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+			return api.Errorf(api.ErrNotFound, "No Data to Update")
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// this function will delete row by id in table configuration_global. return bool and error
+func (repo *sqlConfiguration) DeleteConfiguration(ctx context.Context, configGlobalID int32) (bool, error) {
+	query := "DELETE FROM configuration_global WHERE config_global_id = ?"
+
+	err := repo.withRevision(ctx, func(txRepo *sqlConfiguration, revision int64) error {
+		// to execute query delete in table configuration_global will use handlingStoreQuery
+		res, err := txRepo.handlingStoreQuery(ctx, query, configGlobalID)
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+			return api.Errorf(api.ErrNotFound, "No Data to Delete From DB")
+		}
+
+		// row is gone, so deletion is kept as tombstone to be returned by sync
+		_, err = txRepo.handlingStoreQuery(ctx, "INSERT INTO configuration_global_tombstone (config_global_id, revision) VALUES(?,?)", configGlobalID, revision)
+
+		return err
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// this function will fetch all data rows, return array of pointer configurationGlobal and error
+func (repo *sqlConfiguration) GetConfigurationGlobal(ctx context.Context) ([]*pb.ConfigurationGlobal, error) {
+	query := "SELECT config_global_id, footertext, server_smpt, \"ssl\", port, is_auth, username, password, is_active FROM configuration_global"
+
+	// execute query, and get all data in rows. if error will store in variable err
+	dataConfigGlobals, err := repo.fetchConfigurationGlobal(ctx, query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dataConfigGlobals, nil
+}
+
+// this function will return data pointer ConfigurationGlobal and error. this function will query to table configuration_global with condition configuration_global_id must equal configGlobalID (param 2)
+func (repo *sqlConfiguration) GetConfigurationGlobalByID(ctx context.Context, configGlobalID int32) (*pb.ConfigurationGlobal, error) {
+	query := "SELECT config_global_id, footertext, server_smpt, \"ssl\", port, is_auth, username, password, is_active FROM configuration_global WHERE config_global_id = ?"
+
+	// execute query, and get all data in rows using condition config_global_id must equal. if error will store in variable err
+	data, err := repo.fetchConfigurationGlobal(ctx, query, configGlobalID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// checking if data more than 0, will return only one data. index 0
+	if len(data) > 0 {
+		return data[0], nil
+	}
+
+	return nil, api.ErrNotFound
+}
+
+// this function will return data pointer ConfigurationGlobal and error. this function will query to table configuration_global with condition configration is active
+func (repo *sqlConfiguration) GetConfigurationGlobalActive(ctx context.Context) (*pb.ConfigurationGlobal, error) {
+	query := "SELECT config_global_id, footertext, server_smpt, \"ssl\", port, is_auth, username, password, is_active FROM configuration_global WHERE is_active = ?"
+
+	// execute query to get data configuration_global is active
+	res, err := repo.fetchConfigurationGlobal(ctx, query, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) > 0 {
+		return res[0], nil
+	}
+
+	return nil, nil
+}
+
+// this function will activate one row in table configuration_global by id and deactivate all other rows. both of update run in one transaction, so there is always exactly one configuration active. return bool and error
+func (repo *sqlConfiguration) SetConfigurationGlobalActive(ctx context.Context, configGlobalID int32) (bool, error) {
+	err := repo.withRevision(ctx, func(txRepo *sqlConfiguration, revision int64) error {
+		// deactivate other configuration first, so partial unique index of is_active will not be violated when target is activated
+		_, err := txRepo.handlingStoreQuery(ctx, "UPDATE configuration_global SET is_active = ?, revision = ? WHERE is_active = ? AND config_global_id <> ?", false, revision, true, configGlobalID)
+		if err != nil {
+			return err
+		}
+
+		res, err := txRepo.handlingStoreQuery(ctx, "UPDATE configuration_global SET is_active = ?, revision = ? WHERE config_global_id = ?", true, revision, configGlobalID)
+		if err != nil {
+			return err
+		}
+
+		// if target configuration not exists, return error so the transaction rolled back and the old active configuration still active
+		if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+			return api.Errorf(api.ErrNotFound, "No Data to Activate")
+		}
+
+		return nil
+	})
+
+	// partial unique index of is_active is violated when other configuration is activated at the same time
+	if errors.Is(err, api.ErrAlreadyExists) {
+		return false, api.WrapError(api.ErrConflict, "Other Configuration Activated at The Same Time", err)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// this function will return prepared statement of query. outside transaction the statement is taken from cache, inside transaction it is prepared on the transaction and closed when the transaction end
+func (repo *sqlConfiguration) prepare(ctx context.Context, query string) (*sql.Stmt, error) {
+	query = repo.dialect.rebind(query)
+
+	if repo.tx != nil {
+		stmt, err := repo.tx.PrepareContext(ctx, query)
+		return stmt, repo.dialect.translate(err)
+	}
+
+	stmt, err := repo.stmts.prepare(ctx, query)
+	return stmt, repo.dialect.translate(err)
+}
+
+func (repo *sqlConfiguration) handlingStoreQuery(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	stmt, err := repo.prepare(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := stmt.ExecContext(ctx, args...)
+	return res, repo.dialect.translate(err)
+}
+
+// this function will execute insert query and return id of inserted row. idColumn is column of the id, used by dialect that support RETURNING
+func (repo *sqlConfiguration) insert(ctx context.Context, query, idColumn string, args ...interface{}) (int64, error) {
+	if !repo.dialect.returning {
+		res, err := repo.handlingStoreQuery(ctx, query, args...)
+		if err != nil {
+			return 0, err
+		}
+
+		return res.LastInsertId()
+	}
+
+	stmt, err := repo.prepare(ctx, query+" RETURNING "+idColumn)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	err = stmt.QueryRowContext(ctx, args...).Scan(&id)
+
+	return id, repo.dialect.translate(err)
+}
+
+// this function will return client and global changed after since, and revision of the last change.
+// head is read first, so change committed while syncing is left to the next sync
+func (repo *sqlConfiguration) SyncConfiguration(ctx context.Context, since int64) (*pb.ResponseSync, error) {
+	res := &pb.ResponseSync{}
+
+	err := repo.withinTx(ctx, func(txRepo *sqlConfiguration) error {
+		head, err := txRepo.headRevision(ctx)
+		if err != nil {
+			return err
+		}
+
+		res.Revision = head
+		if since >= head {
+			return nil
+		}
+
+		res.Configclients, err = txRepo.fetchDataConfigClient(ctx, "SELECT config_client_id, config_client_uuid, multiple_language_id, appname, report_title, company_subs_id, is_config_deleted, revision FROM configuration_client WHERE is_config_deleted = 0 AND revision > ? AND revision <= ? ORDER BY revision, config_client_id", since, head)
+		if err != nil {
+			return err
+		}
+
+		res.DeletedClients, err = txRepo.fetchClientTombstones(ctx, since, head)
+		if err != nil {
+			return err
+		}
+
+		res.Configglobals, err = txRepo.fetchConfigurationGlobal(ctx, "SELECT config_global_id, footertext, server_smpt, \"ssl\", port, is_auth, username, password, is_active FROM configuration_global WHERE revision > ? AND revision <= ? ORDER BY revision, config_global_id", since, head)
+		if err != nil {
+			return err
+		}
+
+		res.DeletedGlobals, err = txRepo.fetchGlobalTombstones(ctx, since, head)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (repo *sqlConfiguration) fetchClientTombstones(ctx context.Context, since, head int64) ([]*pb.ClientTombstone, error) {
+	rows, err := repo.queryRead(ctx, "SELECT company_subs_id, revision FROM configuration_client WHERE is_config_deleted = 1 AND revision > ? AND revision <= ? ORDER BY revision, config_client_id", since, head)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	tombstones := make([]*pb.ClientTombstone, 0)
+	for rows.Next() {
+		temp := &pb.ClientTombstone{}
+		if err = rows.Scan(&temp.CompanySubsId, &temp.Revision); err != nil {
+			return nil, err
+		}
+
+		tombstones = append(tombstones, temp)
+	}
+
+	return tombstones, rows.Err()
+}
+
+func (repo *sqlConfiguration) fetchGlobalTombstones(ctx context.Context, since, head int64) ([]*pb.GlobalTombstone, error) {
+	rows, err := repo.queryRead(ctx, "SELECT config_global_id, revision FROM configuration_global_tombstone WHERE revision > ? AND revision <= ? ORDER BY revision, config_global_id", since, head)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	tombstones := make([]*pb.GlobalTombstone, 0)
+	for rows.Next() {
+		temp := &pb.GlobalTombstone{}
+		if err = rows.Scan(&temp.ConfigGlobalId, &temp.Revision); err != nil {
+			return nil, err
+		}
+
+		tombstones = append(tombstones, temp)
+	}
+
+	return tombstones, rows.Err()
+}
+
+// this function will return array pointer of configurationClient and error
+// in params query, query must follow column name as sequentially : config_client_id, config_client_uuid, multiple_language_id, appname, report_title, company_subs_id, is_config_deleted, revision
+func (repo *sqlConfiguration) fetchDataConfigClient(ctx context.Context, query string, args ...interface{}) ([]*pb.ConfigurationClient, error) {
+	// execute query using querycontext, on replica when repository has replica
+	rows, err := repo.queryRead(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// rows will be closed when this function ended
+	defer rows.Close()
+
+	// make array of configurationClient with size 0 for the first
+	configClient := make([]*pb.ConfigurationClient, 0)
+
+	// iteration to get data all rows
+	for rows.Next() {
+		// this variable is temporary, will be use to containt value of row
+		temp := &pb.ConfigurationClient{}
+
+		// mapping data of row to field in variable temp, and if error will be store in variable err. and function will be exit
+		err = rows.Scan(
+			&temp.ConfigClientId,
+			&temp.ConfigClientUuid,
+			&temp.MultipleLanguageId,
+			&temp.Appname,
+			&temp.ReportTitle,
+			&temp.CompanySubsId,
+			&temp.IsConfigDeleted,
+			&temp.Revision,
+		)
+
+		if err != nil {
+			return configClient, err
+		}
+
+		// append value from temp to array of configClient
+		configClient = append(configClient, temp)
+	}
+
+	return configClient, nil
+}
+
+func (repo *sqlConfiguration) fetchConfigurationGlobal(ctx context.Context, query string, args ...interface{}) ([]*pb.ConfigurationGlobal, error) {
+	// execute query, and get all data in rows. on replica when repository has replica
+	rows, err := repo.queryRead(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	// initiate map of pointer configurationGlobal with size 0
+	dataConfigGlobals := make([]*pb.ConfigurationGlobal, 0)
+
+	// iteration for data rows
+	for rows.Next() {
+		// variable to containt data temporary. and will be used to append in dataConfigGlobals
+		temp := &pb.ConfigurationGlobal{}
+
+		// mapping data to field of temp
+		err = rows.Scan(
+			&temp.ConfigGlobalId,
+			&temp.Footertext,
+			&temp.ServerSmpt,
+			&temp.Ssl,
+			&temp.Port,
+			&temp.IsAuth,
+			&temp.Username,
+			&temp.Password,
+			&temp.IsActive,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// append data config temporary to dataConfigGlobals
+		dataConfigGlobals = append(dataConfigGlobals, temp)
+	}
+
+	return dataConfigGlobals, nil
+}
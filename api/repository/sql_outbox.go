@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+)
+
+// this function will save event in table outbox, inside transaction it is committed together with the change
+func (repo *sqlConfiguration) AddOutboxEvent(ctx context.Context, ev *api.OutboxEvent) error {
+	query := "INSERT INTO outbox (topic, event_key, payload, attempts, next_attempt_at, created_at) VALUES(?,?,?,?,?,?)"
+
+	if ev.CreatedAt.IsZero() {
+		ev.CreatedAt = time.Now().UTC()
+	}
+
+	if ev.NextAttemptAt.IsZero() {
+		ev.NextAttemptAt = ev.CreatedAt
+	}
+
+	id, err := repo.insert(ctx, query, "id", ev.Topic, ev.Key, ev.Payload, ev.Attempts, ev.NextAttemptAt.UTC(), ev.CreatedAt.UTC())
+	if err != nil {
+		return err
+	}
+
+	ev.ID = id
+
+	return nil
+}
+
+// this function will take lock of relay on its own connection, the lock is released with the connection when unlock is called.
+// database that need no lock is always locked
+func (repo *sqlConfiguration) LockOutbox(ctx context.Context) (func(), bool, error) {
+	if repo.dialect.relayLock == "" {
+		return func() {}, true, nil
+	}
+
+	conn, err := repo.db.Conn(ctx)
+	if err != nil {
+		return nil, false, repo.dialect.translate(err)
+	}
+
+	// postgres return boolean, mysql return 1 or 0
+	var locked sql.NullBool
+	if err := conn.QueryRowContext(ctx, repo.dialect.relayLock).Scan(&locked); err != nil {
+		conn.Close()
+		return nil, false, repo.dialect.translate(err)
+	}
+
+	if !locked.Bool {
+		conn.Close()
+		return nil, false, nil
+	}
+
+	unlock := func() {
+		conn.ExecContext(context.Background(), repo.dialect.relayUnlock)
+		conn.Close()
+	}
+
+	return unlock, true, nil
+}
+
+// this function will return event of outbox that is due and not dead. event is skipped when event before it with the same key
+// wait for retry, so order of the key is kept. it always read from primary, replica may not have the newest event
+func (repo *sqlConfiguration) PendingOutboxEvents(ctx context.Context, now time.Time, limit int) ([]*api.OutboxEvent, error) {
+	query := `SELECT o.id, o.topic, o.event_key, o.payload, o.attempts, o.next_attempt_at, o.created_at FROM outbox o
+		WHERE o.dead_at IS NULL AND o.next_attempt_at <= ?
+		AND NOT EXISTS (SELECT 1 FROM outbox w WHERE w.event_key = o.event_key AND w.id < o.id AND w.dead_at IS NULL AND w.next_attempt_at > ?)
+		ORDER BY o.id LIMIT ?`
+
+	stmt, err := repo.prepare(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.QueryContext(ctx, now.UTC(), now.UTC(), limit)
+	if err != nil {
+		return nil, repo.dialect.translate(err)
+	}
+
+	defer rows.Close()
+
+	events := make([]*api.OutboxEvent, 0)
+	for rows.Next() {
+		ev := &api.OutboxEvent{}
+		if err := rows.Scan(&ev.ID, &ev.Topic, &ev.Key, &ev.Payload, &ev.Attempts, &ev.NextAttemptAt, &ev.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		events = append(events, ev)
+	}
+
+	return events, rows.Err()
+}
+
+// this function will delete event that is already published
+func (repo *sqlConfiguration) DeleteOutboxEvent(ctx context.Context, id int64) error {
+	query := "DELETE FROM outbox WHERE id = ?"
+
+	_, err := repo.handlingStoreQuery(ctx, query, id)
+
+	return err
+}
+
+// this function will count failed attempt of event and set the time of next attempt
+func (repo *sqlConfiguration) RetryOutboxEvent(ctx context.Context, id int64, nextAttemptAt time.Time, reason string) error {
+	query := "UPDATE outbox SET attempts = attempts + 1, next_attempt_at = ?, last_error = ? WHERE id = ?"
+
+	res, err := repo.handlingStoreQuery(ctx, query, nextAttemptAt.UTC(), reason, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return api.Errorf(api.ErrNotFound, "Data Not Found to Update")
+	}
+
+	return nil
+}
+
+// this function will count the last failed attempt of event and mark it dead, so relay never read it again
+func (repo *sqlConfiguration) DeadOutboxEvent(ctx context.Context, id int64, reason string) error {
+	query := "UPDATE outbox SET attempts = attempts + 1, dead_at = ?, last_error = ? WHERE id = ?"
+
+	res, err := repo.handlingStoreQuery(ctx, query, time.Now().UTC(), reason, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return api.Errorf(api.ErrNotFound, "Data Not Found to Update")
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+)
+
+// this function will add webhook, topics is saved as comma separated text
+func (repo *sqlConfiguration) AddWebhook(ctx context.Context, wh *pb.Webhook) (bool, error) {
+	query := "INSERT INTO webhook (url, secret, topics) VALUES(?,?,?)"
+
+	id, err := repo.insert(ctx, query, "webhook_id", wh.Url, wh.Secret, strings.Join(wh.Topics, ","))
+	if err != nil {
+		return false, err
+	}
+
+	wh.WebhookId = id
+
+	return true, nil
+}
+
+func (repo *sqlConfiguration) DeleteWebhook(ctx context.Context, webhookID int64) (bool, error) {
+	query := "DELETE FROM webhook WHERE webhook_id = ?"
+
+	res, err := repo.handlingStoreQuery(ctx, query, webhookID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rowsAffected == 0 {
+		return false, api.Errorf(api.ErrNotFound, "Data Not Found to Delete")
+	}
+
+	return true, nil
+}
+
+// this function will return all of webhook, it always read from primary so new webhook is delivered immediately
+func (repo *sqlConfiguration) GetWebhooks(ctx context.Context) ([]*pb.Webhook, error) {
+	query := "SELECT webhook_id, url, secret, topics FROM webhook ORDER BY webhook_id"
+
+	stmt, err := repo.prepare(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return nil, repo.dialect.translate(err)
+	}
+
+	defer rows.Close()
+
+	webhooks := make([]*pb.Webhook, 0)
+	for rows.Next() {
+		wh := &pb.Webhook{}
+
+		var topics string
+		if err := rows.Scan(&wh.WebhookId, &wh.Url, &wh.Secret, &topics); err != nil {
+			return nil, err
+		}
+
+		if topics != "" {
+			wh.Topics = strings.Split(topics, ",")
+		}
+
+		webhooks = append(webhooks, wh)
+	}
+
+	return webhooks, rows.Err()
+}
+
+// this function will add delivery with status pending
+func (repo *sqlConfiguration) AddWebhookDelivery(ctx context.Context, d *pb.WebhookDelivery) error {
+	query := "INSERT INTO webhook_delivery (webhook_id, event_id, topic, payload, status, attempts, next_attempt_at) VALUES(?,?,?,?,?,?,?)"
+
+	d.Status = api.WebhookPending
+
+	id, err := repo.insert(ctx, query, "delivery_id", d.WebhookId, d.EventId, d.Topic, d.Payload, d.Status, d.Attempts, d.NextAttemptAt)
+	if err != nil {
+		return err
+	}
+
+	d.DeliveryId = id
+
+	return nil
+}
+
+func (repo *sqlConfiguration) PendingWebhookDeliveries(ctx context.Context, now int64, limit int) ([]*pb.WebhookDelivery, error) {
+	query := "SELECT delivery_id, webhook_id, event_id, topic, payload, status, attempts, next_attempt_at, last_error FROM webhook_delivery WHERE status = ? AND next_attempt_at <= ? ORDER BY delivery_id LIMIT ?"
+
+	return repo.fetchWebhookDeliveries(ctx, query, api.WebhookPending, now, limit)
+}
+
+// this function will move next_attempt_at of delivery to until when next_attempt_at is still the one that is read,
+// so only one dispatcher claim it. delivery of dispatcher that stopped before updating it is due again after until
+func (repo *sqlConfiguration) ClaimWebhookDelivery(ctx context.Context, d *pb.WebhookDelivery, until int64) (bool, error) {
+	query := "UPDATE webhook_delivery SET next_attempt_at = ? WHERE delivery_id = ? AND status = ? AND next_attempt_at = ?"
+
+	res, err := repo.handlingStoreQuery(ctx, query, until, d.DeliveryId, api.WebhookPending, d.NextAttemptAt)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	d.NextAttemptAt = until
+
+	return true, nil
+}
+
+// this function will save status, attempts, next_attempt_at and last_error of delivery
+func (repo *sqlConfiguration) UpdateWebhookDelivery(ctx context.Context, d *pb.WebhookDelivery) error {
+	query := "UPDATE webhook_delivery SET status = ?, attempts = ?, next_attempt_at = ?, last_error = ? WHERE delivery_id = ?"
+
+	res, err := repo.handlingStoreQuery(ctx, query, d.Status, d.Attempts, d.NextAttemptAt, d.LastError, d.DeliveryId)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return api.Errorf(api.ErrNotFound, "Data Not Found to Update")
+	}
+
+	return nil
+}
+
+func (repo *sqlConfiguration) GetWebhookDeliveries(ctx context.Context, status string) ([]*pb.WebhookDelivery, error) {
+	query := "SELECT delivery_id, webhook_id, event_id, topic, payload, status, attempts, next_attempt_at, last_error FROM webhook_delivery WHERE status = ? ORDER BY delivery_id"
+
+	return repo.fetchWebhookDeliveries(ctx, query, status)
+}
+
+func (repo *sqlConfiguration) RedeliverWebhookDelivery(ctx context.Context, deliveryID int64, now int64) (bool, error) {
+	query := "UPDATE webhook_delivery SET status = ?, attempts = 0, next_attempt_at = ? WHERE delivery_id = ? AND status = ?"
+
+	res, err := repo.handlingStoreQuery(ctx, query, api.WebhookPending, now, deliveryID, api.WebhookDead)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rowsAffected == 0 {
+		return false, api.Errorf(api.ErrNotFound, "No Dead Delivery to Redeliver")
+	}
+
+	return true, nil
+}
+
+// this function will return delivery of query, column of query must be delivery_id, webhook_id, event_id, topic, payload, status, attempts, next_attempt_at, last_error
+func (repo *sqlConfiguration) fetchWebhookDeliveries(ctx context.Context, query string, args ...interface{}) ([]*pb.WebhookDelivery, error) {
+	stmt, err := repo.prepare(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, repo.dialect.translate(err)
+	}
+
+	defer rows.Close()
+
+	deliveries := make([]*pb.WebhookDelivery, 0)
+	for rows.Next() {
+		d := &pb.WebhookDelivery{}
+
+		var lastError sql.NullString
+		if err := rows.Scan(&d.DeliveryId, &d.WebhookId, &d.EventId, &d.Topic, &d.Payload, &d.Status, &d.Attempts, &d.NextAttemptAt, &lastError); err != nil {
+			return nil, err
+		}
+
+		d.LastError = lastError.String
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
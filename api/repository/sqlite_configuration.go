@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/muhammadhidayah/configuration-service/api"
+)
+
+// NewSqliteConfiguration create api.Repository on sqlite. sqlite has no boolean type, so flag is stored as integer 0 or 1
+func NewSqliteConfiguration(conn *sql.DB) api.Repository {
+	return newSQLConfiguration(conn, sqliteDialect)
+}
+
+// sqliteUniqueViolation is true for violation of unique index or primary key
+func sqliteUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && (sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey)
+}
@@ -0,0 +1,224 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+	repo "github.com/muhammadhidayah/configuration-service/api/repository"
+	"github.com/muhammadhidayah/configuration-service/migration"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSqliteDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening sqlite database", err)
+	}
+
+	// every connection of :memory: is different database, so only one connection is used
+	db.SetMaxOpenConns(1)
+
+	if _, err := migration.NewMigrator(db, migration.SQLiteDialect, migration.SQLite).Up(context.TODO()); err != nil {
+		t.Fatalf("an error '%s' was not expected when migrating sqlite database", err)
+	}
+
+	return db
+}
+
+func TestSqliteConfigurationClient(t *testing.T) {
+	db := newSqliteDB(t)
+	defer db.Close()
+
+	configRepo := repo.NewSqliteConfiguration(db)
+
+	cc := &pb.ConfigurationClient{
+		ConfigClientUuid:   "a6e2745e-c930-4717-a9d1-d1cfb2a64aa4",
+		MultipleLanguageId: 2,
+		Appname:            "client1.inactsoft.com",
+		ReportTitle:        "Client Satu",
+		CompanySubsId:      "012-031-234-542",
+	}
+
+	created, err := configRepo.AddConfigurationClient(context.TODO(), cc)
+	assert.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, int64(1), cc.ConfigClientId)
+
+	res, err := configRepo.GetConfigurationClientBySubs(context.TODO(), cc.CompanySubsId)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), res.ConfigClientId)
+	assert.Equal(t, cc.ReportTitle, res.ReportTitle)
+
+	deleted, err := configRepo.DeleteConfigurationClientBySubs(context.TODO(), cc)
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+
+	res, err = configRepo.GetConfigurationClientBySubs(context.TODO(), cc.CompanySubsId)
+	assert.Error(t, err)
+	assert.Nil(t, res)
+
+	list, err := configRepo.GetConfigurationClient(context.TODO())
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, int32(1), list[0].IsConfigDeleted)
+}
+
+func TestSqliteConfigurationGlobal(t *testing.T) {
+	db := newSqliteDB(t)
+	defer db.Close()
+
+	configRepo := repo.NewSqliteConfiguration(db)
+
+	for _, username := range []string{"notification1@inactsoft.com", "notification2@inactsoft.com"} {
+		created, err := configRepo.AddConfigurationGlobal(context.TODO(), &pb.ConfigurationGlobal{
+			Footertext: "Technical support",
+			ServerSmpt: "mail.google.com",
+			Ssl:        true,
+			Port:       465,
+			IsAuth:     true,
+			Username:   username,
+			Password:   "123456789087654",
+		})
+		assert.NoError(t, err)
+		assert.True(t, created)
+	}
+
+	res, err := configRepo.GetConfigurationGlobalByID(context.TODO(), 1)
+	assert.NoError(t, err)
+	assert.True(t, res.Ssl)
+	assert.False(t, res.IsActive)
+
+	for _, id := range []int32{1, 2} {
+		updated, err := configRepo.SetConfigurationGlobalActive(context.TODO(), id)
+		assert.NoError(t, err)
+		assert.True(t, updated)
+	}
+
+	active, err := configRepo.GetConfigurationGlobalActive(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), active.ConfigGlobalId)
+
+	updated, err := configRepo.SetConfigurationGlobalActive(context.TODO(), 99)
+	assert.Error(t, err)
+	assert.False(t, updated)
+
+	active, _ = configRepo.GetConfigurationGlobalActive(context.TODO())
+	assert.Equal(t, int32(2), active.ConfigGlobalId)
+
+	deleted, err := configRepo.DeleteConfiguration(context.TODO(), 1)
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+
+	list, err := configRepo.GetConfigurationGlobal(context.TODO())
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+}
+
+func TestSqliteConfigurationWithinTx(t *testing.T) {
+	db := newSqliteDB(t)
+	defer db.Close()
+
+	configRepo := repo.NewSqliteConfiguration(db)
+
+	err := configRepo.WithinTx(context.TODO(), func(txRepo api.Repository) error {
+		if _, err := txRepo.AddConfigurationGlobal(context.TODO(), &pb.ConfigurationGlobal{ServerSmpt: "mail.google.com"}); err != nil {
+			return err
+		}
+
+		return errors.New("Unexpected Error")
+	})
+	assert.Error(t, err)
+
+	list, err := configRepo.GetConfigurationGlobal(context.TODO())
+	assert.NoError(t, err)
+	assert.Len(t, list, 0)
+}
+
+// cached statement is shared by all goroutine, run with -race to check it
+func TestSqliteConcurrentPreparedStatement(t *testing.T) {
+	db := newSqliteDB(t)
+	defer db.Close()
+
+	configRepo := repo.NewSqliteConfiguration(db)
+	defer configRepo.(io.Closer).Close()
+
+	_, err := configRepo.AddConfigurationClient(context.TODO(), &pb.ConfigurationClient{ConfigClientUuid: "a6e2745e-c930-4717-a9d1-d1cfb2a64aa4", CompanySubsId: "012-031-234-542"})
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			res, err := configRepo.GetConfigurationClientBySubs(context.TODO(), "012-031-234-542")
+			assert.NoError(t, err)
+			assert.NotNil(t, res)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSqliteRepairDuplicateConfigurationClient(t *testing.T) {
+	db := newSqliteDB(t)
+	defer db.Close()
+
+	// duplicate can only exist in database created before the unique index
+	_, err := db.Exec("DROP INDEX configuration_client_company_subs_id_uq")
+	assert.NoError(t, err)
+
+	configRepo := repo.NewSqliteConfiguration(db)
+	for _, cc := range []*pb.ConfigurationClient{
+		{ConfigClientUuid: "a6e2745e-c930-4717-a9d1-d1cfb2a64aa4", ReportTitle: "Client Satu", CompanySubsId: "012-031-234-542"},
+		{ConfigClientUuid: "a6e2745e-c930-4717-a9d1-d1cfb2a64ab2", ReportTitle: "Client Satu Lagi", CompanySubsId: "012-031-234-542"},
+		{ConfigClientUuid: "a6e2745e-c930-4717-a9d1-d1cfb2a64ac3", ReportTitle: "Client Dua", CompanySubsId: "012-031-234-543"},
+		{ConfigClientUuid: "a6e2745e-c930-4717-a9d1-d1cfb2a64ad4", ReportTitle: "Client Satu Lagi Lagi", CompanySubsId: "012-031-234-542"},
+	} {
+		_, err := configRepo.AddConfigurationClient(context.TODO(), cc)
+		assert.NoError(t, err)
+	}
+
+	duplicates, err := configRepo.GetDuplicateConfigurationClient(context.TODO())
+	assert.NoError(t, err)
+	if assert.Len(t, duplicates, 2) {
+		assert.Equal(t, int64(2), duplicates[0].ConfigClientId)
+		assert.Equal(t, int64(4), duplicates[1].ConfigClientId)
+	}
+
+	before, err := configRepo.SyncConfiguration(context.TODO(), 0)
+	assert.NoError(t, err)
+
+	repaired, err := configRepo.RepairDuplicateConfigurationClient(context.TODO())
+	assert.NoError(t, err)
+	assert.Len(t, repaired, 2)
+
+	// the oldest client is kept
+	res, err := configRepo.GetConfigurationClientBySubs(context.TODO(), "012-031-234-542")
+	assert.NoError(t, err)
+	assert.Equal(t, "Client Satu", res.ReportTitle)
+
+	// sync return tombstone and the kept client together, so the kept client is applied after tombstone
+	sync, err := configRepo.SyncConfiguration(context.TODO(), before.Revision)
+	assert.NoError(t, err)
+	assert.Len(t, sync.DeletedClients, 2)
+	for _, tombstone := range sync.DeletedClients {
+		assert.Equal(t, "012-031-234-542", tombstone.CompanySubsId)
+	}
+	if assert.Len(t, sync.Configclients, 1) {
+		assert.Equal(t, int64(1), sync.Configclients[0].ConfigClientId)
+	}
+
+	// unique index can be created after repair
+	_, err = db.Exec("CREATE UNIQUE INDEX configuration_client_company_subs_id_uq ON configuration_client (company_subs_id) WHERE is_config_deleted = 0")
+	assert.NoError(t, err)
+
+	repaired, err = configRepo.RepairDuplicateConfigurationClient(context.TODO())
+	assert.NoError(t, err)
+	assert.Empty(t, repaired)
+}
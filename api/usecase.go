@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"time"
 
 	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
 )
@@ -9,8 +10,15 @@ import (
 type Usecase interface {
 	GetConfigurationClient(context.Context) (*pb.ResponseConfigClient, error)
 	GetConfigurationClientBySubs(context.Context, string) (*pb.ResponseConfigClient, error)
+
+	// WaitConfigurationClientBySubs return when content hash of the client differ from hash or revision of the client differ from revision,
+	// or after wait. the wait end before deadline of ctx
+	WaitConfigurationClientBySubs(ctx context.Context, subsID string, hash string, revision int64, wait time.Duration) (*pb.ResponseConfigClient, error)
+
 	AddConfigurationClient(context.Context, *pb.ConfigurationClient) (*pb.ResponseConfigClient, error)
 	UpdateConfigurationClientBySubs(context.Context, *pb.ConfigurationClient) (*pb.ResponseConfigClient, error)
+	// UpsertConfigurationClientBySubs create or update client of company_subs_id, status tell which one is done
+	UpsertConfigurationClientBySubs(context.Context, *pb.ConfigurationClient) (*pb.ResponseConfigClient, error)
 	DeleteConfigurationClientBySubs(context.Context, *pb.ConfigurationClient) (*pb.ResponseConfigClient, error)
 
 	AddConfigurationGlobal(context.Context, *pb.ConfigurationGlobal) (*pb.ResponseConfigGlobal, error)
@@ -19,5 +27,20 @@ type Usecase interface {
 	GetConfigurationGlobal(context.Context) (*pb.ResponseConfigGlobal, error)
 	GetConfigurationGlobalByID(context.Context, int32) (*pb.ResponseConfigGlobal, error)
 	GetConfigurationGlobalActive(context.Context) (*pb.ResponseConfigGlobal, error)
-	SetConfigurationGlobalActive(context.Context, *pb.ConfigurationGlobal) (*pb.ResponseConfigGlobal, error)
+
+	// SetConfigurationGlobalActive refuse configuration that has error finding of lint, unless override is true
+	SetConfigurationGlobalActive(ctx context.Context, cg *pb.ConfigurationGlobal, override bool) (*pb.ResponseConfigGlobal, error)
+
+	// Watch call send with the current configuration, then call it again every time the configuration changed until context done
+	WatchConfigurationClient(context.Context, string, func(*pb.ResponseConfigClient) error) error
+	WatchConfigurationGlobalActive(context.Context, func(*pb.ResponseConfigGlobal) error) error
+
+	AddWebhook(context.Context, *pb.Webhook) (*pb.ResponseWebhook, error)
+	DeleteWebhook(context.Context, int64) (*pb.ResponseWebhook, error)
+	GetWebhooks(context.Context) (*pb.ResponseWebhook, error)
+	GetWebhookDeliveries(context.Context, string) (*pb.ResponseWebhook, error)
+	RedeliverWebhook(context.Context, int64) (*pb.ResponseWebhook, error)
+
+	// SyncConfiguration return configuration changed after since revision, and head revision for the next sync
+	SyncConfiguration(context.Context, int64) (*pb.ResponseSync, error)
 }
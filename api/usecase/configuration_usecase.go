@@ -3,20 +3,60 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/proto"
 	"github.com/muhammadhidayah/configuration-service/api"
+	"github.com/muhammadhidayah/configuration-service/api/lint"
+	"github.com/muhammadhidayah/configuration-service/api/notifier"
 	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
 )
 
 type configurationUseCase struct {
 	configRepo     api.Repository
 	contextTimeout time.Duration
+	notifier       api.Notifier
+	watchInterval  time.Duration
+	outbox         bool
+	linter         *lint.Linter
 }
 
-func NewConfigurationUsecase(repo api.Repository, timeout time.Duration) api.Usecase {
-	return &configurationUseCase{repo, timeout}
+// Option configure usecase created by NewConfigurationUsecase
+type Option func(*configurationUseCase)
+
+// WithNotifier set notifier used to tell watcher about change, by default every usecase has its own in process notifier
+func WithNotifier(n api.Notifier) Option {
+	return func(ucase *configurationUseCase) {
+		ucase.notifier = n
+	}
+}
+
+// WithWatchInterval set how often watcher read configuration again without notification, so change that is not notified is seen too.
+// interval that is not positive keep the default
+func WithWatchInterval(interval time.Duration) Option {
+	return func(ucase *configurationUseCase) {
+		if interval > 0 {
+			ucase.watchInterval = interval
+		}
+	}
+}
+
+func NewConfigurationUsecase(repo api.Repository, timeout time.Duration, opts ...Option) api.Usecase {
+	ucase := &configurationUseCase{
+		configRepo:     repo,
+		contextTimeout: timeout,
+		notifier:       notifier.NewHub(),
+		watchInterval:  30 * time.Second,
+		linter:         lint.New(lint.DefaultRules()...),
+	}
+
+	for _, opt := range opts {
+		opt(ucase)
+	}
+
+	return ucase
 }
 
 // this function will return pointer of ResponseConfigClient and Error. this function will call GetConfigurationClient method of Repository to get all data in table configuration_client
@@ -55,9 +95,11 @@ func (ucase *configurationUseCase) GetConfigurationClientBySubs(c context.Contex
 		return nil, err
 	}
 
-	// store configClient in respConfigClient
+	// store configClient in respConfigClient, with hash to be compared by caller that already has the configuration
 	respConfigClient := &pb.ResponseConfigClient{
 		Configclient: configClient,
+		ContentHash:  contentHash(configClient),
+		Revision:     configClient.GetRevision(),
 	}
 
 	return respConfigClient, nil
@@ -70,6 +112,12 @@ func (ucase *configurationUseCase) AddConfigurationClient(c context.Context, cc
 		Status: &pb.ConfigurationStatus{Created: false},
 	}
 
+	// invalid configuration is not stored, violations is returned to show which field is wrong
+	if v := validateConfigurationClient(cc, false); len(v) > 0 {
+		respConfigC.Violations = v
+		return respConfigC, v.err()
+	}
+
 	// generate uuid for configClientUuid
 	configClientUuid, err := uuid.NewV4()
 	if err != nil {
@@ -84,16 +132,26 @@ func (ucase *configurationUseCase) AddConfigurationClient(c context.Context, cc
 
 	defer cancel()
 
-	// call function
-	resp, err := ucase.configRepo.AddConfigurationClient(ctx, cc)
+	// call AddConfigurationClient method of repository, event is recorded with it
+	var resp bool
+	err = ucase.change(ctx, func(repo api.Repository) error {
+		var err error
+		if resp, err = repo.AddConfigurationClient(ctx, cc); err != nil {
+			return err
+		}
+
+		return ucase.recordClientEvent(ctx, repo, api.TopicClientCreated, nil, cc)
+	})
 	if err != nil {
-		return respConfigC, err
+		return respConfigC, clientExistsError(cc, err)
 	}
 
 	// store configClient in respConfigClient
 	respConfigC.Status.Created = resp
 	respConfigC.Configclient = cc
 
+	ucase.notifier.Notify(api.ClientKey(cc.CompanySubsId))
+
 	return respConfigC, nil
 }
 
@@ -105,23 +163,118 @@ func (ucase *configurationUseCase) UpdateConfigurationClientBySubs(c context.Con
 		},
 	}
 
+	if v := validateConfigurationClient(cc, true); len(v) > 0 {
+		responseConfigC.Violations = v
+		return responseConfigC, v.err()
+	}
+
 	// create context timeout to cancel process database when process to long
 	ctx, cancel := context.WithTimeout(c, ucase.contextTimeout)
 
 	defer cancel()
 
-	// call UpdateConfigurationClientBySubs method of configRepo to update data in table configuration_client
-	resp, err := ucase.configRepo.UpdateConfigurationClientBySubs(ctx, cc)
+	// call UpdateConfigurationClientBySubs method of configRepo to update data in table configuration_client, event is recorded with it
+	var resp bool
+	err := ucase.change(ctx, func(repo api.Repository) error {
+		before, err := ucase.clientSnapshotByUUID(ctx, repo, cc.ConfigClientUuid)
+		if err != nil {
+			return err
+		}
+
+		if resp, err = repo.UpdateConfigurationClientBySubs(ctx, cc); err != nil {
+			return err
+		}
+
+		after, err := ucase.clientSnapshotByUUID(ctx, repo, cc.ConfigClientUuid)
+		if err != nil {
+			return err
+		}
+
+		return ucase.recordClientEvent(ctx, repo, api.TopicClientUpdated, before, after)
+	})
 	if err != nil {
-		return responseConfigC, err
+		return responseConfigC, clientExistsError(cc, err)
 	}
 
 	// update value status.updated
 	responseConfigC.Status.Updated = resp
 
+	// company_subs_id can be changed by update, so watcher of all client read again
+	ucase.notifier.NotifyPrefix(api.ClientKey(""))
+
 	return responseConfigC, nil
 }
 
+// this function will create client of company_subs_id, or update the existing client that is not deleted. uuid is generated,
+// and only stored when client is created. status.Created or status.Updated tell which one is done
+func (ucase *configurationUseCase) UpsertConfigurationClientBySubs(c context.Context, cc *pb.ConfigurationClient) (*pb.ResponseConfigClient, error) {
+	respConfigC := &pb.ResponseConfigClient{
+		Status: &pb.ConfigurationStatus{Created: false, Updated: false},
+	}
+
+	if v := validateConfigurationClient(cc, false); len(v) > 0 {
+		respConfigC.Violations = v
+		return respConfigC, v.err()
+	}
+
+	// generate uuid for configClientUuid, it is replaced by uuid of existing client when client is updated
+	configClientUuid, err := uuid.NewV4()
+	if err != nil {
+		return respConfigC, err
+	}
+
+	cc.ConfigClientUuid = configClientUuid.String()
+
+	// create context timeout to cancel process database
+	ctx, cancel := context.WithTimeout(c, ucase.contextTimeout)
+
+	defer cancel()
+
+	// call UpsertConfigurationClientBySubs method of repository, event of created or updated is recorded with it
+	var created bool
+	err = ucase.change(ctx, func(repo api.Repository) error {
+		before, err := ucase.clientSnapshot(ctx, repo, cc.CompanySubsId)
+		if err != nil {
+			return err
+		}
+
+		if created, err = repo.UpsertConfigurationClientBySubs(ctx, cc); err != nil {
+			return err
+		}
+
+		if created {
+			return ucase.recordClientEvent(ctx, repo, api.TopicClientCreated, nil, cc)
+		}
+
+		after, err := ucase.clientSnapshot(ctx, repo, cc.CompanySubsId)
+		if err != nil {
+			return err
+		}
+
+		return ucase.recordClientEvent(ctx, repo, api.TopicClientUpdated, before, after)
+	})
+	if err != nil {
+		return respConfigC, clientExistsError(cc, err)
+	}
+
+	respConfigC.Status.Created = created
+	respConfigC.Status.Updated = !created
+	respConfigC.Configclient = cc
+
+	ucase.notifier.Notify(api.ClientKey(cc.CompanySubsId))
+
+	return respConfigC, nil
+}
+
+// this function will tell which company_subs_id already has client when unique index of company_subs_id is violated, other error is returned as it is
+func clientExistsError(cc *pb.ConfigurationClient, err error) error {
+	if !errors.Is(err, api.ErrAlreadyExists) {
+		return err
+	}
+
+	return api.WrapError(api.ErrAlreadyExists, fmt.Sprintf("Configuration client of company_subs_id %s already exists", cc.GetCompanySubsId()), err)
+}
+
 // this function will change status is_delete to 1. actually not really remove from db. the function will return struct of ResponseConfigClient and error
 func (ucase *configurationUseCase) DeleteConfigurationClientBySubs(c context.Context, cc *pb.ConfigurationClient) (*pb.ResponseConfigClient, error) {
 	// create variable to contain struct responseConfigClient. for first initiate will set status.Deleted is false
@@ -136,8 +289,20 @@ func (ucase *configurationUseCase) DeleteConfigurationClientBySubs(c context.Con
 
 	defer cancel()
 
-	// call DeleteConfigurationClientBySubs method of configRepo to change status is deleted to 1
-	res, err := ucase.configRepo.DeleteConfigurationClientBySubs(ctx, cc)
+	// call DeleteConfigurationClientBySubs method of configRepo to change status is deleted to 1, event is recorded with it
+	var res bool
+	err := ucase.change(ctx, func(repo api.Repository) error {
+		before, err := ucase.clientSnapshot(ctx, repo, cc.CompanySubsId)
+		if err != nil {
+			return err
+		}
+
+		if res, err = repo.DeleteConfigurationClientBySubs(ctx, cc); err != nil {
+			return err
+		}
+
+		return ucase.recordClientEvent(ctx, repo, api.TopicClientDeleted, before, nil)
+	})
 	if err != nil {
 		return responseConfigC, err
 	}
@@ -145,6 +310,8 @@ func (ucase *configurationUseCase) DeleteConfigurationClientBySubs(c context.Con
 	// update value status.deleted
 	responseConfigC.Status.Deleted = res
 
+	ucase.notifier.Notify(api.ClientKey(cc.CompanySubsId))
+
 	return responseConfigC, nil
 }
 
@@ -156,19 +323,38 @@ func (ucase *configurationUseCase) AddConfigurationGlobal(c context.Context, cg
 		},
 	}
 
+	if v := validateConfigurationGlobal(cg, false); len(v) > 0 {
+		respConfigG.Violations = v
+		return respConfigG, v.err()
+	}
+
+	// risky configuration is stored, finding is only shown to caller. it is refused when it is activated
+	respConfigG.Findings, _ = ucase.lint(cg)
+
 	// create context timeout to cancel process database when process to long
 	ctx, cancel := context.WithTimeout(c, ucase.contextTimeout)
 
 	defer cancel()
 
-	// call AddConfigurationGlobal method of configRepo, to store data in table configuration_global
-	res, err := ucase.configRepo.AddConfigurationGlobal(ctx, cg)
+	// call AddConfigurationGlobal method of configRepo, to store data in table configuration_global, event is recorded with it
+	var res bool
+	err := ucase.change(ctx, func(repo api.Repository) error {
+		var err error
+		if res, err = repo.AddConfigurationGlobal(ctx, cg); err != nil {
+			return err
+		}
+
+		return ucase.recordGlobalEvent(ctx, repo, api.TopicGlobalCreated, nil, cg)
+	})
 	if err != nil {
 		return respConfigG, err
 	}
 
 	respConfigG.Configstatus.Created = res
 
+	// first added configuration become default active configuration
+	ucase.notifier.Notify(api.GlobalActiveKey)
+
 	return respConfigG, nil
 
 }
@@ -181,19 +367,45 @@ func (ucase *configurationUseCase) UpdateConfigurationGlobal(c context.Context,
 		},
 	}
 
+	if v := validateConfigurationGlobal(cg, true); len(v) > 0 {
+		respConfigG.Violations = v
+		return respConfigG, v.err()
+	}
+
+	respConfigG.Findings, _ = ucase.lint(cg)
+
 	// create context timeout to cancel process database when process to long
 	ctx, cancel := context.WithTimeout(c, ucase.contextTimeout)
 
 	defer cancel()
 
-	// call UpdateConfigurationGlobal method of configRepo, to update data exists by config_global_id in table configuration_global
-	res, err := ucase.configRepo.UpdateConfigurationGlobal(ctx, cg)
+	// call UpdateConfigurationGlobal method of configRepo, to update data exists by config_global_id in table configuration_global, event is recorded with it
+	var res bool
+	err := ucase.change(ctx, func(repo api.Repository) error {
+		before, err := ucase.globalSnapshot(ctx, repo, cg.GetConfigGlobalId())
+		if err != nil {
+			return err
+		}
+
+		if res, err = repo.UpdateConfigurationGlobal(ctx, cg); err != nil {
+			return err
+		}
+
+		after, err := ucase.globalSnapshot(ctx, repo, cg.GetConfigGlobalId())
+		if err != nil {
+			return err
+		}
+
+		return ucase.recordGlobalEvent(ctx, repo, api.TopicGlobalUpdated, before, after)
+	})
 	if err != nil {
 		return respConfigG, err
 	}
 
 	respConfigG.Configstatus.Updated = res
 
+	ucase.notifier.Notify(api.GlobalActiveKey)
+
 	return respConfigG, nil
 }
 
@@ -210,14 +422,28 @@ func (ucase *configurationUseCase) DeleteConfiguration(c context.Context, config
 
 	defer cancel()
 
-	// call DeleteConfiguration method of configRepo, to update data exists by config_global_id in table configuration_global
-	res, err := ucase.configRepo.DeleteConfiguration(ctx, configGloalId)
+	// call DeleteConfiguration method of configRepo, to update data exists by config_global_id in table configuration_global, event is recorded with it
+	var res bool
+	err := ucase.change(ctx, func(repo api.Repository) error {
+		before, err := ucase.globalSnapshot(ctx, repo, configGloalId)
+		if err != nil {
+			return err
+		}
+
+		if res, err = repo.DeleteConfiguration(ctx, configGloalId); err != nil {
+			return err
+		}
+
+		return ucase.recordGlobalEvent(ctx, repo, api.TopicGlobalDeleted, before, nil)
+	})
 	if err != nil {
 		return respConfigG, err
 	}
 
 	respConfigG.Configstatus.Deleted = res
 
+	ucase.notifier.Notify(api.GlobalActiveKey)
+
 	return respConfigG, nil
 }
 
@@ -275,14 +501,14 @@ func (ucase *configurationUseCase) GetConfigurationGlobalActive(c context.Contex
 	// checking is res nil or not. if nil will will set default first data in configuration_global
 	if res == nil {
 		listConfgiGlobal, err := ucase.configRepo.GetConfigurationGlobal(ctx)
-		if err != nil {
-			return nil, errors.New("Cannot set default configuration global")
+		if err != nil && !errors.Is(err, api.ErrNotFound) {
+			return nil, err
 		}
 
 		if len(listConfgiGlobal) > 0 {
 			res = listConfgiGlobal[0]
 		} else {
-			return nil, errors.New("Cannot set default configuration global")
+			return nil, api.Errorf(api.ErrNotFound, "Cannot set default configuration global")
 		}
 
 	}
@@ -291,42 +517,77 @@ func (ucase *configurationUseCase) GetConfigurationGlobalActive(c context.Contex
 	respConfigG := &pb.ResponseConfigGlobal{}
 
 	respConfigG.Configglobal = res
+	respConfigG.ContentHash = contentHash(res)
 
 	return respConfigG, nil
 }
 
-func (ucase *configurationUseCase) SetConfigurationGlobalActive(c context.Context, cg *pb.ConfigurationGlobal) (*pb.ResponseConfigGlobal, error) {
-	// set isActive field of cg param to be true
-	cg.IsActive = true
-
+// this function will activate configuration global of cg. configuration that has error finding of linter is not activated unless override is true
+func (ucase *configurationUseCase) SetConfigurationGlobalActive(c context.Context, cg *pb.ConfigurationGlobal, override bool) (*pb.ResponseConfigGlobal, error) {
 	// create context timeout to cancel process database when process to long
 	ctx, cancel := context.WithTimeout(c, ucase.contextTimeout)
 
 	defer cancel()
 
-	// Get All Configuration Global
-	listConfigGlobal, _ := ucase.configRepo.GetConfigurationGlobal(ctx)
+	// activate configuration and read it back as one unit of work, so response always contain data that was committed
+	var activated *pb.ConfigurationGlobal
+	var findings []*pb.LintFinding
+	var refused bool
+	err := ucase.configRepo.WithinTx(ctx, func(repo api.Repository) error {
+		before, err := ucase.activeSnapshot(ctx, repo)
+		if err != nil {
+			return err
+		}
+
+		// call SetConfigurationGlobalActive method of repo, to activate data by id and deactivate the others in table configuration_global
+		if _, err := repo.SetConfigurationGlobalActive(ctx, cg.GetConfigGlobalId()); err != nil {
+			return err
+		}
+
+		res, err := repo.GetConfigurationGlobalByID(ctx, cg.GetConfigGlobalId())
+		if err != nil && !errors.Is(err, api.ErrNotFound) {
+			return err
+		}
 
-	// checking configuration active, then deactive
-	for _, configGlobal := range listConfigGlobal {
-		if configGlobal.IsActive {
-			configGlobal.IsActive = false
+		// configuration is linted as it is stored, and activation is rolled back when it is refused
+		if res != nil {
+			var hasError bool
+			findings, hasError = ucase.lint(res)
+			if refused = hasError && !override; refused {
+				return api.Errorf(api.ErrConflict, "Configuration global has error finding of lint, set override_lint to activate it")
+			}
+		}
 
-			// because we wont waitting, and let the syntax run as asyncronous without blocking other process
-			go ucase.configRepo.UpdateConfigurationGlobal(ctx, configGlobal)
+		// set isActive field of copy of cg param to be true, and use it when data cannot be read back
+		activated = res
+		if activated == nil {
+			activated = proto.Clone(cg).(*pb.ConfigurationGlobal)
+			activated.IsActive = true
 		}
+
+		return ucase.recordGlobalEvent(ctx, repo, api.TopicGlobalActivated, before, activated)
+	})
+
+	if refused {
+		return &pb.ResponseConfigGlobal{Configstatus: &pb.ConfigurationStatus{Updated: false}, Findings: findings}, err
 	}
 
-	// call UpdateConfigurationGlobal method of configRepo, to update data by id in table configuration_global
-	updated, err := ucase.configRepo.UpdateConfigurationGlobal(ctx, cg)
 	if err != nil {
 		return nil, err
 	}
 
+	cg.IsActive = true
+
 	// create variable to contain struct responseConfigGlobal.
 	respConfigG := &pb.ResponseConfigGlobal{}
-	respConfigG.Configglobal = cg
-	respConfigG.Configstatus = &pb.ConfigurationStatus{Updated: updated}
+	respConfigG.Configglobal = activated
+	respConfigG.Configstatus = &pb.ConfigurationStatus{Updated: true}
+	respConfigG.Findings = findings
+
+	ucase.notifier.Notify(api.GlobalActiveKey)
+	if ucase.outbox {
+		ucase.notifier.Notify(api.OutboxKey)
+	}
 
 	return respConfigG, nil
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/muhammadhidayah/configuration-service/api"
 	"github.com/muhammadhidayah/configuration-service/api/mocks"
 	ucase "github.com/muhammadhidayah/configuration-service/api/usecase"
 	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
@@ -99,6 +100,28 @@ func TestGetConfigurationClientBySubs(t *testing.T) {
 
 		mockConfigRepo.AssertExpectations(t)
 	})
+
+	t.Run("Content hash only change when configuration changed", func(t *testing.T) {
+		changed := mockConfigClient
+		changed.ReportTitle = "Client Dua"
+
+		mockConfigRepo.On("GetConfigurationClientBySubs", mock.Anything, mock.AnythingOfType("string")).Return(&mockConfigClient, nil).Twice()
+		mockConfigRepo.On("GetConfigurationClientBySubs", mock.Anything, mock.AnythingOfType("string")).Return(&changed, nil).Once()
+
+		uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2)
+		first, err := uc.GetConfigurationClientBySubs(context.TODO(), mockConfigClient.CompanySubsId)
+		assert.NoError(t, err)
+		second, err := uc.GetConfigurationClientBySubs(context.TODO(), mockConfigClient.CompanySubsId)
+		assert.NoError(t, err)
+		third, err := uc.GetConfigurationClientBySubs(context.TODO(), mockConfigClient.CompanySubsId)
+		assert.NoError(t, err)
+
+		assert.Len(t, first.ContentHash, 64)
+		assert.Equal(t, first.ContentHash, second.ContentHash)
+		assert.NotEqual(t, first.ContentHash, third.ContentHash)
+
+		mockConfigRepo.AssertExpectations(t)
+	})
 }
 
 func TestAddConfigurationClient(t *testing.T) {
@@ -131,12 +154,24 @@ func TestAddConfigurationClient(t *testing.T) {
 		assert.Error(t, err)
 		assert.False(t, inserted.Status.Created)
 	})
+
+	t.Run("Company subs id already has client", func(t *testing.T) {
+		mockConfigRepo.On("AddConfigurationClient", mock.Anything, mock.AnythingOfType("*configuration.ConfigurationClient")).Return(false, api.Errorf(api.ErrAlreadyExists, "Data Already Exists")).Once()
+
+		uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2)
+		inserted, err := uc.AddConfigurationClient(context.TODO(), mockConfigClient)
+
+		assert.True(t, errors.Is(err, api.ErrAlreadyExists), "expected api.ErrAlreadyExists, got %v", err)
+		assert.Contains(t, err.Error(), "company_subs_id 012-031-234-542 already exists")
+		assert.False(t, inserted.Status.Created)
+	})
 }
 
 func TestUpdateConfigurationClientBySubs(t *testing.T) {
 	mockConfigRepo := new(mocks.Repository)
 	mockConfigClient := &pb.ConfigurationClient{
 		ConfigClientId:     1,
+		ConfigClientUuid:   "a6e2745e-c930-4717-a9d1-d1cfb2a64aa4",
 		MultipleLanguageId: 2,
 		Appname:            "client1.inactsoft.com",
 		ReportTitle:        "Client Satu",
@@ -165,6 +200,60 @@ func TestUpdateConfigurationClientBySubs(t *testing.T) {
 	})
 }
 
+func TestUpsertConfigurationClientBySubs(t *testing.T) {
+	mockConfigRepo := new(mocks.Repository)
+	mockConfigClient := &pb.ConfigurationClient{
+		MultipleLanguageId: 2,
+		Appname:            "client1.inactsoft.com",
+		ReportTitle:        "Client Satu",
+		CompanySubsId:      "012-031-234-542",
+	}
+
+	t.Run("Client is created", func(t *testing.T) {
+		mockConfigRepo.On("UpsertConfigurationClientBySubs", mock.Anything, mock.AnythingOfType("*configuration.ConfigurationClient")).Return(true, nil).Once()
+
+		uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2)
+		res, err := uc.UpsertConfigurationClientBySubs(context.TODO(), mockConfigClient)
+
+		assert.NoError(t, err)
+		assert.True(t, res.Status.Created)
+		assert.False(t, res.Status.Updated)
+		assert.NotEmpty(t, res.Configclient.ConfigClientUuid)
+	})
+
+	t.Run("Client is updated", func(t *testing.T) {
+		mockConfigRepo.On("UpsertConfigurationClientBySubs", mock.Anything, mock.AnythingOfType("*configuration.ConfigurationClient")).Return(false, nil).Once()
+
+		uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2)
+		res, err := uc.UpsertConfigurationClientBySubs(context.TODO(), mockConfigClient)
+
+		assert.NoError(t, err)
+		assert.False(t, res.Status.Created)
+		assert.True(t, res.Status.Updated)
+	})
+
+	t.Run("Failed upsert configuration client", func(t *testing.T) {
+		mockConfigRepo.On("UpsertConfigurationClientBySubs", mock.Anything, mock.AnythingOfType("*configuration.ConfigurationClient")).Return(false, errors.New("Unexpected Error")).Once()
+
+		uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2)
+		res, err := uc.UpsertConfigurationClientBySubs(context.TODO(), mockConfigClient)
+
+		assert.Error(t, err)
+		assert.False(t, res.Status.Created)
+		assert.False(t, res.Status.Updated)
+	})
+
+	t.Run("Invalid configuration client is not stored", func(t *testing.T) {
+		uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2)
+		res, err := uc.UpsertConfigurationClientBySubs(context.TODO(), &pb.ConfigurationClient{Appname: "client1.inactsoft.com"})
+
+		assert.True(t, errors.Is(err, api.ErrInvalidArgument), "expected api.ErrInvalidArgument, got %v", err)
+		assert.Equal(t, "company_subs_id", res.Violations[0].Field)
+	})
+
+	mockConfigRepo.AssertExpectations(t)
+}
+
 func TestDeleteConfigurationClientBySubs(t *testing.T) {
 	mockConfigRepo := new(mocks.Repository)
 	mockConfigClient := &pb.ConfigurationClient{
@@ -487,25 +576,53 @@ func TestSetConfigurationGlobalActive(t *testing.T) {
 		},
 	}
 
+	withinTx := func(ctx context.Context, fn func(api.Repository) error) error {
+		return fn(mockConfigRepo)
+	}
+
 	t.Run("Set Configuration Global Active", func(t *testing.T) {
-		mockConfigRepo.On("GetConfigurationGlobal", mock.Anything).Return(mockListConfigGlobal, nil).Once()
-		mockConfigRepo.On("UpdateConfigurationGlobal", mock.Anything, mock.AnythingOfType("*configuration.ConfigurationGlobal")).Return(true, nil)
+		activated := *mockListConfigGlobal[2]
+		activated.IsActive = true
+
+		mockConfigRepo.On("WithinTx", mock.Anything, mock.Anything).Return(withinTx).Once()
+		mockConfigRepo.On("SetConfigurationGlobalActive", mock.Anything, mockListConfigGlobal[2].ConfigGlobalId).Return(true, nil).Once()
+		mockConfigRepo.On("GetConfigurationGlobalByID", mock.Anything, mockListConfigGlobal[2].ConfigGlobalId).Return(&activated, nil).Once()
 
 		uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2)
-		res, err := uc.SetConfigurationGlobalActive(context.TODO(), mockListConfigGlobal[2])
+		res, err := uc.SetConfigurationGlobalActive(context.TODO(), mockListConfigGlobal[2], false)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
+		assert.True(t, res.Configstatus.Updated)
+		assert.Equal(t, &activated, res.Configglobal)
+
+		mockConfigRepo.AssertExpectations(t)
 	})
 
-	t.Run("Set Configuration Global Active and Inactive Config Gloabl", func(t *testing.T) {
-		mockConfigRepo.On("GetConfigurationGlobal", mock.Anything).Return(mockListConfigGlobal, nil).Once()
-		mockConfigRepo.On("UpdateConfigurationGlobal", mock.Anything, mock.AnythingOfType("*configuration.ConfigurationGlobal")).Return(true, nil)
+	t.Run("Failed Set Configuration Global Active", func(t *testing.T) {
+		cg := &pb.ConfigurationGlobal{ConfigGlobalId: 9}
+		mockConfigRepo.On("WithinTx", mock.Anything, mock.Anything).Return(withinTx).Once()
+		mockConfigRepo.On("SetConfigurationGlobalActive", mock.Anything, cg.ConfigGlobalId).Return(false, errors.New("No Data to Activate")).Once()
 
 		uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2)
-		res, err := uc.SetConfigurationGlobalActive(context.TODO(), mockListConfigGlobal[2])
+		res, err := uc.SetConfigurationGlobalActive(context.TODO(), cg, false)
 
-		assert.NoError(t, err)
-		assert.NotNil(t, res)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+		assert.False(t, cg.IsActive)
+
+		mockConfigRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed Set Configuration Global Active, transaction cannot begin", func(t *testing.T) {
+		mockConfigRepo.On("WithinTx", mock.Anything, mock.Anything).Return(errors.New("connection refused")).Once()
+
+		uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2)
+		res, err := uc.SetConfigurationGlobalActive(context.TODO(), mockListConfigGlobal[2], false)
+
+		assert.Error(t, err)
+		assert.Nil(t, res)
+
+		mockConfigRepo.AssertExpectations(t)
 	})
 }
@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/proto"
+	"github.com/muhammadhidayah/configuration-service/api"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+)
+
+// WithOutbox make every change record event in outbox, relay of package outbox publish it later.
+// without it no event is recorded, and snapshot before the change is not read
+func WithOutbox() Option {
+	return func(ucase *configurationUseCase) {
+		ucase.outbox = true
+	}
+}
+
+// clientSnapshot return copy of configuration client of subsID before it changed, nil when it not exists or event is not published.
+// error of reading fail the change, so event is never recorded with wrong snapshot
+func (ucase *configurationUseCase) clientSnapshot(ctx context.Context, repo api.Repository, subsID string) (*pb.ConfigurationClient, error) {
+	if !ucase.outbox {
+		return nil, nil
+	}
+
+	cc, err := repo.GetConfigurationClientBySubs(ctx, subsID)
+	if err != nil || cc == nil {
+		return nil, notFoundIsNil(err)
+	}
+
+	return proto.Clone(cc).(*pb.ConfigurationClient), nil
+}
+
+// clientSnapshotByUUID return copy of configuration client of configClientUUID. update find client by uuid,
+// because company_subs_id can be changed by the update itself
+func (ucase *configurationUseCase) clientSnapshotByUUID(ctx context.Context, repo api.Repository, configClientUUID string) (*pb.ConfigurationClient, error) {
+	if !ucase.outbox {
+		return nil, nil
+	}
+
+	cc, err := repo.GetConfigurationClientByUUID(ctx, configClientUUID)
+	if err != nil || cc == nil {
+		return nil, notFoundIsNil(err)
+	}
+
+	return proto.Clone(cc).(*pb.ConfigurationClient), nil
+}
+
+// globalSnapshot return copy of configuration global of configGlobalID, nil when it not exists or event is not published
+func (ucase *configurationUseCase) globalSnapshot(ctx context.Context, repo api.Repository, configGlobalID int32) (*pb.ConfigurationGlobal, error) {
+	if !ucase.outbox {
+		return nil, nil
+	}
+
+	cg, err := repo.GetConfigurationGlobalByID(ctx, configGlobalID)
+	if err != nil || cg == nil {
+		return nil, notFoundIsNil(err)
+	}
+
+	return proto.Clone(cg).(*pb.ConfigurationGlobal), nil
+}
+
+// activeSnapshot return copy of the active configuration global, nil when no one active or event is not published
+func (ucase *configurationUseCase) activeSnapshot(ctx context.Context, repo api.Repository) (*pb.ConfigurationGlobal, error) {
+	if !ucase.outbox {
+		return nil, nil
+	}
+
+	cg, err := repo.GetConfigurationGlobalActive(ctx)
+	if err != nil || cg == nil {
+		return nil, notFoundIsNil(err)
+	}
+
+	return proto.Clone(cg).(*pb.ConfigurationGlobal), nil
+}
+
+// notFoundIsNil return nil when err is api.ErrNotFound, snapshot of data that not exists is nil
+func notFoundIsNil(err error) error {
+	if errors.Is(err, api.ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// change run fn with configRepo. when event is recorded fn run in transaction, so event is saved in outbox
+// only when the change is committed, and event of failed change is rolled back with it
+func (ucase *configurationUseCase) change(ctx context.Context, fn func(api.Repository) error) error {
+	if !ucase.outbox {
+		return fn(ucase.configRepo)
+	}
+
+	if err := ucase.configRepo.WithinTx(ctx, fn); err != nil {
+		return err
+	}
+
+	// wake relay, so event is published without waiting the next poll
+	ucase.notifier.Notify(api.OutboxKey)
+
+	return nil
+}
+
+// recordClientEvent save ConfigurationClientEvent of topic to outbox of repo. event of the same client is published in order,
+// client is keyed by uuid because company_subs_id can be changed
+func (ucase *configurationUseCase) recordClientEvent(ctx context.Context, repo api.Repository, topic string, before, after *pb.ConfigurationClient) error {
+	if !ucase.outbox {
+		return nil
+	}
+
+	if after != nil {
+		after = proto.Clone(after).(*pb.ConfigurationClient)
+	}
+
+	key := after.GetConfigClientUuid()
+	if key == "" {
+		key = before.GetConfigClientUuid()
+	}
+
+	return ucase.recordEvent(ctx, repo, topic, "client:"+key, &pb.ConfigurationClientEvent{
+		Id:         newEventID(),
+		Topic:      topic,
+		OccurredAt: time.Now().UnixNano() / int64(time.Millisecond),
+		Before:     before,
+		After:      after,
+	})
+}
+
+// recordGlobalEvent save ConfigurationGlobalEvent of topic to outbox of repo. activation change more than one global,
+// so all of global event share one key and published in order. password is not saved in the event
+func (ucase *configurationUseCase) recordGlobalEvent(ctx context.Context, repo api.Repository, topic string, before, after *pb.ConfigurationGlobal) error {
+	if !ucase.outbox {
+		return nil
+	}
+
+	return ucase.recordEvent(ctx, repo, topic, "global", &pb.ConfigurationGlobalEvent{
+		Id:         newEventID(),
+		Topic:      topic,
+		OccurredAt: time.Now().UnixNano() / int64(time.Millisecond),
+		Before:     withoutSecret(before),
+		After:      withoutSecret(after),
+	})
+}
+
+// withoutSecret return copy of cg without password, event is read by every subscriber of broker and must not contain it
+func withoutSecret(cg *pb.ConfigurationGlobal) *pb.ConfigurationGlobal {
+	if cg == nil {
+		return nil
+	}
+
+	cg = proto.Clone(cg).(*pb.ConfigurationGlobal)
+	cg.Password = ""
+
+	return cg
+}
+
+func (ucase *configurationUseCase) recordEvent(ctx context.Context, repo api.Repository, topic, key string, ev proto.Message) error {
+	payload, err := proto.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	return repo.AddOutboxEvent(ctx, &api.OutboxEvent{Topic: topic, Key: key, Payload: payload})
+}
+
+// newEventID return id of event, subscriber can use it to ignore event that is delivered twice
+func newEventID() string {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return ""
+	}
+
+	return id.String()
+}
@@ -0,0 +1,156 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/broker"
+	"github.com/micro/go-micro/broker/memory"
+	"github.com/micro/go-micro/client"
+	"github.com/muhammadhidayah/configuration-service/api"
+	"github.com/muhammadhidayah/configuration-service/api/event"
+	"github.com/muhammadhidayah/configuration-service/api/mocks"
+	"github.com/muhammadhidayah/configuration-service/api/outbox"
+	"github.com/muhammadhidayah/configuration-service/api/repository"
+	ucase "github.com/muhammadhidayah/configuration-service/api/usecase"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// subscribeEvent subscribe topic of memory broker and decode the message into new value of T returned by newEvent
+func subscribeEvent(t *testing.T, b broker.Broker, topic string, newEvent func() proto.Message) <-chan proto.Message {
+	received := make(chan proto.Message, 1)
+	_, err := b.Subscribe(topic, func(p broker.Event) error {
+		ev := newEvent()
+		if err := proto.Unmarshal(p.Message().Body, ev); err != nil {
+			return err
+		}
+
+		received <- ev
+		return nil
+	})
+	require.NoError(t, err)
+
+	return received
+}
+
+func waitEvent(t *testing.T, received <-chan proto.Message) proto.Message {
+	select {
+	case ev := <-received:
+		return ev
+	case <-time.After(time.Second):
+		t.Fatal("event not received")
+		return nil
+	}
+}
+
+func TestPublishEvent(t *testing.T) {
+	b := memory.NewBroker()
+	require.NoError(t, b.Connect())
+	defer b.Disconnect()
+
+	newClientEvent := func() proto.Message { return &pb.ConfigurationClientEvent{} }
+	newGlobalEvent := func() proto.Message { return &pb.ConfigurationGlobalEvent{} }
+
+	created := subscribeEvent(t, b, api.TopicClientCreated, newClientEvent)
+	updated := subscribeEvent(t, b, api.TopicClientUpdated, newClientEvent)
+	deleted := subscribeEvent(t, b, api.TopicClientDeleted, newClientEvent)
+	activated := subscribeEvent(t, b, api.TopicGlobalActivated, newGlobalEvent)
+
+	repo := repository.NewMemoryConfiguration()
+	relay := outbox.NewRelay(repo.(api.Outbox), event.NewMicroPublisher(client.NewClient(client.Broker(b))), time.Second)
+	uc := ucase.NewConfigurationUsecase(repo, time.Second*2, ucase.WithOutbox())
+
+	// flush publish event recorded in outbox by the usecase
+	flush := func(t *testing.T) {
+		_, err := relay.Flush(context.TODO())
+		require.NoError(t, err)
+	}
+
+	t.Run("Client change carry snapshot before and after", func(t *testing.T) {
+		_, err := uc.AddConfigurationClient(context.TODO(), &pb.ConfigurationClient{Appname: "client1.inactsoft.com", ReportTitle: "Client Satu", CompanySubsId: "012-031-234-542"})
+		require.NoError(t, err)
+		flush(t)
+
+		ev := waitEvent(t, created).(*pb.ConfigurationClientEvent)
+		assert.Equal(t, api.TopicClientCreated, ev.Topic)
+		assert.NotEmpty(t, ev.Id)
+		assert.Nil(t, ev.Before)
+		assert.Equal(t, "Client Satu", ev.After.ReportTitle)
+
+		_, err = uc.UpdateConfigurationClientBySubs(context.TODO(), &pb.ConfigurationClient{ConfigClientUuid: ev.After.ConfigClientUuid, Appname: "client1.inactsoft.com", ReportTitle: "Client Updated", CompanySubsId: "012-031-234-542"})
+		require.NoError(t, err)
+		flush(t)
+
+		ev = waitEvent(t, updated).(*pb.ConfigurationClientEvent)
+		assert.Equal(t, "Client Satu", ev.Before.ReportTitle)
+		assert.Equal(t, "Client Updated", ev.After.ReportTitle)
+
+		_, err = uc.DeleteConfigurationClientBySubs(context.TODO(), &pb.ConfigurationClient{CompanySubsId: "012-031-234-542"})
+		require.NoError(t, err)
+		flush(t)
+
+		ev = waitEvent(t, deleted).(*pb.ConfigurationClientEvent)
+		assert.Equal(t, "Client Updated", ev.Before.ReportTitle)
+		assert.Nil(t, ev.After)
+	})
+
+	t.Run("Activated global carry the previous active", func(t *testing.T) {
+		first := &pb.ConfigurationGlobal{ServerSmpt: "mail.google.com", Port: 587, IsAuth: true, Username: "notification@inactsoft.com", Password: "123456789087654"}
+		second := &pb.ConfigurationGlobal{ServerSmpt: "smtp.mailgun.org", Port: 2525}
+		_, err := uc.AddConfigurationGlobal(context.TODO(), first)
+		require.NoError(t, err)
+		_, err = uc.AddConfigurationGlobal(context.TODO(), second)
+		require.NoError(t, err)
+
+		_, err = uc.SetConfigurationGlobalActive(context.TODO(), &pb.ConfigurationGlobal{ConfigGlobalId: first.ConfigGlobalId}, false)
+		require.NoError(t, err)
+		flush(t)
+		ev := waitEvent(t, activated).(*pb.ConfigurationGlobalEvent)
+		assert.Equal(t, first.ConfigGlobalId, ev.After.ConfigGlobalId)
+		assert.Equal(t, "notification@inactsoft.com", ev.After.Username)
+		assert.Empty(t, ev.After.Password, "password must not be published")
+
+		_, err = uc.SetConfigurationGlobalActive(context.TODO(), &pb.ConfigurationGlobal{ConfigGlobalId: second.ConfigGlobalId}, false)
+		require.NoError(t, err)
+		flush(t)
+
+		ev = waitEvent(t, activated).(*pb.ConfigurationGlobalEvent)
+		assert.Equal(t, api.TopicGlobalActivated, ev.Topic)
+		assert.Equal(t, first.ConfigGlobalId, ev.Before.ConfigGlobalId)
+		assert.Empty(t, ev.Before.Password, "password must not be published")
+		assert.Equal(t, second.ConfigGlobalId, ev.After.ConfigGlobalId)
+		assert.True(t, ev.After.IsActive)
+	})
+
+	t.Run("Failed change record no event", func(t *testing.T) {
+		_, err := uc.UpdateConfigurationClientBySubs(context.TODO(), &pb.ConfigurationClient{ConfigClientUuid: "not-exists", Appname: "client1.inactsoft.com", CompanySubsId: "012-031-234-542"})
+		require.True(t, errors.Is(err, api.ErrNotFound), "expected ErrNotFound, got %v", err)
+
+		pending, err := repo.(api.Outbox).PendingOutboxEvents(context.TODO(), time.Now(), 10)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+	})
+}
+
+func TestSnapshotError(t *testing.T) {
+	mockConfigRepo := new(mocks.Repository)
+	withinTx := func(ctx context.Context, fn func(api.Repository) error) error { return fn(mockConfigRepo) }
+
+	mockConfigRepo.On("WithinTx", mock.Anything, mock.Anything).Return(withinTx).Once()
+	mockConfigRepo.On("GetConfigurationClientByUUID", mock.Anything, "a6e2745e-c930-4717-a9d1-d1cfb2a64aa4").Return(nil, errors.New("connection reset by peer")).Once()
+
+	// change is not written when snapshot before it cannot be read, so event is never recorded without it
+	uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2, ucase.WithOutbox())
+	res, err := uc.UpdateConfigurationClientBySubs(context.TODO(), &pb.ConfigurationClient{ConfigClientUuid: "a6e2745e-c930-4717-a9d1-d1cfb2a64aa4", Appname: "client1.inactsoft.com", CompanySubsId: "012-031-234-542"})
+	assert.Error(t, err)
+	assert.False(t, res.Status.Updated)
+
+	mockConfigRepo.AssertExpectations(t)
+	mockConfigRepo.AssertNotCalled(t, "UpdateConfigurationClientBySubs", mock.Anything, mock.Anything)
+}
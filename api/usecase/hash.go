@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// contentHash return hex of sha256 of record. record is marshaled deterministically, so the same record always has the same hash.
+// empty string is returned when record is nil or cannot be marshaled, it never match hash of caller
+func contentHash(record proto.Message) string {
+	if record == nil {
+		return ""
+	}
+
+	buf := proto.NewBuffer(nil)
+	buf.SetDeterministic(true)
+	if err := buf.Marshal(record); err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	return hex.EncodeToString(sum[:])
+}
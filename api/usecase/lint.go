@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"github.com/muhammadhidayah/configuration-service/api/lint"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+)
+
+// WithLinter set linter of configuration global, by default only lint.DefaultRules is checked
+func WithLinter(l *lint.Linter) Option {
+	return func(ucase *configurationUseCase) {
+		ucase.linter = l
+	}
+}
+
+// this function will return finding of linter for cg as proto, and true when one of finding has severity error
+func (ucase *configurationUseCase) lint(cg *pb.ConfigurationGlobal) ([]*pb.LintFinding, bool) {
+	findings := ucase.linter.Lint(cg)
+
+	res := make([]*pb.LintFinding, 0, len(findings))
+	for _, f := range findings {
+		res = append(res, &pb.LintFinding{Rule: f.Rule, Field: f.Field, Severity: f.Severity.String(), Message: f.Message})
+	}
+
+	return res, lint.HasError(findings)
+}
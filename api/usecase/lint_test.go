@@ -0,0 +1,60 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+	"github.com/muhammadhidayah/configuration-service/api/lint"
+	"github.com/muhammadhidayah/configuration-service/api/repository"
+	ucase "github.com/muhammadhidayah/configuration-service/api/usecase"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintConfigurationGlobal(t *testing.T) {
+	repo := repository.NewMemoryConfiguration()
+	uc := ucase.NewConfigurationUsecase(repo, time.Second*2, ucase.WithLinter(lint.New(lint.ProductionRules()...)))
+
+	safe := &pb.ConfigurationGlobal{Footertext: "Technical support", ServerSmpt: "mail.google.com", Port: 465, Ssl: true}
+	private := &pb.ConfigurationGlobal{Footertext: "Technical support", ServerSmpt: "10.0.0.25", Port: 465, Ssl: true}
+
+	_, err := uc.AddConfigurationGlobal(context.TODO(), safe)
+	require.NoError(t, err)
+
+	t.Run("Risky configuration is stored with findings", func(t *testing.T) {
+		res, err := uc.AddConfigurationGlobal(context.TODO(), private)
+
+		require.NoError(t, err)
+		assert.True(t, res.Configstatus.Created)
+		require.Len(t, res.Findings, 1)
+		assert.Equal(t, &pb.LintFinding{Rule: "private-server", Field: "server_smpt", Severity: "error", Message: lint.PrivateServer.Message}, res.Findings[0])
+	})
+
+	t.Run("Configuration with error finding is not activated", func(t *testing.T) {
+		res, err := uc.SetConfigurationGlobalActive(context.TODO(), &pb.ConfigurationGlobal{ConfigGlobalId: private.ConfigGlobalId}, false)
+
+		assert.True(t, errors.Is(err, api.ErrConflict), "expected ErrConflict, got %v", err)
+		assert.False(t, res.Configstatus.Updated)
+		assert.Len(t, res.Findings, 1)
+
+		active, err := uc.GetConfigurationGlobalActive(context.TODO())
+		require.NoError(t, err)
+		assert.Equal(t, safe.ConfigGlobalId, active.Configglobal.ConfigGlobalId)
+	})
+
+	t.Run("Override activate configuration with error finding", func(t *testing.T) {
+		res, err := uc.SetConfigurationGlobalActive(context.TODO(), &pb.ConfigurationGlobal{ConfigGlobalId: private.ConfigGlobalId}, true)
+
+		require.NoError(t, err)
+		assert.True(t, res.Configstatus.Updated)
+		assert.Len(t, res.Findings, 1)
+
+		active, err := uc.GetConfigurationGlobalActive(context.TODO())
+		require.NoError(t, err)
+		assert.Equal(t, private.ConfigGlobalId, active.Configglobal.ConfigGlobalId)
+	})
+}
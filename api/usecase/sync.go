@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+)
+
+// this function will return configuration changed after sinceRevision. sinceRevision 0 return all of configuration,
+// then caller keep revision of response to be sent on the next sync
+func (ucase *configurationUseCase) SyncConfiguration(c context.Context, sinceRevision int64) (*pb.ResponseSync, error) {
+	if sinceRevision < 0 {
+		return &pb.ResponseSync{}, api.Errorf(api.ErrInvalidArgument, "Since revision must not be negative")
+	}
+
+	// create context timeout to cancel process database when process to long
+	ctx, cancel := context.WithTimeout(c, ucase.contextTimeout)
+
+	defer cancel()
+
+	return ucase.configRepo.SyncConfiguration(ctx, sinceRevision)
+}
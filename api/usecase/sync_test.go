@@ -0,0 +1,43 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+	"github.com/muhammadhidayah/configuration-service/api/mocks"
+	ucase "github.com/muhammadhidayah/configuration-service/api/usecase"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSyncConfiguration(t *testing.T) {
+	t.Run("Sync return change from repository", func(t *testing.T) {
+		mockConfigRepo := new(mocks.Repository)
+		mockConfigRepo.On("SyncConfiguration", mock.Anything, int64(10)).Return(&pb.ResponseSync{
+			Revision:       12,
+			DeletedClients: []*pb.ClientTombstone{{CompanySubsId: "180-000-123-0321", Revision: 11}},
+		}, nil).Once()
+
+		uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2)
+		res, err := uc.SyncConfiguration(context.TODO(), 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12), res.Revision)
+		assert.Len(t, res.DeletedClients, 1)
+		mockConfigRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative revision is rejected", func(t *testing.T) {
+		mockConfigRepo := new(mocks.Repository)
+
+		uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2)
+		_, err := uc.SyncConfiguration(context.TODO(), -1)
+
+		assert.True(t, errors.Is(err, api.ErrInvalidArgument), "expected ErrInvalidArgument, got %v", err)
+		mockConfigRepo.AssertNotCalled(t, "SyncConfiguration", mock.Anything, mock.Anything)
+	})
+}
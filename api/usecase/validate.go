@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"net"
+	"strings"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+)
+
+// violations collect invalid field of one request, field is named as in proto so admin ui can find it
+type violations []*pb.FieldViolation
+
+func (v *violations) add(field, description string) {
+	*v = append(*v, &pb.FieldViolation{Field: field, Description: description})
+}
+
+// err return error of kind ErrInvalidArgument with all of invalid field in the message, nil when there is no violation
+func (v violations) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+
+	fields := make([]string, 0, len(v))
+	for _, violation := range v {
+		fields = append(fields, violation.Field+": "+violation.Description)
+	}
+
+	return api.Errorf(api.ErrInvalidArgument, "Configuration is not valid, %s", strings.Join(fields, ", "))
+}
+
+// this function will check configuration client before it is stored. it return all of invalid field, not only the first one.
+// update need config_client_uuid of the client
+func validateConfigurationClient(cc *pb.ConfigurationClient, update bool) violations {
+	var v violations
+
+	if update && cc.GetConfigClientUuid() == "" {
+		v.add("config_client_uuid", "must not be empty")
+	}
+
+	if strings.TrimSpace(cc.GetCompanySubsId()) == "" {
+		v.add("company_subs_id", "must not be empty")
+	} else if strings.TrimSpace(cc.GetCompanySubsId()) != cc.GetCompanySubsId() {
+		v.add("company_subs_id", "must not start or end with space")
+	}
+
+	if cc.GetAppname() == "" {
+		v.add("appname", "must not be empty")
+	} else if !isHostname(cc.GetAppname()) {
+		v.add("appname", "must be hostname, like client1.inactsoft.com")
+	}
+
+	if cc.GetMultipleLanguageId() < 0 {
+		v.add("multiple_language_id", "must not be negative")
+	}
+
+	return v
+}
+
+// this function will check configuration global before it is stored. update need config_global_id of the configuration
+func validateConfigurationGlobal(cg *pb.ConfigurationGlobal, update bool) violations {
+	var v violations
+
+	if update && cg.GetConfigGlobalId() <= 0 {
+		v.add("config_global_id", "must be greater than 0")
+	}
+
+	if cg.GetServerSmpt() == "" {
+		v.add("server_smpt", "must not be empty")
+	} else if !isHostname(cg.GetServerSmpt()) && net.ParseIP(cg.GetServerSmpt()) == nil {
+		v.add("server_smpt", "must be hostname or ip address")
+	}
+
+	if cg.GetPort() < 1 || cg.GetPort() > 65535 {
+		v.add("port", "must be between 1 and 65535")
+	}
+
+	if cg.GetIsAuth() {
+		if cg.GetUsername() == "" {
+			v.add("username", "must not be empty when is_auth is true")
+		}
+
+		if cg.GetPassword() == "" {
+			v.add("password", "must not be empty when is_auth is true")
+		}
+	}
+
+	return v
+}
+
+// isHostname check name is hostname of RFC 1123, label is separated by dot and only contain letter, digit and hyphen
+func isHostname(name string) bool {
+	name = strings.TrimSuffix(name, ".")
+	if name == "" || len(name) > 253 {
+		return false
+	}
+
+	for _, label := range strings.Split(name, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+				return false
+			}
+		}
+	}
+
+	return true
+}
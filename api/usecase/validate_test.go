@@ -0,0 +1,114 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+	"github.com/muhammadhidayah/configuration-service/api/mocks"
+	ucase "github.com/muhammadhidayah/configuration-service/api/usecase"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fields return name of field of violations, in order
+func fields(violations []*pb.FieldViolation) []string {
+	names := make([]string, 0, len(violations))
+	for _, v := range violations {
+		names = append(names, v.Field)
+	}
+
+	return names
+}
+
+func TestValidateConfigurationClient(t *testing.T) {
+	tests := []struct {
+		name   string
+		cc     *pb.ConfigurationClient
+		fields []string
+	}{
+		{"Empty company_subs_id", &pb.ConfigurationClient{Appname: "client1.inactsoft.com"}, []string{"company_subs_id"}},
+		{"Company_subs_id with space", &pb.ConfigurationClient{Appname: "client1.inactsoft.com", CompanySubsId: " 012-031-234-542"}, []string{"company_subs_id"}},
+		{"Appname is not hostname", &pb.ConfigurationClient{Appname: "http://client1.inactsoft.com", CompanySubsId: "012-031-234-542"}, []string{"appname"}},
+		{"Appname label start with hyphen", &pb.ConfigurationClient{Appname: "-client1.inactsoft.com", CompanySubsId: "012-031-234-542"}, []string{"appname"}},
+		{"All invalid field is returned", &pb.ConfigurationClient{MultipleLanguageId: -1}, []string{"company_subs_id", "appname", "multiple_language_id"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockConfigRepo := new(mocks.Repository)
+			uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2)
+
+			res, err := uc.AddConfigurationClient(context.TODO(), tt.cc)
+
+			assert.True(t, errors.Is(err, api.ErrInvalidArgument), "expected ErrInvalidArgument, got %v", err)
+			assert.False(t, res.Status.Created)
+			assert.Equal(t, tt.fields, fields(res.Violations))
+			mockConfigRepo.AssertNotCalled(t, "AddConfigurationClient", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("Update need config_client_uuid", func(t *testing.T) {
+		mockConfigRepo := new(mocks.Repository)
+		uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2)
+
+		res, err := uc.UpdateConfigurationClientBySubs(context.TODO(), &pb.ConfigurationClient{Appname: "client1.inactsoft.com", CompanySubsId: "012-031-234-542"})
+
+		assert.Error(t, err)
+		assert.Equal(t, []string{"config_client_uuid"}, fields(res.Violations))
+		mockConfigRepo.AssertNotCalled(t, "UpdateConfigurationClientBySubs", mock.Anything, mock.Anything)
+	})
+}
+
+func TestValidateConfigurationGlobal(t *testing.T) {
+	tests := []struct {
+		name   string
+		cg     *pb.ConfigurationGlobal
+		fields []string
+	}{
+		{"Negative port", &pb.ConfigurationGlobal{ServerSmpt: "mail.google.com", Port: -1}, []string{"port"}},
+		{"Port over 65535", &pb.ConfigurationGlobal{ServerSmpt: "mail.google.com", Port: 65536}, []string{"port"}},
+		{"Auth without username and password", &pb.ConfigurationGlobal{ServerSmpt: "mail.google.com", Port: 465, IsAuth: true}, []string{"username", "password"}},
+		{"Server is not hostname", &pb.ConfigurationGlobal{ServerSmpt: "mail google com", Port: 465}, []string{"server_smpt"}},
+		{"Empty server", &pb.ConfigurationGlobal{Port: 465}, []string{"server_smpt"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockConfigRepo := new(mocks.Repository)
+			uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2)
+
+			res, err := uc.AddConfigurationGlobal(context.TODO(), tt.cg)
+
+			assert.True(t, errors.Is(err, api.ErrInvalidArgument), "expected ErrInvalidArgument, got %v", err)
+			assert.False(t, res.Configstatus.Created)
+			assert.Equal(t, tt.fields, fields(res.Violations))
+			mockConfigRepo.AssertNotCalled(t, "AddConfigurationGlobal", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("Ip address is valid server", func(t *testing.T) {
+		mockConfigRepo := new(mocks.Repository)
+		mockConfigRepo.On("AddConfigurationGlobal", mock.Anything, mock.AnythingOfType("*configuration.ConfigurationGlobal")).Return(true, nil).Once()
+		uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2)
+
+		res, err := uc.AddConfigurationGlobal(context.TODO(), &pb.ConfigurationGlobal{ServerSmpt: "10.0.0.25", Port: 25})
+
+		assert.NoError(t, err)
+		assert.Empty(t, res.Violations)
+		mockConfigRepo.AssertExpectations(t)
+	})
+
+	t.Run("Update need config_global_id", func(t *testing.T) {
+		mockConfigRepo := new(mocks.Repository)
+		uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2)
+
+		res, err := uc.UpdateConfigurationGlobal(context.TODO(), &pb.ConfigurationGlobal{ServerSmpt: "mail.google.com", Port: 465})
+
+		assert.Error(t, err)
+		assert.Equal(t, []string{"config_global_id"}, fields(res.Violations))
+	})
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+)
+
+const (
+	// maxWait is the longest time caller can be held by WaitConfigurationClientBySubs
+	maxWait = 5 * time.Minute
+
+	// deadlineMargin is kept before deadline of the caller, so response is sent before the caller give up
+	deadlineMargin = 500 * time.Millisecond
+)
+
+// this function will hold the caller until configuration client of subsID differ from hash or revision, or the wait passed.
+// empty hash and zero revision is not compared. the wait is cut before deadline of c, so the caller get the response instead of timeout.
+// it is woken by notifier when configuration changed, and read the configuration again every watch interval for change written by other instance.
+// the last configuration is returned when nothing changed, so content hash and revision of response is equal with the request
+func (ucase *configurationUseCase) WaitConfigurationClientBySubs(c context.Context, subsID string, hash string, revision int64, wait time.Duration) (*pb.ResponseConfigClient, error) {
+	if wait > maxWait {
+		wait = maxWait
+	}
+
+	if deadline, ok := c.Deadline(); ok && time.Until(deadline)-deadlineMargin < wait {
+		wait = time.Until(deadline) - deadlineMargin
+	}
+
+	// subscribe before the first read, so change between the read and waiting is not lost
+	changed, stop := ucase.notifier.Subscribe(api.ClientKey(subsID))
+	defer stop()
+
+	timeout := time.NewTimer(wait)
+	defer timeout.Stop()
+
+	ticker := time.NewTicker(ucase.watchInterval)
+	defer ticker.Stop()
+
+	for {
+		// read from primary database, replica may not have the change that is notified yet
+		res, err := ucase.GetConfigurationClientBySubs(api.WithReadPrimary(c), subsID)
+		if err != nil {
+			return nil, err
+		}
+
+		if (hash != "" && res.ContentHash != hash) || (revision != 0 && res.Revision != revision) {
+			return res, nil
+		}
+
+		select {
+		case <-c.Done():
+			return nil, c.Err()
+		case <-timeout.C:
+			return res, nil
+		case <-changed:
+		case <-ticker.C:
+		}
+	}
+}
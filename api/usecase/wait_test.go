@@ -0,0 +1,127 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+	"github.com/muhammadhidayah/configuration-service/api/notifier"
+	"github.com/muhammadhidayah/configuration-service/api/repository"
+	ucase "github.com/muhammadhidayah/configuration-service/api/usecase"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// waitFixture is usecase with one client, hash is content hash of the client
+type waitFixture struct {
+	uc       api.Usecase
+	client   *pb.ConfigurationClient
+	hash     string
+	revision int64
+}
+
+func TestWaitConfigurationClientBySubs(t *testing.T) {
+	newUsecase := func(t *testing.T) waitFixture {
+		uc := ucase.NewConfigurationUsecase(repository.NewMemoryConfiguration(), time.Second*2, ucase.WithNotifier(notifier.NewHub()), ucase.WithWatchInterval(time.Hour))
+
+		cc := &pb.ConfigurationClient{Appname: "client1.inactsoft.com", ReportTitle: "Client Satu", CompanySubsId: "012-031-234-542"}
+		_, err := uc.AddConfigurationClient(context.TODO(), cc)
+		require.NoError(t, err)
+
+		res, err := uc.GetConfigurationClientBySubs(context.TODO(), cc.CompanySubsId)
+		require.NoError(t, err)
+
+		return waitFixture{uc: uc, client: cc, hash: res.ContentHash, revision: res.Revision}
+	}
+
+	t.Run("Return immediately when hash is old", func(t *testing.T) {
+		s := newUsecase(t)
+
+		res, err := s.uc.WaitConfigurationClientBySubs(context.TODO(), s.client.CompanySubsId, "old", 0, time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, s.hash, res.ContentHash)
+	})
+
+	t.Run("Return when configuration changed", func(t *testing.T) {
+		s := newUsecase(t)
+
+		done := make(chan *pb.ResponseConfigClient)
+		go func() {
+			res, err := s.uc.WaitConfigurationClientBySubs(context.TODO(), s.client.CompanySubsId, s.hash, 0, time.Minute)
+			assert.NoError(t, err)
+			done <- res
+		}()
+
+		// still waiting, nothing changed
+		select {
+		case <-done:
+			t.Fatal("wait returned before configuration changed")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		s.client.ReportTitle = "Client Dua"
+		_, err := s.uc.UpdateConfigurationClientBySubs(context.TODO(), s.client)
+		require.NoError(t, err)
+
+		select {
+		case res := <-done:
+			assert.NotEqual(t, s.hash, res.ContentHash)
+			assert.Equal(t, "Client Dua", res.Configclient.ReportTitle)
+		case <-time.After(time.Second):
+			t.Fatal("wait not woken by change")
+		}
+	})
+
+	t.Run("Return when revision changed", func(t *testing.T) {
+		s := newUsecase(t)
+		require.NotZero(t, s.revision)
+
+		res, err := s.uc.WaitConfigurationClientBySubs(context.TODO(), s.client.CompanySubsId, "", s.revision-1, time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, s.revision, res.Revision)
+
+		done := make(chan *pb.ResponseConfigClient)
+		go func() {
+			res, err := s.uc.WaitConfigurationClientBySubs(context.TODO(), s.client.CompanySubsId, "", s.revision, time.Minute)
+			assert.NoError(t, err)
+			done <- res
+		}()
+
+		s.client.ReportTitle = "Client Dua"
+		_, err = s.uc.UpdateConfigurationClientBySubs(context.TODO(), s.client)
+		require.NoError(t, err)
+
+		select {
+		case res := <-done:
+			assert.True(t, res.Revision > s.revision)
+			assert.Equal(t, "Client Dua", res.Configclient.ReportTitle)
+		case <-time.After(time.Second):
+			t.Fatal("wait not woken by change")
+		}
+	})
+
+	t.Run("Return before deadline of caller", func(t *testing.T) {
+		s := newUsecase(t)
+
+		ctx, cancel := context.WithTimeout(context.TODO(), 700*time.Millisecond)
+		defer cancel()
+
+		// nothing changed, the last configuration is returned instead of timeout
+		res, err := s.uc.WaitConfigurationClientBySubs(ctx, s.client.CompanySubsId, s.hash, 0, time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, s.hash, res.ContentHash)
+		assert.NoError(t, ctx.Err())
+	})
+
+	t.Run("Return the same configuration when wait passed", func(t *testing.T) {
+		s := newUsecase(t)
+
+		started := time.Now()
+		res, err := s.uc.WaitConfigurationClientBySubs(context.TODO(), s.client.CompanySubsId, s.hash, 0, 50*time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, s.hash, res.ContentHash)
+		assert.True(t, time.Since(started) >= 50*time.Millisecond)
+	})
+}
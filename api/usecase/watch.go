@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/muhammadhidayah/configuration-service/api"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+)
+
+// this function will send configuration client of subsID, then send it again every time it changed. Configclient of response is nil when client not exists or deleted.
+// content_hash is the same as of GetConfigurationClientBySubs, so caller can continue with conditional read after the stream end.
+// it return nil when ctx done, or error when reading configuration or sending failed
+func (ucase *configurationUseCase) WatchConfigurationClient(c context.Context, subsID string, send func(*pb.ResponseConfigClient) error) error {
+	fetch := func(ctx context.Context) (proto.Message, error) {
+		configClient, err := ucase.configRepo.GetConfigurationClientBySubs(ctx, subsID)
+		if err != nil && !errors.Is(err, api.ErrNotFound) {
+			return nil, err
+		}
+
+		return &pb.ResponseConfigClient{Configclient: configClient, ContentHash: contentHash(configClient)}, nil
+	}
+
+	return ucase.watch(c, api.ClientKey(subsID), fetch, func(res proto.Message) error {
+		return send(res.(*pb.ResponseConfigClient))
+	})
+}
+
+// this function will send active configuration global, then send it again every time it changed. like GetConfigurationGlobalActive, the first configuration is sent when no configuration active
+func (ucase *configurationUseCase) WatchConfigurationGlobalActive(c context.Context, send func(*pb.ResponseConfigGlobal) error) error {
+	fetch := func(ctx context.Context) (proto.Message, error) {
+		res, err := ucase.configRepo.GetConfigurationGlobalActive(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if res == nil {
+			listConfigGlobal, err := ucase.configRepo.GetConfigurationGlobal(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(listConfigGlobal) > 0 {
+				res = listConfigGlobal[0]
+			}
+		}
+
+		return &pb.ResponseConfigGlobal{Configglobal: res, ContentHash: contentHash(res)}, nil
+	}
+
+	return ucase.watch(c, api.GlobalActiveKey, fetch, func(res proto.Message) error {
+		return send(res.(*pb.ResponseConfigGlobal))
+	})
+}
+
+// watch call fetch when started, when key notified and every watch interval, then call send when the result differ from the last sent
+func (ucase *configurationUseCase) watch(ctx context.Context, key string, fetch func(context.Context) (proto.Message, error), send func(proto.Message) error) error {
+	// subscribe before the first fetch, so change between the fetch and waiting is not lost
+	changed, stop := ucase.notifier.Subscribe(key)
+	defer stop()
+
+	ticker := time.NewTicker(ucase.watchInterval)
+	defer ticker.Stop()
+
+	var last proto.Message
+	for {
+		// read from primary database, replica may not have the change that is notified yet
+		fetchCtx, cancel := context.WithTimeout(api.WithReadPrimary(ctx), ucase.contextTimeout)
+		current, err := fetch(fetchCtx)
+		cancel()
+
+		if err != nil {
+			return err
+		}
+
+		if last == nil || !proto.Equal(last, current) {
+			if err := send(current); err != nil {
+				return err
+			}
+
+			last = current
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changed:
+		case <-ticker.C:
+		}
+	}
+}
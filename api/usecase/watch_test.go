@@ -0,0 +1,146 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+	"github.com/muhammadhidayah/configuration-service/api/mocks"
+	"github.com/muhammadhidayah/configuration-service/api/notifier"
+	ucase "github.com/muhammadhidayah/configuration-service/api/usecase"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// waitSent return response sent by watch, or nil when nothing sent in a while
+func waitSent(sent <-chan *pb.ResponseConfigClient) *pb.ResponseConfigClient {
+	select {
+	case res := <-sent:
+		return res
+	case <-time.After(200 * time.Millisecond):
+		return nil
+	}
+}
+
+func TestWatchConfigurationClient(t *testing.T) {
+	mockConfigClient := &pb.ConfigurationClient{
+		ConfigClientId:   1,
+		ConfigClientUuid: "a6e2745e-c930-4717-a9d1-d1cfb2a64aa4",
+		ReportTitle:      "Client Satu",
+		CompanySubsId:    "012-031-234-542",
+	}
+
+	t.Run("Send current client, then send only when it changed", func(t *testing.T) {
+		mockConfigRepo := new(mocks.Repository)
+		hub := notifier.NewHub()
+		uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2, ucase.WithNotifier(hub), ucase.WithWatchInterval(time.Hour))
+
+		mockConfigRepo.On("GetConfigurationClientBySubs", mock.Anything, "012-031-234-542").Return(mockConfigClient, nil).Twice()
+		mockConfigRepo.On("GetConfigurationClientBySubs", mock.Anything, "012-031-234-542").Return(nil, api.ErrNotFound).Once()
+
+		ctx, cancel := context.WithCancel(context.TODO())
+		sent := make(chan *pb.ResponseConfigClient)
+		done := make(chan error)
+		go func() {
+			done <- uc.WatchConfigurationClient(ctx, "012-031-234-542", func(res *pb.ResponseConfigClient) error {
+				sent <- res
+				return nil
+			})
+		}()
+
+		res := waitSent(sent)
+		if assert.NotNil(t, res) {
+			assert.Equal(t, "Client Satu", res.Configclient.ReportTitle)
+			assert.NotEmpty(t, res.ContentHash)
+		}
+
+		// notified but not changed, nothing sent
+		hub.Notify(api.ClientKey("012-031-234-542"))
+		assert.Nil(t, waitSent(sent))
+
+		// deleted client is sent as empty configclient
+		hub.Notify(api.ClientKey("012-031-234-542"))
+		res = waitSent(sent)
+		if assert.NotNil(t, res) {
+			assert.Nil(t, res.Configclient)
+		}
+
+		cancel()
+		assert.NoError(t, <-done)
+		mockConfigRepo.AssertExpectations(t)
+	})
+
+	t.Run("Write through usecase notify watcher", func(t *testing.T) {
+		mockConfigRepo := new(mocks.Repository)
+		uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2, ucase.WithWatchInterval(time.Hour))
+
+		updated := &pb.ConfigurationClient{ConfigClientUuid: mockConfigClient.ConfigClientUuid, Appname: "client1.inactsoft.com", ReportTitle: "Client Updated", CompanySubsId: "012-031-234-542"}
+		mockConfigRepo.On("GetConfigurationClientBySubs", mock.Anything, "012-031-234-542").Return(mockConfigClient, nil).Once()
+		mockConfigRepo.On("GetConfigurationClientBySubs", mock.Anything, "012-031-234-542").Return(updated, nil).Once()
+		mockConfigRepo.On("UpdateConfigurationClientBySubs", mock.Anything, updated).Return(true, nil).Once()
+
+		ctx, cancel := context.WithCancel(context.TODO())
+		sent := make(chan *pb.ResponseConfigClient)
+		done := make(chan error)
+		go func() {
+			done <- uc.WatchConfigurationClient(ctx, "012-031-234-542", func(res *pb.ResponseConfigClient) error {
+				sent <- res
+				return nil
+			})
+		}()
+
+		assert.NotNil(t, waitSent(sent))
+
+		_, err := uc.UpdateConfigurationClientBySubs(context.TODO(), updated)
+		assert.NoError(t, err)
+
+		res := waitSent(sent)
+		if assert.NotNil(t, res) {
+			assert.Equal(t, "Client Updated", res.Configclient.ReportTitle)
+		}
+
+		cancel()
+		assert.NoError(t, <-done)
+		mockConfigRepo.AssertExpectations(t)
+	})
+
+	t.Run("Watch stop when reading failed", func(t *testing.T) {
+		mockConfigRepo := new(mocks.Repository)
+		uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2)
+
+		mockConfigRepo.On("GetConfigurationClientBySubs", mock.Anything, "012-031-234-542").Return(nil, errors.New("connection refused")).Once()
+
+		err := uc.WatchConfigurationClient(context.TODO(), "012-031-234-542", func(res *pb.ResponseConfigClient) error {
+			t.Errorf("nothing must be sent")
+			return nil
+		})
+		assert.Error(t, err)
+		mockConfigRepo.AssertExpectations(t)
+	})
+}
+
+func TestWatchConfigurationGlobalActive(t *testing.T) {
+	mockConfigRepo := new(mocks.Repository)
+	uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2)
+
+	first := &pb.ConfigurationGlobal{ConfigGlobalId: 1, ServerSmpt: "mail.google.com"}
+	mockConfigRepo.On("GetConfigurationGlobalActive", mock.Anything).Return(nil, nil).Once()
+	mockConfigRepo.On("GetConfigurationGlobal", mock.Anything).Return([]*pb.ConfigurationGlobal{first}, nil).Once()
+
+	errStop := errors.New("stop")
+	var sent *pb.ResponseConfigGlobal
+	err := uc.WatchConfigurationGlobalActive(context.TODO(), func(res *pb.ResponseConfigGlobal) error {
+		sent = res
+		return errStop
+	})
+
+	// error of send stop watching
+	assert.Equal(t, errStop, err)
+	if assert.NotNil(t, sent) {
+		assert.Equal(t, int32(1), sent.Configglobal.ConfigGlobalId)
+	}
+	mockConfigRepo.AssertExpectations(t)
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"time"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+)
+
+// topics that can be subscribed by webhook
+var webhookTopics = map[string]bool{
+	api.TopicClientCreated:   true,
+	api.TopicClientUpdated:   true,
+	api.TopicClientDeleted:   true,
+	api.TopicGlobalCreated:   true,
+	api.TopicGlobalUpdated:   true,
+	api.TopicGlobalDeleted:   true,
+	api.TopicGlobalActivated: true,
+}
+
+// this function will add webhook. secret is generated when it is empty, and only returned by this function
+func (ucase *configurationUseCase) AddWebhook(c context.Context, wh *pb.Webhook) (*pb.ResponseWebhook, error) {
+	respWebhook := &pb.ResponseWebhook{
+		Status: &pb.ConfigurationStatus{Created: false},
+	}
+
+	u, err := url.Parse(wh.GetUrl())
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return respWebhook, api.Errorf(api.ErrInvalidArgument, "Url of webhook must be absolute http or https url")
+	}
+
+	for _, topic := range wh.Topics {
+		if !webhookTopics[topic] {
+			return respWebhook, api.Errorf(api.ErrInvalidArgument, "Unknown topic of webhook: %s", topic)
+		}
+	}
+
+	if wh.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return respWebhook, err
+		}
+
+		wh.Secret = hex.EncodeToString(secret)
+	}
+
+	// create context timeout to cancel process database when process to long
+	ctx, cancel := context.WithTimeout(c, ucase.contextTimeout)
+
+	defer cancel()
+
+	res, err := ucase.configRepo.AddWebhook(ctx, wh)
+	if err != nil {
+		return respWebhook, err
+	}
+
+	respWebhook.Status.Created = res
+	respWebhook.Webhook = wh
+
+	return respWebhook, nil
+}
+
+func (ucase *configurationUseCase) DeleteWebhook(c context.Context, webhookID int64) (*pb.ResponseWebhook, error) {
+	respWebhook := &pb.ResponseWebhook{
+		Status: &pb.ConfigurationStatus{Deleted: false},
+	}
+
+	ctx, cancel := context.WithTimeout(c, ucase.contextTimeout)
+
+	defer cancel()
+
+	res, err := ucase.configRepo.DeleteWebhook(ctx, webhookID)
+	if err != nil {
+		return respWebhook, err
+	}
+
+	respWebhook.Status.Deleted = res
+
+	return respWebhook, nil
+}
+
+// this function will return all of webhook without the secret
+func (ucase *configurationUseCase) GetWebhooks(c context.Context) (*pb.ResponseWebhook, error) {
+	ctx, cancel := context.WithTimeout(c, ucase.contextTimeout)
+
+	defer cancel()
+
+	webhooks, err := ucase.configRepo.GetWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, wh := range webhooks {
+		wh.Secret = ""
+	}
+
+	return &pb.ResponseWebhook{Webhooks: webhooks}, nil
+}
+
+// this function will return delivery of status, dead delivery is returned when status is empty
+func (ucase *configurationUseCase) GetWebhookDeliveries(c context.Context, status string) (*pb.ResponseWebhook, error) {
+	if status == "" {
+		status = api.WebhookDead
+	}
+
+	ctx, cancel := context.WithTimeout(c, ucase.contextTimeout)
+
+	defer cancel()
+
+	deliveries, err := ucase.configRepo.GetWebhookDeliveries(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ResponseWebhook{Deliveries: deliveries}, nil
+}
+
+// this function will make dead delivery pending again, then wake dispatcher to post it
+func (ucase *configurationUseCase) RedeliverWebhook(c context.Context, deliveryID int64) (*pb.ResponseWebhook, error) {
+	respWebhook := &pb.ResponseWebhook{
+		Status: &pb.ConfigurationStatus{Updated: false},
+	}
+
+	ctx, cancel := context.WithTimeout(c, ucase.contextTimeout)
+
+	defer cancel()
+
+	res, err := ucase.configRepo.RedeliverWebhookDelivery(ctx, deliveryID, time.Now().UnixNano()/int64(time.Millisecond))
+	if err != nil {
+		return respWebhook, err
+	}
+
+	respWebhook.Status.Updated = res
+
+	ucase.notifier.Notify(api.WebhookKey)
+
+	return respWebhook, nil
+}
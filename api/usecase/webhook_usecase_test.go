@@ -0,0 +1,82 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+	"github.com/muhammadhidayah/configuration-service/api/mocks"
+	"github.com/muhammadhidayah/configuration-service/api/notifier"
+	ucase "github.com/muhammadhidayah/configuration-service/api/usecase"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddWebhook(t *testing.T) {
+	t.Run("Secret is generated when empty", func(t *testing.T) {
+		mockConfigRepo := new(mocks.Repository)
+		mockConfigRepo.On("AddWebhook", mock.Anything, mock.AnythingOfType("*configuration.Webhook")).Return(true, nil).Once()
+
+		uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2)
+		res, err := uc.AddWebhook(context.TODO(), &pb.Webhook{Url: "https://example.com/hook", Topics: []string{api.TopicClientUpdated}})
+
+		assert.NoError(t, err)
+		assert.True(t, res.Status.Created)
+		assert.Len(t, res.Webhook.Secret, 64)
+		mockConfigRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid url or topic is rejected", func(t *testing.T) {
+		mockConfigRepo := new(mocks.Repository)
+		uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2)
+
+		for _, wh := range []*pb.Webhook{
+			{Url: "example.com/hook"},
+			{Url: "ftp://example.com/hook"},
+			{Url: "https://example.com/hook", Topics: []string{"config.client.renamed"}},
+		} {
+			res, err := uc.AddWebhook(context.TODO(), wh)
+			assert.True(t, errors.Is(err, api.ErrInvalidArgument), "expected ErrInvalidArgument, got %v", err)
+			assert.False(t, res.Status.Created)
+		}
+
+		mockConfigRepo.AssertNotCalled(t, "AddWebhook", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetWebhooks(t *testing.T) {
+	mockConfigRepo := new(mocks.Repository)
+	mockConfigRepo.On("GetWebhooks", mock.Anything).Return([]*pb.Webhook{{WebhookId: 1, Url: "https://example.com/hook", Secret: "s3cr3t"}}, nil).Once()
+
+	uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2)
+	res, err := uc.GetWebhooks(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Len(t, res.Webhooks, 1)
+	assert.Empty(t, res.Webhooks[0].Secret)
+	mockConfigRepo.AssertExpectations(t)
+}
+
+func TestRedeliverWebhook(t *testing.T) {
+	mockConfigRepo := new(mocks.Repository)
+	mockConfigRepo.On("RedeliverWebhookDelivery", mock.Anything, int64(3), mock.AnythingOfType("int64")).Return(true, nil).Once()
+
+	hub := notifier.NewHub()
+	wake, stop := hub.Subscribe(api.WebhookKey)
+	defer stop()
+
+	uc := ucase.NewConfigurationUsecase(mockConfigRepo, time.Second*2, ucase.WithNotifier(hub))
+	res, err := uc.RedeliverWebhook(context.TODO(), 3)
+
+	assert.NoError(t, err)
+	assert.True(t, res.Status.Updated)
+	select {
+	case <-wake:
+	default:
+		t.Error("dispatcher must be woken up")
+	}
+	mockConfigRepo.AssertExpectations(t)
+}
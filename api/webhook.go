@@ -0,0 +1,13 @@
+package api
+
+// status of pb.WebhookDelivery
+const (
+	WebhookPending   = "pending"
+	WebhookDelivered = "delivered"
+
+	// WebhookDead is delivery that failed every attempt, it is only delivered again by RedeliverWebhook
+	WebhookDead = "dead"
+)
+
+// WebhookKey is notified when delivery of webhook can be posted, it wake dispatcher of webhook
+const WebhookKey = "webhook"
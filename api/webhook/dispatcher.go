@@ -0,0 +1,230 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+)
+
+const (
+	defaultBatchSize   = 100
+	defaultMaxAttempts = 8
+	minBackoff         = 10 * time.Second
+	maxBackoff         = time.Hour
+
+	// claimTimeout is how long claimed delivery is kept from other dispatcher, it must be longer than posting the delivery
+	claimTimeout = time.Minute
+)
+
+// Dispatcher post pending delivery to url of the webhook. delivery that failed is retried with exponential backoff,
+// and become dead after max attempts. delivery is claimed before it is posted, so dispatcher of every instance can run
+type Dispatcher struct {
+	repo        api.Repository
+	client      *http.Client
+	maxAttempts int
+	backoff     func(attempts int) time.Duration
+	batchSize   int
+}
+
+// Option configure dispatcher created by NewDispatcher
+type Option func(*Dispatcher)
+
+// WithHTTPClient set client used to post delivery, by default client has 10 seconds timeout
+func WithHTTPClient(c *http.Client) Option {
+	return func(d *Dispatcher) {
+		d.client = c
+	}
+}
+
+// WithMaxAttempts set how many times delivery is attempted before it become dead
+func WithMaxAttempts(n int) Option {
+	return func(d *Dispatcher) {
+		if n > 0 {
+			d.maxAttempts = n
+		}
+	}
+}
+
+// WithBackoff set how long to wait before the next attempt after attempts failed
+func WithBackoff(fn func(attempts int) time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.backoff = fn
+	}
+}
+
+// NewDispatcher create dispatcher of delivery saved in repo
+func NewDispatcher(repo api.Repository, opts ...Option) *Dispatcher {
+	d := &Dispatcher{
+		repo:        repo,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		maxAttempts: defaultMaxAttempts,
+		backoff:     Backoff,
+		batchSize:   defaultBatchSize,
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// Backoff wait 10 seconds after the first failed attempt, and double it every attempt up to 1 hour
+func Backoff(attempts int) time.Duration {
+	wait := minBackoff
+	for i := 1; i < attempts && wait < maxBackoff; i++ {
+		wait *= 2
+	}
+
+	if wait > maxBackoff {
+		wait = maxBackoff
+	}
+
+	return wait
+}
+
+// Run post pending delivery every interval and every time wake receive value, until ctx is done
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration, wake <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			attempted, err := d.Flush(ctx)
+			if err != nil {
+				log.Printf("Could not dispatch webhook: %v", err)
+			}
+
+			if err != nil || attempted < d.batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush attempt one batch of delivery that is due, and return how many delivery is attempted
+func (d *Dispatcher) Flush(ctx context.Context) (int, error) {
+	now := time.Now()
+	pending, err := d.repo.PendingWebhookDeliveries(ctx, millis(now), d.batchSize)
+	if err != nil || len(pending) == 0 {
+		return 0, err
+	}
+
+	webhooks, err := d.repo.GetWebhooks(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	byID := make(map[int64]*pb.Webhook, len(webhooks))
+	for _, wh := range webhooks {
+		byID[wh.WebhookId] = wh
+	}
+
+	attempted := 0
+	for _, delivery := range pending {
+		claimed, err := d.repo.ClaimWebhookDelivery(ctx, delivery, millis(now.Add(d.claimTimeout())))
+		if err != nil {
+			return attempted, err
+		}
+
+		// dispatcher of other instance post it
+		if !claimed {
+			continue
+		}
+
+		wh, ok := byID[delivery.WebhookId]
+		if !ok {
+			// webhook is deleted, there is no url to post to
+			delivery.Status = api.WebhookDead
+			delivery.LastError = "Webhook not found"
+		} else {
+			d.attempt(ctx, wh, delivery, now)
+		}
+
+		if err := d.repo.UpdateWebhookDelivery(ctx, delivery); err != nil {
+			return attempted, err
+		}
+
+		attempted++
+	}
+
+	return attempted, nil
+}
+
+// claimTimeout return how long delivery is claimed, it is longer than timeout of the client
+func (d *Dispatcher) claimTimeout() time.Duration {
+	if timeout := 2 * d.client.Timeout; timeout > claimTimeout {
+		return timeout
+	}
+
+	return claimTimeout
+}
+
+// attempt post delivery once, then set status of delivery by the result
+func (d *Dispatcher) attempt(ctx context.Context, wh *pb.Webhook, delivery *pb.WebhookDelivery, now time.Time) {
+	delivery.Attempts++
+
+	err := d.post(ctx, wh, delivery)
+	switch {
+	case err == nil:
+		delivery.Status = api.WebhookDelivered
+		delivery.LastError = ""
+	case int(delivery.Attempts) >= d.maxAttempts:
+		delivery.Status = api.WebhookDead
+		delivery.LastError = err.Error()
+	default:
+		delivery.NextAttemptAt = millis(now.Add(d.backoff(int(delivery.Attempts))))
+		delivery.LastError = err.Error()
+	}
+}
+
+func (d *Dispatcher) post(ctx context.Context, wh *pb.Webhook, delivery *pb.WebhookDelivery) error {
+	body := []byte(delivery.Payload)
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequest(http.MethodPost, wh.Url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(wh.Secret, timestamp, body))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(EventHeader, delivery.Topic)
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.DeliveryId, 10))
+
+	resp, err := d.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	// body is read until the end, so connection can be reused
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("Webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func millis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
@@ -0,0 +1,114 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/muhammadhidayah/configuration-service/api"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+)
+
+type enqueuer struct {
+	repo     api.Repository
+	notifier api.Notifier
+	marshal  jsonpb.Marshaler
+}
+
+// NewEnqueuer return api.EventPublisher that save delivery of event for every webhook subscribing the topic,
+// then wake dispatcher through notifier. it is used by relay of outbox together with publisher of broker
+func NewEnqueuer(repo api.Repository, notifier api.Notifier) api.EventPublisher {
+	return &enqueuer{
+		repo:     repo,
+		notifier: notifier,
+		marshal:  jsonpb.Marshaler{OrigName: true},
+	}
+}
+
+func (e *enqueuer) Publish(ctx context.Context, topic string, event proto.Message) error {
+	webhooks, err := e.repo.GetWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	payload, err := e.marshal.MarshalToString(redact(event))
+	if err != nil {
+		return err
+	}
+
+	var eventID string
+	if ev, ok := event.(interface{ GetId() string }); ok {
+		eventID = ev.GetId()
+	}
+
+	// delivery of all webhook is saved together, so event is not saved twice for some webhook when relay retry it
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	enqueued := false
+	err = e.repo.WithinTx(ctx, func(repo api.Repository) error {
+		for _, wh := range webhooks {
+			if !Subscribed(wh, topic) {
+				continue
+			}
+
+			d := &pb.WebhookDelivery{
+				WebhookId:     wh.WebhookId,
+				EventId:       eventID,
+				Topic:         topic,
+				Payload:       payload,
+				NextAttemptAt: now,
+			}
+
+			if err := repo.AddWebhookDelivery(ctx, d); err != nil {
+				return err
+			}
+
+			enqueued = true
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if enqueued {
+		e.notifier.Notify(api.WebhookKey)
+	}
+
+	return nil
+}
+
+// redact return copy of event without password of configuration global, event saved before password is stripped
+// from the outbox may still carry it
+func redact(event proto.Message) proto.Message {
+	ev, ok := event.(*pb.ConfigurationGlobalEvent)
+	if !ok {
+		return event
+	}
+
+	ev = proto.Clone(ev).(*pb.ConfigurationGlobalEvent)
+	for _, cg := range []*pb.ConfigurationGlobal{ev.Before, ev.After} {
+		if cg != nil {
+			cg.Password = ""
+		}
+	}
+
+	return ev
+}
+
+// Subscribed return true when webhook subscribe topic, webhook without topics subscribe all of topic
+func Subscribed(wh *pb.Webhook, topic string) bool {
+	if len(wh.Topics) == 0 {
+		return true
+	}
+
+	for _, t := range wh.Topics {
+		if t == topic {
+			return true
+		}
+	}
+
+	return false
+}
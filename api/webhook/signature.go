@@ -0,0 +1,36 @@
+// Package webhook deliver event of configuration change as signed json POST to url of webhook
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// header of request posted to webhook
+const (
+	// SignatureHeader is "sha256=" followed by hex of HMAC-SHA256 of "<timestamp>.<body>" using secret of webhook
+	SignatureHeader = "X-Webhook-Signature"
+
+	// TimestampHeader is unix time in second when request is signed, receiver should reject old request
+	TimestampHeader = "X-Webhook-Timestamp"
+
+	EventHeader    = "X-Webhook-Event"
+	DeliveryHeader = "X-Webhook-Delivery"
+)
+
+// Sign return signature of body posted at timestamp, it is value of SignatureHeader
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify check signature of body posted at timestamp, it can be used by receiver written in go
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
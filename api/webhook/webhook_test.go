@@ -0,0 +1,273 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/muhammadhidayah/configuration-service/api"
+	"github.com/muhammadhidayah/configuration-service/api/notifier"
+	"github.com/muhammadhidayah/configuration-service/api/repository"
+	"github.com/muhammadhidayah/configuration-service/api/webhook"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receiver is webhook endpoint that verify signature and keep body of every request
+type receiver struct {
+	t      *testing.T
+	secret string
+
+	mu     sync.Mutex
+	status int
+	bodies []map[string]interface{}
+	topics []string
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	require.NoError(rc.t, err)
+
+	timestamp, err := strconv.ParseInt(r.Header.Get(webhook.TimestampHeader), 10, 64)
+	require.NoError(rc.t, err)
+	assert.True(rc.t, webhook.Verify(rc.secret, timestamp, body, r.Header.Get(webhook.SignatureHeader)), "signature must be valid")
+	assert.Equal(rc.t, "application/json", r.Header.Get("Content-Type"))
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.status != http.StatusOK {
+		w.WriteHeader(rc.status)
+		return
+	}
+
+	payload := map[string]interface{}{}
+	require.NoError(rc.t, json.Unmarshal(body, &payload))
+	rc.bodies = append(rc.bodies, payload)
+	rc.topics = append(rc.topics, r.Header.Get(webhook.EventHeader))
+}
+
+func (rc *receiver) setStatus(status int) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.status = status
+}
+
+func newClientEvent() *pb.ConfigurationClientEvent {
+	return &pb.ConfigurationClientEvent{
+		Id:     "e7b2bd1b-5fe9-4a55-9d83-5f7a47e2d1f6",
+		Topic:  api.TopicClientUpdated,
+		Before: &pb.ConfigurationClient{CompanySubsId: "012-031-234-542", ReportTitle: "Client Satu"},
+		After:  &pb.ConfigurationClient{CompanySubsId: "012-031-234-542", ReportTitle: "Client Updated"},
+	}
+}
+
+func TestSignature(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	signature := webhook.Sign("s3cr3t", 1571300000, body)
+
+	assert.Contains(t, signature, "sha256=")
+	assert.True(t, webhook.Verify("s3cr3t", 1571300000, body, signature))
+	assert.False(t, webhook.Verify("other", 1571300000, body, signature))
+	assert.False(t, webhook.Verify("s3cr3t", 1571300001, body, signature))
+	assert.False(t, webhook.Verify("s3cr3t", 1571300000, []byte(`{"id":"2"}`), signature))
+}
+
+func TestDeliver(t *testing.T) {
+	rc := &receiver{t: t, secret: "s3cr3t", status: http.StatusOK}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	repo := repository.NewMemoryConfiguration()
+	_, err := repo.AddWebhook(context.TODO(), &pb.Webhook{Url: srv.URL, Secret: "s3cr3t", Topics: []string{api.TopicClientUpdated}})
+	require.NoError(t, err)
+	_, err = repo.AddWebhook(context.TODO(), &pb.Webhook{Url: srv.URL, Secret: "other", Topics: []string{api.TopicGlobalActivated}})
+	require.NoError(t, err)
+
+	hub := notifier.NewHub()
+	wake, stop := hub.Subscribe(api.WebhookKey)
+	defer stop()
+
+	require.NoError(t, webhook.NewEnqueuer(repo, hub).Publish(context.TODO(), api.TopicClientUpdated, newClientEvent()))
+
+	select {
+	case <-wake:
+	default:
+		t.Fatal("dispatcher must be woken up")
+	}
+
+	// only webhook subscribing the topic get the delivery
+	attempted, err := webhook.NewDispatcher(repo).Flush(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+
+	require.Len(t, rc.bodies, 1)
+	assert.Equal(t, []string{api.TopicClientUpdated}, rc.topics)
+	assert.Equal(t, "e7b2bd1b-5fe9-4a55-9d83-5f7a47e2d1f6", rc.bodies[0]["id"])
+	assert.Equal(t, "Client Updated", rc.bodies[0]["after"].(map[string]interface{})["report_title"])
+
+	delivered, err := repo.GetWebhookDeliveries(context.TODO(), api.WebhookDelivered)
+	require.NoError(t, err)
+	require.Len(t, delivered, 1)
+	assert.Equal(t, int32(1), delivered[0].Attempts)
+}
+
+func TestRetryAndRedeliver(t *testing.T) {
+	rc := &receiver{t: t, secret: "s3cr3t", status: http.StatusInternalServerError}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	repo := repository.NewMemoryConfiguration()
+	_, err := repo.AddWebhook(context.TODO(), &pb.Webhook{Url: srv.URL, Secret: "s3cr3t"})
+	require.NoError(t, err)
+	require.NoError(t, webhook.NewEnqueuer(repo, notifier.NewHub()).Publish(context.TODO(), api.TopicClientUpdated, newClientEvent()))
+
+	t.Run("Failed delivery wait for backoff", func(t *testing.T) {
+		dispatcher := webhook.NewDispatcher(repo, webhook.WithMaxAttempts(3))
+
+		attempted, err := dispatcher.Flush(context.TODO())
+		require.NoError(t, err)
+		assert.Equal(t, 1, attempted)
+
+		// the next attempt is 10 seconds later
+		attempted, err = dispatcher.Flush(context.TODO())
+		require.NoError(t, err)
+		assert.Equal(t, 0, attempted)
+
+		pending, err := repo.GetWebhookDeliveries(context.TODO(), api.WebhookPending)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, int32(1), pending[0].Attempts)
+		assert.Equal(t, "Webhook responded with status 500", pending[0].LastError)
+		assert.True(t, pending[0].NextAttemptAt > time.Now().UnixNano()/int64(time.Millisecond))
+	})
+
+	t.Run("Delivery become dead after max attempts", func(t *testing.T) {
+		dispatcher := webhook.NewDispatcher(repo, webhook.WithMaxAttempts(3), webhook.WithBackoff(func(int) time.Duration { return -time.Second }))
+
+		pending, err := repo.GetWebhookDeliveries(context.TODO(), api.WebhookPending)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		pending[0].NextAttemptAt = 0
+		require.NoError(t, repo.UpdateWebhookDelivery(context.TODO(), pending[0]))
+
+		for i := 0; i < 2; i++ {
+			_, err = dispatcher.Flush(context.TODO())
+			require.NoError(t, err)
+		}
+
+		dead, err := repo.GetWebhookDeliveries(context.TODO(), api.WebhookDead)
+		require.NoError(t, err)
+		require.Len(t, dead, 1)
+		assert.Equal(t, int32(3), dead[0].Attempts)
+	})
+
+	t.Run("Redelivered delivery is posted again", func(t *testing.T) {
+		rc.setStatus(http.StatusOK)
+
+		dead, err := repo.GetWebhookDeliveries(context.TODO(), api.WebhookDead)
+		require.NoError(t, err)
+		require.Len(t, dead, 1)
+
+		_, err = repo.RedeliverWebhookDelivery(context.TODO(), dead[0].DeliveryId, time.Now().UnixNano()/int64(time.Millisecond))
+		require.NoError(t, err)
+
+		attempted, err := webhook.NewDispatcher(repo).Flush(context.TODO())
+		require.NoError(t, err)
+		assert.Equal(t, 1, attempted)
+		assert.Len(t, rc.bodies, 1)
+
+		delivered, err := repo.GetWebhookDeliveries(context.TODO(), api.WebhookDelivered)
+		require.NoError(t, err)
+		assert.Len(t, delivered, 1)
+	})
+}
+
+// staleRepository return the same pending delivery to every dispatcher, like dispatcher of two instance reading at the same time
+type staleRepository struct {
+	api.Repository
+	pending []*pb.WebhookDelivery
+}
+
+func (repo *staleRepository) PendingWebhookDeliveries(ctx context.Context, now int64, limit int) ([]*pb.WebhookDelivery, error) {
+	pending := make([]*pb.WebhookDelivery, 0, len(repo.pending))
+	for _, d := range repo.pending {
+		pending = append(pending, proto.Clone(d).(*pb.WebhookDelivery))
+	}
+
+	return pending, nil
+}
+
+func TestDeliveryPostedOnce(t *testing.T) {
+	rc := &receiver{t: t, secret: "s3cr3t", status: http.StatusOK}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	repo := repository.NewMemoryConfiguration()
+	_, err := repo.AddWebhook(context.TODO(), &pb.Webhook{Url: srv.URL, Secret: "s3cr3t"})
+	require.NoError(t, err)
+	require.NoError(t, webhook.NewEnqueuer(repo, notifier.NewHub()).Publish(context.TODO(), api.TopicClientUpdated, newClientEvent()))
+
+	pending, err := repo.PendingWebhookDeliveries(context.TODO(), time.Now().UnixNano()/int64(time.Millisecond), 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+
+	stale := &staleRepository{Repository: repo, pending: pending}
+	attempted, err := webhook.NewDispatcher(stale).Flush(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+
+	// the delivery is already claimed by the first dispatcher
+	attempted, err = webhook.NewDispatcher(stale).Flush(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, 0, attempted)
+	assert.Len(t, rc.bodies, 1)
+}
+
+func TestPasswordIsRedacted(t *testing.T) {
+	repo := repository.NewMemoryConfiguration()
+	_, err := repo.AddWebhook(context.TODO(), &pb.Webhook{Url: "http://127.0.0.1:1", Secret: "s3cr3t"})
+	require.NoError(t, err)
+
+	ev := &pb.ConfigurationGlobalEvent{
+		Id:     "e7b2bd1b-5fe9-4a55-9d83-5f7a47e2d1f6",
+		Topic:  api.TopicGlobalUpdated,
+		Before: &pb.ConfigurationGlobal{ConfigGlobalId: 1, Password: "secret-before"},
+		After:  &pb.ConfigurationGlobal{ConfigGlobalId: 1, Password: "secret-after"},
+	}
+	require.NoError(t, webhook.NewEnqueuer(repo, notifier.NewHub()).Publish(context.TODO(), api.TopicGlobalUpdated, ev))
+
+	pending, err := repo.GetWebhookDeliveries(context.TODO(), api.WebhookPending)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.NotContains(t, pending[0].Payload, "secret-")
+	assert.Equal(t, "secret-after", ev.After.Password, "event of caller must not be changed")
+}
+
+func TestDeletedWebhook(t *testing.T) {
+	repo := repository.NewMemoryConfiguration()
+	wh := &pb.Webhook{Url: "http://127.0.0.1:1", Secret: "s3cr3t"}
+	_, err := repo.AddWebhook(context.TODO(), wh)
+	require.NoError(t, err)
+	require.NoError(t, webhook.NewEnqueuer(repo, notifier.NewHub()).Publish(context.TODO(), api.TopicClientUpdated, newClientEvent()))
+
+	_, err = repo.DeleteWebhook(context.TODO(), wh.WebhookId)
+	require.NoError(t, err)
+
+	_, err = webhook.NewDispatcher(repo).Flush(context.TODO())
+	require.NoError(t, err)
+
+	dead, err := repo.GetWebhookDeliveries(context.TODO(), api.WebhookDead)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, "Webhook not found", dead[0].LastError)
+}
@@ -0,0 +1,485 @@
+// Package client keep configuration of one company_subs_id and the active configuration global in memory.
+// the configuration is refreshed in background, and saved to disk to be used when configuration-service is unreachable
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	mclient "github.com/micro/go-micro/client"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+)
+
+const (
+	// defaultWatchTimeout is how long watch stream is kept open before it is opened again
+	defaultWatchTimeout = 10 * time.Minute
+
+	// minReopenWait is the first wait before watch that failed to open is opened again, it is doubled up to refresh interval
+	minReopenWait = 100 * time.Millisecond
+)
+
+// ErrNoConfiguration is returned by Start when configuration cannot be read from service nor from snapshot
+var ErrNoConfiguration = errors.New("No configuration from service or snapshot")
+
+// Option change behavior of Client
+type Option func(*Client)
+
+// WithRefreshInterval set how often configuration is read again, or the longest wait before watch that failed is reopened. default is 30 seconds
+func WithRefreshInterval(interval time.Duration) Option {
+	return func(c *Client) {
+		if interval > 0 {
+			c.interval = interval
+		}
+	}
+}
+
+// WithWatch keep WatchConfigurationClient and WatchConfigurationGlobalActive stream open instead of polling
+func WithWatch() Option {
+	return func(c *Client) {
+		c.watch = true
+	}
+}
+
+// WithWatchTimeout set how long watch stream is kept open. go-micro end the stream after request timeout, 5 seconds by default,
+// so the stream is opened with longer request timeout, closed by client after this timeout and opened again right away. default is 10 minutes
+func WithWatchTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		if timeout > 0 {
+			c.watchTimeout = timeout
+		}
+	}
+}
+
+// WithSnapshotFile save every configuration read from service to path, and load it when service is unreachable on Start
+func WithSnapshotFile(path string) Option {
+	return func(c *Client) {
+		c.snapshotPath = path
+	}
+}
+
+// WithOnChange set function called after configuration changed. it is called from background goroutine
+func WithOnChange(fn func(*Client)) Option {
+	return func(c *Client) {
+		c.onChange = fn
+	}
+}
+
+// SMTP is smtp setting of configuration global
+type SMTP struct {
+	Server   string
+	Port     int64
+	SSL      bool
+	Auth     bool
+	Username string
+	Password string
+}
+
+// Client keep configuration of company_subs_id in memory. it is safe to be used by many goroutine
+type Client struct {
+	service      pb.ConfigurationService
+	subsID       string
+	interval     time.Duration
+	watch        bool
+	watchTimeout time.Duration
+	snapshotPath string
+	onChange     func(*Client)
+
+	mu         sync.RWMutex
+	client     *pb.ConfigurationClient
+	global     *pb.ConfigurationGlobal
+	clientHash string
+	globalHash string
+	updatedAt  time.Time
+	stale      bool
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// New create client of configuration of subsID. configuration is not read until Start
+func New(service pb.ConfigurationService, subsID string, opts ...Option) *Client {
+	c := &Client{
+		service:      service,
+		subsID:       subsID,
+		interval:     30 * time.Second,
+		watchTimeout: defaultWatchTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Start read configuration, then keep it up to date in background until Close. when service is unreachable,
+// configuration is loaded from snapshot and marked stale, then it is replaced after the service can be reached
+func (c *Client) Start(ctx context.Context) error {
+	if err := c.Refresh(ctx); err != nil {
+		if c.snapshotPath == "" {
+			return fmt.Errorf("%w: %v", ErrNoConfiguration, err)
+		}
+
+		snap, snapErr := loadSnapshot(c.snapshotPath)
+		if snapErr != nil {
+			return fmt.Errorf("%w: %v, snapshot: %v", ErrNoConfiguration, err, snapErr)
+		}
+
+		c.mu.Lock()
+		c.client, c.global = snap.client, snap.global
+		c.clientHash, c.globalHash = snap.ClientHash, snap.GlobalHash
+		c.updatedAt = snap.SavedAt
+		c.stale = true
+		c.mu.Unlock()
+
+		log.Printf("Configuration service unreachable, using snapshot of %s: %v", snap.SavedAt.Format(time.RFC3339), err)
+	}
+
+	bg, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.done = make(chan struct{})
+
+	go func() {
+		defer close(c.done)
+
+		if c.watch {
+			c.runWatch(bg)
+			return
+		}
+
+		c.runRefresh(bg)
+	}()
+
+	return nil
+}
+
+// Close stop background refresh
+func (c *Client) Close() error {
+	if c.cancel != nil {
+		c.cancel()
+		<-c.done
+	}
+
+	return nil
+}
+
+// Refresh read configuration now. configuration that is not modified since the last read is not sent by service
+func (c *Client) Refresh(ctx context.Context) error {
+	c.mu.RLock()
+	clientHash, globalHash := c.clientHash, c.globalHash
+	c.mu.RUnlock()
+
+	resClient, err := c.service.GetConfigurationClientBySubs(ctx, &pb.RequestConfigCient{
+		Configclient: &pb.ConfigurationClient{CompanySubsId: c.subsID},
+		ContentHash:  clientHash,
+	})
+	if err != nil {
+		c.markStale()
+		return err
+	}
+
+	resGlobal, err := c.service.GetConfigurationGlobalActive(ctx, &pb.RequestConfigGlobal{ContentHash: globalHash})
+	if err != nil {
+		c.markStale()
+		return err
+	}
+
+	c.update(func() bool {
+		changed := false
+		if !resClient.GetNotModified() {
+			changed = c.setClient(resClient.GetConfigclient(), resClient.GetContentHash()) || changed
+		}
+
+		if !resGlobal.GetNotModified() {
+			changed = c.setGlobal(resGlobal.GetConfigglobal(), resGlobal.GetContentHash()) || changed
+		}
+
+		return changed
+	})
+
+	return nil
+}
+
+func (c *Client) runRefresh(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := c.Refresh(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Could not refresh configuration: %v", err)
+		}
+	}
+}
+
+// runWatch keep both stream open. stream that ended after configuration is received is opened again right away,
+// stream that failed before it is opened again with backoff up to interval
+func (c *Client) runWatch(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		c.reopen(ctx, c.watchClient)
+	}()
+
+	go func() {
+		defer wg.Done()
+		c.reopen(ctx, c.watchGlobal)
+	}()
+
+	wg.Wait()
+}
+
+func (c *Client) reopen(ctx context.Context, watch func(context.Context) (bool, error)) {
+	wait := time.Duration(0)
+	for {
+		received, err := watch(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		// stream ended by request timeout, configuration is still up to date
+		if received {
+			wait = 0
+			continue
+		}
+
+		c.markStale()
+		log.Printf("Configuration watch closed: %v", err)
+
+		wait = reopenWait(wait, c.interval)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// reopenWait return the next wait after wait, it start from minReopenWait and is doubled up to max
+func reopenWait(wait, max time.Duration) time.Duration {
+	if wait < minReopenWait {
+		wait = minReopenWait
+	} else {
+		wait *= 2
+	}
+
+	if wait > max {
+		wait = max
+	}
+
+	return wait
+}
+
+// openWatch return context of one watch stream, it is done after watch timeout. the service is asked to keep the stream
+// a half longer, so the stream is ended by client and configuration is not lost while the service end it
+func (c *Client) openWatch(ctx context.Context) (context.Context, context.CancelFunc, mclient.CallOption) {
+	ctx, cancel := context.WithTimeout(ctx, c.watchTimeout)
+	return ctx, cancel, mclient.WithRequestTimeout(c.watchTimeout + c.watchTimeout/2)
+}
+
+// closeOnDone close stream when ctx done, Recv of go-micro stream does not return when its context done
+func closeOnDone(ctx context.Context, stream io.Closer) {
+	go func() {
+		<-ctx.Done()
+		stream.Close()
+	}()
+}
+
+// watchClient and watchGlobal return true when at least one configuration is received before the stream ended
+func (c *Client) watchClient(ctx context.Context) (bool, error) {
+	ctx, cancel, timeout := c.openWatch(ctx)
+	defer cancel()
+
+	stream, err := c.service.WatchConfigurationClient(ctx, &pb.RequestConfigCient{Configclient: &pb.ConfigurationClient{CompanySubsId: c.subsID}}, timeout)
+	if err != nil {
+		return false, err
+	}
+
+	closeOnDone(ctx, stream)
+
+	received := false
+	for {
+		res, err := stream.Recv()
+		if err != nil {
+			return received, err
+		}
+
+		received = true
+
+		c.update(func() bool {
+			return c.setClient(res.GetConfigclient(), res.GetContentHash())
+		})
+	}
+}
+
+func (c *Client) watchGlobal(ctx context.Context) (bool, error) {
+	ctx, cancel, timeout := c.openWatch(ctx)
+	defer cancel()
+
+	stream, err := c.service.WatchConfigurationGlobalActive(ctx, &pb.RequestConfigGlobal{}, timeout)
+	if err != nil {
+		return false, err
+	}
+
+	closeOnDone(ctx, stream)
+
+	received := false
+	for {
+		res, err := stream.Recv()
+		if err != nil {
+			return received, err
+		}
+
+		received = true
+
+		c.update(func() bool {
+			return c.setGlobal(res.GetConfigglobal(), res.GetContentHash())
+		})
+	}
+}
+
+// update call fn while holding lock, then save snapshot and call onChange when fn changed the configuration
+func (c *Client) update(fn func() bool) {
+	c.mu.Lock()
+	changed := fn()
+	c.updatedAt = time.Now()
+	c.stale = false
+	snap := &snapshot{client: c.client, global: c.global, ClientHash: c.clientHash, GlobalHash: c.globalHash, SavedAt: c.updatedAt}
+	c.mu.Unlock()
+
+	if !changed {
+		return
+	}
+
+	if c.snapshotPath != "" {
+		if err := saveSnapshot(c.snapshotPath, snap); err != nil {
+			log.Printf("Could not save configuration snapshot: %v", err)
+		}
+	}
+
+	if c.onChange != nil {
+		c.onChange(c)
+	}
+}
+
+// setClient and setGlobal must be called while holding lock
+func (c *Client) setClient(cc *pb.ConfigurationClient, hash string) bool {
+	if proto.Equal(c.client, cc) {
+		c.clientHash = hash
+		return false
+	}
+
+	c.client, c.clientHash = cc, hash
+	return true
+}
+
+func (c *Client) setGlobal(cg *pb.ConfigurationGlobal, hash string) bool {
+	if proto.Equal(c.global, cg) {
+		c.globalHash = hash
+		return false
+	}
+
+	c.global, c.globalHash = cg, hash
+	return true
+}
+
+func (c *Client) markStale() {
+	c.mu.Lock()
+	c.stale = true
+	c.mu.Unlock()
+}
+
+// ConfigurationClient return copy of configuration client, nil when the client not exists
+func (c *Client) ConfigurationClient() *pb.ConfigurationClient {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.client == nil {
+		return nil
+	}
+
+	return proto.Clone(c.client).(*pb.ConfigurationClient)
+}
+
+// ConfigurationGlobal return copy of active configuration global, nil when there is no configuration global
+func (c *Client) ConfigurationGlobal() *pb.ConfigurationGlobal {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.global == nil {
+		return nil
+	}
+
+	return proto.Clone(c.global).(*pb.ConfigurationGlobal)
+}
+
+func (c *Client) Appname() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.client.GetAppname()
+}
+
+func (c *Client) ReportTitle() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.client.GetReportTitle()
+}
+
+func (c *Client) MultipleLanguageID() int32 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.client.GetMultipleLanguageId()
+}
+
+func (c *Client) FooterText() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.global.GetFootertext()
+}
+
+func (c *Client) SMTP() SMTP {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return SMTP{
+		Server:   c.global.GetServerSmpt(),
+		Port:     c.global.GetPort(),
+		SSL:      c.global.GetSsl(),
+		Auth:     c.global.GetIsAuth(),
+		Username: c.global.GetUsername(),
+		Password: c.global.GetPassword(),
+	}
+}
+
+// Stale is true when configuration is loaded from snapshot, or the last refresh failed
+func (c *Client) Stale() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.stale
+}
+
+// UpdatedAt return time of the last successful read from service, or time of snapshot when it is loaded from snapshot
+func (c *Client) UpdatedAt() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.updatedAt
+}
@@ -0,0 +1,229 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	mclient "github.com/micro/go-micro/client"
+	"github.com/muhammadhidayah/configuration-service/client"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeService serve configuration that can be changed by test. method that is not used by client panic
+type fakeService struct {
+	pb.ConfigurationService
+
+	mu          sync.Mutex
+	cc          *pb.ConfigurationClient
+	cg          *pb.ConfigurationGlobal
+	unreachable bool
+	notModified int
+
+	clientStream chan *pb.ResponseConfigClient
+	globalStream chan *pb.ResponseConfigGlobal
+}
+
+func (s *fakeService) GetConfigurationClientBySubs(ctx context.Context, in *pb.RequestConfigCient, opts ...mclient.CallOption) (*pb.ResponseConfigClient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.unreachable {
+		return nil, errors.New("service unreachable")
+	}
+
+	hash := s.cc.GetReportTitle()
+	if in.GetContentHash() == hash {
+		s.notModified++
+		return &pb.ResponseConfigClient{ContentHash: hash, NotModified: true}, nil
+	}
+
+	return &pb.ResponseConfigClient{Configclient: s.cc, ContentHash: hash}, nil
+}
+
+func (s *fakeService) GetConfigurationGlobalActive(ctx context.Context, in *pb.RequestConfigGlobal, opts ...mclient.CallOption) (*pb.ResponseConfigGlobal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.unreachable {
+		return nil, errors.New("service unreachable")
+	}
+
+	return &pb.ResponseConfigGlobal{Configglobal: s.cg, ContentHash: s.cg.GetServerSmpt()}, nil
+}
+
+func (s *fakeService) WatchConfigurationClient(ctx context.Context, in *pb.RequestConfigCient, opts ...mclient.CallOption) (pb.ConfigurationService_WatchConfigurationClientService, error) {
+	return &fakeClientStream{ctx: ctx, ch: s.clientStream}, nil
+}
+
+func (s *fakeService) WatchConfigurationGlobalActive(ctx context.Context, in *pb.RequestConfigGlobal, opts ...mclient.CallOption) (pb.ConfigurationService_WatchConfigurationGlobalActiveService, error) {
+	return &fakeGlobalStream{ctx: ctx, ch: s.globalStream}, nil
+}
+
+func (s *fakeService) set(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn()
+}
+
+type fakeClientStream struct {
+	pb.ConfigurationService_WatchConfigurationClientService
+	ctx context.Context
+	ch  chan *pb.ResponseConfigClient
+}
+
+func (s *fakeClientStream) Recv() (*pb.ResponseConfigClient, error) {
+	select {
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	case res, ok := <-s.ch:
+		if !ok {
+			return nil, io.EOF
+		}
+		return res, nil
+	}
+}
+
+func (s *fakeClientStream) Close() error { return nil }
+
+type fakeGlobalStream struct {
+	pb.ConfigurationService_WatchConfigurationGlobalActiveService
+	ctx context.Context
+	ch  chan *pb.ResponseConfigGlobal
+}
+
+func (s *fakeGlobalStream) Recv() (*pb.ResponseConfigGlobal, error) {
+	select {
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	case res := <-s.ch:
+		return res, nil
+	}
+}
+
+func (s *fakeGlobalStream) Close() error { return nil }
+
+func newFakeService() *fakeService {
+	return &fakeService{
+		cc: &pb.ConfigurationClient{Appname: "client1.inactsoft.com", ReportTitle: "Client Satu", CompanySubsId: "012-031-234-542", MultipleLanguageId: 2},
+		cg: &pb.ConfigurationGlobal{Footertext: "Technical support", ServerSmpt: "mail.google.com", Port: 465, Ssl: true, IsActive: true},
+
+		clientStream: make(chan *pb.ResponseConfigClient),
+		globalStream: make(chan *pb.ResponseConfigGlobal),
+	}
+}
+
+func tempSnapshot(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "configuration-client")
+	require.NoError(t, err)
+
+	return filepath.Join(dir, "snapshot.json"), func() { os.RemoveAll(dir) }
+}
+
+func TestClientRefresh(t *testing.T) {
+	svc := newFakeService()
+	changed := make(chan struct{}, 1)
+
+	c := client.New(svc, "012-031-234-542", client.WithRefreshInterval(10*time.Millisecond), client.WithOnChange(func(*client.Client) {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}))
+	require.NoError(t, c.Start(context.TODO()))
+	defer c.Close()
+
+	assert.Equal(t, "Client Satu", c.ReportTitle())
+	assert.Equal(t, "client1.inactsoft.com", c.Appname())
+	assert.Equal(t, int32(2), c.MultipleLanguageID())
+	assert.Equal(t, "Technical support", c.FooterText())
+	assert.Equal(t, client.SMTP{Server: "mail.google.com", Port: 465, SSL: true}, c.SMTP())
+	assert.False(t, c.Stale())
+
+	// configuration read by Start is a change too
+	<-changed
+
+	svc.set(func() { svc.cc = &pb.ConfigurationClient{ReportTitle: "Client Dua", CompanySubsId: "012-031-234-542"} })
+
+	select {
+	case <-changed:
+	case <-time.After(time.Second):
+		t.Fatal("configuration not refreshed")
+	}
+
+	assert.Equal(t, "Client Dua", c.ReportTitle())
+
+	// unchanged configuration is answered with not modified
+	assert.Eventually(t, func() bool {
+		svc.mu.Lock()
+		defer svc.mu.Unlock()
+		return svc.notModified > 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestClientSnapshot(t *testing.T) {
+	path, cleanup := tempSnapshot(t)
+	defer cleanup()
+
+	t.Run("Configuration is saved to snapshot", func(t *testing.T) {
+		c := client.New(newFakeService(), "012-031-234-542", client.WithSnapshotFile(path), client.WithRefreshInterval(time.Hour))
+		require.NoError(t, c.Start(context.TODO()))
+		c.Close()
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+
+	t.Run("Snapshot is used when service unreachable, then replaced when service is back", func(t *testing.T) {
+		svc := newFakeService()
+		svc.unreachable = true
+		svc.cc = &pb.ConfigurationClient{ReportTitle: "Client Dua", CompanySubsId: "012-031-234-542"}
+
+		c := client.New(svc, "012-031-234-542", client.WithSnapshotFile(path), client.WithRefreshInterval(10*time.Millisecond))
+		require.NoError(t, c.Start(context.TODO()))
+		defer c.Close()
+
+		assert.True(t, c.Stale())
+		assert.Equal(t, "Client Satu", c.ReportTitle())
+		assert.Equal(t, "mail.google.com", c.SMTP().Server)
+
+		svc.set(func() { svc.unreachable = false })
+
+		assert.Eventually(t, func() bool { return c.ReportTitle() == "Client Dua" && !c.Stale() }, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Start failed without service and snapshot", func(t *testing.T) {
+		svc := newFakeService()
+		svc.unreachable = true
+
+		c := client.New(svc, "012-031-234-542", client.WithSnapshotFile(path+".missing"))
+		err := c.Start(context.TODO())
+		assert.True(t, errors.Is(err, client.ErrNoConfiguration), "expected ErrNoConfiguration, got %v", err)
+	})
+}
+
+func TestClientWatch(t *testing.T) {
+	svc := newFakeService()
+
+	c := client.New(svc, "012-031-234-542", client.WithWatch(), client.WithRefreshInterval(10*time.Millisecond))
+	require.NoError(t, c.Start(context.TODO()))
+	defer c.Close()
+
+	svc.clientStream <- &pb.ResponseConfigClient{Configclient: &pb.ConfigurationClient{ReportTitle: "Client Dua"}}
+	svc.globalStream <- &pb.ResponseConfigGlobal{Configglobal: &pb.ConfigurationGlobal{ServerSmpt: "smtp.inactsoft.com"}}
+
+	assert.Eventually(t, func() bool { return c.ReportTitle() == "Client Dua" && c.SMTP().Server == "smtp.inactsoft.com" }, time.Second, 10*time.Millisecond)
+
+	// stream is opened again after closed
+	close(svc.clientStream)
+	assert.Eventually(t, c.Stale, time.Second, time.Millisecond)
+}
@@ -0,0 +1,66 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	bmemory "github.com/micro/go-micro/broker/memory"
+	mclient "github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/client/selector"
+	rmemory "github.com/micro/go-micro/registry/memory"
+	"github.com/micro/go-micro/server"
+	"github.com/micro/go-micro/transport"
+	"github.com/muhammadhidayah/configuration-service/api/delivery/microgrpc"
+	"github.com/muhammadhidayah/configuration-service/api/notifier"
+	"github.com/muhammadhidayah/configuration-service/api/repository"
+	"github.com/muhammadhidayah/configuration-service/api/usecase"
+	"github.com/muhammadhidayah/configuration-service/client"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestClientWatchOverMicro run client against the service through go-micro client and server, so stream is ended like in production
+func TestClientWatchOverMicro(t *testing.T) {
+	reg := rmemory.NewRegistry()
+	// stream of memory transport cannot be closed while it is receiving, so the default http transport is used
+	tr := transport.NewTransport()
+	br := bmemory.NewBroker()
+
+	uc := usecase.NewConfigurationUsecase(repository.NewMemoryConfiguration(), 2*time.Second, usecase.WithNotifier(notifier.NewHub()), usecase.WithWatchInterval(time.Hour))
+	cc := &pb.ConfigurationClient{Appname: "client1.inactsoft.com", ReportTitle: "Client Satu", CompanySubsId: "012-031-234-542"}
+	_, err := uc.AddConfigurationClient(context.TODO(), cc)
+	require.NoError(t, err)
+	_, err = uc.AddConfigurationGlobal(context.TODO(), &pb.ConfigurationGlobal{Footertext: "Technical support", ServerSmpt: "mail.google.com", Port: 465, Ssl: true})
+	require.NoError(t, err)
+
+	srv := server.NewServer(server.Name("inact.srv.configuration"), server.Address("127.0.0.1:0"), server.Registry(reg), server.Transport(tr), server.Broker(br))
+	require.NoError(t, pb.RegisterConfigurationServiceHandler(srv, microgrpc.NewMicroGrpc(uc)))
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	// default request timeout is shorter than the watch, like 5 seconds default of go-micro against 10 minutes of the watch
+	cli := mclient.NewClient(mclient.Registry(reg), mclient.Transport(tr), mclient.Broker(br), mclient.Selector(selector.NewSelector(selector.Registry(reg))),
+		mclient.RequestTimeout(100*time.Millisecond))
+	svc := pb.NewConfigurationService("inact.srv.configuration", cli)
+
+	// stream is ended every 200 milliseconds, failed stream would wait an hour before it is opened again
+	c := client.New(svc, cc.CompanySubsId, client.WithWatch(), client.WithWatchTimeout(200*time.Millisecond), client.WithRefreshInterval(time.Hour))
+	require.NoError(t, c.Start(context.TODO()))
+	defer c.Close()
+
+	assert.Equal(t, "Client Satu", c.ReportTitle())
+
+	// stream is opened again several times, configuration is never stale meanwhile
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		require.False(t, c.Stale(), "configuration is stale while watch is opened again")
+	}
+
+	cc.ReportTitle = "Client Dua"
+	_, err = uc.UpdateConfigurationClientBySubs(context.TODO(), cc)
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool { return c.ReportTitle() == "Client Dua" }, time.Second, 10*time.Millisecond)
+	assert.False(t, c.Stale())
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+)
+
+// snapshot is the last known good configuration saved on disk. configuration is json of jsonpb, so it can be read by human
+type snapshot struct {
+	Client     json.RawMessage `json:"client,omitempty"`
+	Global     json.RawMessage `json:"global,omitempty"`
+	ClientHash string          `json:"client_hash,omitempty"`
+	GlobalHash string          `json:"global_hash,omitempty"`
+	SavedAt    time.Time       `json:"saved_at"`
+
+	client *pb.ConfigurationClient
+	global *pb.ConfigurationGlobal
+}
+
+// saveSnapshot write snapshot to temporary file then rename it to path, so path is never half written.
+// snapshot contain password of smtp, so it is only readable by owner
+func saveSnapshot(path string, snap *snapshot) error {
+	marshaler := jsonpb.Marshaler{OrigName: true}
+
+	if snap.client != nil {
+		s, err := marshaler.MarshalToString(snap.client)
+		if err != nil {
+			return err
+		}
+		snap.Client = json.RawMessage(s)
+	}
+
+	if snap.global != nil {
+		s, err := marshaler.MarshalToString(snap.global)
+		if err != nil {
+			return err
+		}
+		snap.Global = json.RawMessage(s)
+	}
+
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func loadSnapshot(path string) (*snapshot, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	snap := &snapshot{}
+	if err := json.Unmarshal(data, snap); err != nil {
+		return nil, err
+	}
+
+	if len(snap.Client) > 0 {
+		snap.client = &pb.ConfigurationClient{}
+		if err := jsonpb.Unmarshal(bytes.NewReader(snap.Client), snap.client); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(snap.Global) > 0 {
+		snap.global = &pb.ConfigurationGlobal{}
+		if err := jsonpb.Unmarshal(bytes.NewReader(snap.Global), snap.global); err != nil {
+			return nil, err
+		}
+	}
+
+	return snap, nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/muhammadhidayah/configuration-service/api/repository"
+)
+
+// healthHandler write health of every database pool as json. status is 503 when primary is not healthy
+func healthHandler(checker repository.HealthChecker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+		defer cancel()
+
+		health := checker.Health(ctx)
+
+		status := http.StatusOK
+		for _, pool := range health {
+			if pool.Primary && !pool.Healthy {
+				status = http.StatusServiceUnavailable
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(health)
+	})
+}
+
+// serveHealth serve endpoint /health on address in background
+func serveHealth(address string, checker repository.HealthChecker) {
+	mux := http.NewServeMux()
+	mux.Handle("/health", healthHandler(checker))
+
+	go func() {
+		if err := http.ListenAndServe(address, mux); err != nil {
+			log.Printf("health endpoint stopped: %v", err)
+		}
+	}()
+}
+
+// checkHealthEvery check health of checker every interval, so replica that is back online is routed again. the returned function stop the check
+func checkHealthEvery(checker repository.HealthChecker, interval time.Duration) func() {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), interval)
+				checker.Health(ctx)
+				cancel()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		ticker.Stop()
+		close(done)
+	}
+}
@@ -7,42 +7,221 @@ import (
 	"os"
 	"time"
 
+	"github.com/muhammadhidayah/configuration-service/api"
 	"github.com/muhammadhidayah/configuration-service/api/delivery/microgrpc"
+	"github.com/muhammadhidayah/configuration-service/api/event"
+	"github.com/muhammadhidayah/configuration-service/api/lint"
+	"github.com/muhammadhidayah/configuration-service/api/notifier"
 	"github.com/muhammadhidayah/configuration-service/api/repository"
 	"github.com/muhammadhidayah/configuration-service/api/usecase"
+	"github.com/muhammadhidayah/configuration-service/api/webhook"
 	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
 
 	_ "github.com/lib/pq"
+	"github.com/micro/cli"
 	"github.com/micro/go-micro"
 )
 
-func createConnection() (*sql.DB, error) {
+// pgDSN build DSN of postgres from environment
+func pgDSN() string {
 	host := os.Getenv("DB_HOST")
 	user := os.Getenv("DB_USER")
 	DBName := os.Getenv("DB_NAME")
 	password := os.Getenv("DB_PASSWORD")
 
-	dbinfo := fmt.Sprintf("host=%s user=%s password=%s dbname=%s sslmode=disable", host, user, password, DBName)
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s sslmode=disable", host, user, password, DBName)
+}
 
-	return sql.Open("postgres", dbinfo)
+func createConnection() (*sql.DB, error) {
+	return sql.Open("postgres", pgDSN())
 }
 
 func main() {
-	db, err := createConnection()
-	if err != nil {
-		log.Fatalf(fmt.Sprintf("Could not connect to DB: %v", err))
+	// command migrate run without starting the service
+	// database of command migrate is chosen by environment STORE, SQLITE_PATH and MYSQL_DSN
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		db, migrator, err := openDatabase(getEnv("STORE", "postgres"), getEnv("SQLITE_PATH", "configuration.db"), os.Getenv("MYSQL_DSN"))
+		if err != nil {
+			log.Fatalf(fmt.Sprintf("Could not connect to DB: %v", err))
+		}
+
+		defer db.Close()
+
+		if err := runMigrate(migrator, os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
+	// command repair-clients soft delete duplicate client, so migration of unique company_subs_id can be applied
+	if len(os.Args) > 1 && os.Args[1] == "repair-clients" {
+		store := getEnv("STORE", "postgres")
+		db, _, err := openDatabase(store, getEnv("SQLITE_PATH", "configuration.db"), os.Getenv("MYSQL_DSN"))
+		if err != nil {
+			log.Fatalf(fmt.Sprintf("Could not connect to DB: %v", err))
+		}
+
+		defer db.Close()
+
+		if err := runRepairClients(sqlRepository(store, db), os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+
+		return
 	}
 
-	defer db.Close()
+	var repo api.Repository
+	var closeRepo func() error
+	var watchInterval time.Duration
+	linter := lint.New(lint.DefaultRules()...)
+
+	// hub wake stream of WatchConfiguration when configuration changed
+	hub := notifier.NewHub()
 
 	srv := micro.NewService(
 		micro.Name("inact.srv.configuration"),
+		// request with metadata X-Read-Primary read from primary database instead of replica
+		micro.WrapHandler(microgrpc.ReadPrimaryWrapper),
+		micro.Flags(
+			cli.StringFlag{
+				Name:   "store",
+				EnvVar: "STORE",
+				Value:  "postgres",
+				Usage:  "Backend of configuration data: postgres, mysql, sqlite or memory",
+			},
+			cli.StringFlag{
+				Name:   "sqlite_path",
+				EnvVar: "SQLITE_PATH",
+				Value:  "configuration.db",
+				Usage:  "Path of sqlite database file, used when store is sqlite",
+			},
+			cli.StringFlag{
+				Name:   "mysql_dsn",
+				EnvVar: "MYSQL_DSN",
+				Usage:  "DSN of mysql database, e.g. user:password@tcp(host:3306)/dbname?parseTime=true. used when store is mysql",
+			},
+			cli.StringSliceFlag{
+				Name:   "pg_replica_dsn",
+				EnvVar: "PG_REPLICA_DSN",
+				Usage:  "DSN of postgres read replica, can be repeated (comma separated in environment). used when store is postgres",
+			},
+			cli.StringFlag{
+				Name:   "health_address",
+				EnvVar: "HEALTH_ADDRESS",
+				Usage:  "Address of http endpoint /health that report health of database pools, e.g. :8081. disabled when empty",
+			},
+			cli.DurationFlag{
+				Name:   "cache_ttl",
+				EnvVar: "CACHE_TTL",
+				Value:  10 * time.Second,
+				Usage:  "How long lookup of client by subs and global is cached, 0 disable the cache",
+			},
+			cli.DurationFlag{
+				Name:   "watch_interval",
+				EnvVar: "WATCH_INTERVAL",
+				Value:  30 * time.Second,
+				Usage:  "How often stream of WatchConfiguration read again when no change notified",
+			},
+			cli.BoolTFlag{
+				Name:   "outbox_relay",
+				EnvVar: "OUTBOX_RELAY",
+				Usage:  "Publish event of outbox to broker and webhook, only one instance that hold lock of outbox publish at a time",
+			},
+			cli.DurationFlag{
+				Name:   "outbox_interval",
+				EnvVar: "OUTBOX_INTERVAL",
+				Value:  time.Second,
+				Usage:  "How often relay read outbox, event written by this instance is published without waiting it",
+			},
+			cli.IntFlag{
+				Name:   "outbox_max_attempts",
+				EnvVar: "OUTBOX_MAX_ATTEMPTS",
+				Value:  20,
+				Usage:  "How many times event of outbox is attempted before it become dead",
+			},
+			cli.IntFlag{
+				Name:   "webhook_max_attempts",
+				EnvVar: "WEBHOOK_MAX_ATTEMPTS",
+				Value:  8,
+				Usage:  "How many times delivery of webhook is attempted before it become dead",
+			},
+			cli.StringFlag{
+				Name:   "environment",
+				EnvVar: "ENVIRONMENT",
+				Value:  "development",
+				Usage:  "Environment of the service, production check configuration global with rules of production before it is activated",
+			},
+			cli.BoolFlag{
+				Name:   "auto_migrate",
+				EnvVar: "AUTO_MIGRATE",
+				Usage:  "Apply pending database migration before the service started",
+			},
+		),
+	)
+
+	srv.Init(
+		micro.Action(func(c *cli.Context) {
+			var err error
+			watchInterval = c.Duration("watch_interval")
+			if c.String("environment") == "production" {
+				linter = lint.New(lint.ProductionRules()...)
+			}
+			repo, closeRepo, err = createRepository(c)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			// event is saved in outbox with the change, and relay publish it to broker of the service
+			// webhook delivery of the event is saved by relay, then posted by dispatcher
+			if c.BoolT("outbox_relay") {
+				publisher := event.NewMultiPublisher(event.NewMicroPublisher(srv.Client()), webhook.NewEnqueuer(repo, hub))
+				closeRelay := startRelay(repo.(api.Outbox), publisher, hub, c.Duration("outbox_interval"), c.Int("outbox_max_attempts"))
+				closeDispatcher := startDispatcher(repo, hub, c.Duration("outbox_interval"), c.Int("webhook_max_attempts"))
+				closeStore := closeRepo
+				closeRepo = func() error {
+					closeRelay()
+					closeDispatcher()
+					return closeStore()
+				}
+			}
+
+			if checker, ok := repo.(repository.HealthChecker); ok && c.String("health_address") != "" {
+				serveHealth(c.String("health_address"), checker)
+			}
+
+			invalidator := changeInvalidator{notifier: hub}
+			if ttl := c.Duration("cache_ttl"); ttl > 0 {
+				repo = repository.NewCachedConfiguration(repo, ttl)
+				invalidator.cache = repo.(repository.CacheInvalidator)
+			}
+
+			// write of other instance is notified by postgres, so cache is evicted without waiting the ttl
+			// and watcher of this instance get the change immediately
+			if c.String("store") == "postgres" {
+				listener, err := repository.NewPgInvalidationListener(pgDSN(), invalidator)
+				if err != nil {
+					log.Fatalf(fmt.Sprintf("Could not listen DB notification: %v", err))
+				}
+
+				closeStore := closeRepo
+				closeRepo = func() error {
+					listener.Close()
+					return closeStore()
+				}
+			}
+		}),
 	)
 
-	srv.Init()
+	defer closeRepo()
 
-	repo := repository.NewPgConfiguration(db)
-	ucase := usecase.NewConfigurationUsecase(repo, time.Second*5)
+	ucase := usecase.NewConfigurationUsecase(repo, time.Second*5,
+		usecase.WithNotifier(hub),
+		usecase.WithWatchInterval(watchInterval),
+		usecase.WithLinter(linter),
+		// every change record event in outbox, so other service can subscribe it
+		usecase.WithOutbox(),
+	)
 	handler := microgrpc.NewMicroGrpc(ucase)
 	pb.RegisterConfigurationServiceHandler(srv.Server(), handler)
 
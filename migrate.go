@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/muhammadhidayah/configuration-service/migration"
+)
+
+const migrateUsage = "usage: configuration-service migrate up|down|status"
+
+// runMigrate handle command `configuration-service migrate up|down|status`
+func runMigrate(migrator *migration.Migrator, args []string) error {
+	if len(args) != 1 {
+		return errors.New(migrateUsage)
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			fmt.Printf("applied %d_%s\n", m.Version, m.Name)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if len(applied) == 0 {
+			fmt.Println("no pending migration")
+		}
+	case "down":
+		reverted, err := migrator.Down(ctx)
+		if err != nil {
+			return err
+		}
+
+		if reverted == nil {
+			fmt.Println("no migration to revert")
+			return nil
+		}
+
+		fmt.Printf("reverted %d_%s\n", reverted.Version, reverted.Name)
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		applied := 0
+		for _, s := range status {
+			if s.Applied {
+				applied++
+			}
+		}
+
+		if applied == 0 {
+			fmt.Println("no migrations applied")
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, s := range status {
+			appliedAt := "pending"
+			if s.Applied {
+				appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+
+			fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+		}
+
+		return w.Flush()
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	return nil
+}
+
+// autoMigrate apply all pending migration when service started with flag auto_migrate
+func autoMigrate(migrator *migration.Migrator) error {
+	applied, err := migrator.Up(context.Background())
+	for _, m := range applied {
+		fmt.Printf("applied %d_%s\n", m.Version, m.Name)
+	}
+
+	return err
+}
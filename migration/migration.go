@@ -0,0 +1,269 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Migration is one numbered change of database schema. Up and Down contain statement that executed sequentially in one transaction
+type Migration struct {
+	Version int64
+	Name    string
+	Up      []string
+	Down    []string
+}
+
+// Status is state of one migration in database
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Dialect contain database specific statement used by Migrator
+type Dialect struct {
+	// Lock and Unlock prevent more than one process migrate the same database, empty when database need no lock
+	Lock   string
+	Unlock string
+
+	// Placeholder return bind variable for argument n, start from 1
+	Placeholder func(n int) string
+
+	// MigrationsTable return count of table schema_migrations, so status can be read without creating it
+	MigrationsTable string
+}
+
+// PostgresDialect hold postgres advisory lock while migrating, so only one instance apply migrations at the same time
+var PostgresDialect = Dialect{
+	Lock:        "SELECT pg_advisory_lock(7317100516)",
+	Unlock:      "SELECT pg_advisory_unlock(7317100516)",
+	Placeholder: func(n int) string { return fmt.Sprintf("$%d", n) },
+
+	MigrationsTable: "SELECT count(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = 'schema_migrations'",
+}
+
+// MySQLDialect hold named lock while migrating. DSN of mysql must contain parseTime=true to scan applied_at of schema_migrations
+var MySQLDialect = Dialect{
+	Lock:        "SELECT GET_LOCK('configuration-service-migrate', -1)",
+	Unlock:      "SELECT RELEASE_LOCK('configuration-service-migrate')",
+	Placeholder: func(n int) string { return "?" },
+
+	MigrationsTable: "SELECT count(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'schema_migrations'",
+}
+
+// SQLiteDialect need no lock, sqlite database file is only used by one process
+var SQLiteDialect = Dialect{
+	Placeholder: func(n int) string { return "?" },
+
+	MigrationsTable: "SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations'",
+}
+
+type Migrator struct {
+	db         *sql.DB
+	dialect    Dialect
+	migrations []Migration
+}
+
+// NewMigrator create migrator for db. migrations will be sorted by version
+func NewMigrator(db *sql.DB, dialect Dialect, migrations []Migration) *Migrator {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	return &Migrator{db: db, dialect: dialect, migrations: sorted}
+}
+
+// Up apply all migration that not applied yet, then return migrations has been applied
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	applied := make([]Migration, 0)
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		versions, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := versions[migration.Version]; ok {
+				continue
+			}
+
+			if err := m.apply(ctx, conn, migration.Up, m.bind("INSERT INTO schema_migrations (version, name, applied_at) VALUES (%s, %s, %s)", 3), migration.Version, migration.Name, time.Now().UTC()); err != nil {
+				return fmt.Errorf("migration %d_%s up: %v", migration.Version, migration.Name, err)
+			}
+
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down revert the latest applied migration, return nil migration when there is no migration applied
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	var reverted *Migration
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		versions, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := versions[migration.Version]; !ok {
+				continue
+			}
+
+			if err := m.apply(ctx, conn, migration.Down, m.bind("DELETE FROM schema_migrations WHERE version = %s", 1), migration.Version); err != nil {
+				return fmt.Errorf("migration %d_%s down: %v", migration.Version, migration.Name, err)
+			}
+
+			reverted = &migration
+			return nil
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Status return all known migration and whether it has been applied. it only read database, when table schema_migrations
+// not exists yet no migration is applied
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	var tables int
+	if err := conn.QueryRowContext(ctx, m.dialect.MigrationsTable).Scan(&tables); err != nil {
+		return nil, err
+	}
+
+	versions := make(map[int64]time.Time)
+	if tables > 0 {
+		if versions, err = m.readVersions(ctx, conn); err != nil {
+			return nil, err
+		}
+	}
+
+	status := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		appliedAt, ok := versions[migration.Version]
+		status = append(status, Status{Migration: migration, Applied: ok, AppliedAt: appliedAt})
+	}
+
+	return status, nil
+}
+
+// Pending return migrations that not applied yet
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]Migration, 0)
+	for _, s := range status {
+		if !s.Applied {
+			pending = append(pending, s.Migration)
+		}
+	}
+
+	return pending, nil
+}
+
+// withLock hold lock of dialect on one connection while fn running
+func (m *Migrator) withLock(ctx context.Context, fn func(*sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	if m.dialect.Lock != "" {
+		if _, err := conn.ExecContext(ctx, m.dialect.Lock); err != nil {
+			return err
+		}
+
+		defer conn.ExecContext(context.Background(), m.dialect.Unlock)
+	}
+
+	return fn(conn)
+}
+
+// bind fill format of query with n placeholder of dialect
+func (m *Migrator) bind(format string, n int) string {
+	placeholders := make([]interface{}, 0, n)
+	for i := 1; i <= n; i++ {
+		placeholders = append(placeholders, m.dialect.Placeholder(i))
+	}
+
+	return fmt.Sprintf(format, placeholders...)
+}
+
+// apply execute statements of migration and record it in schema_migrations in one transaction
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, statements []string, record string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// appliedVersions create table schema_migrations when not exists, then return applied version with time it applied
+func (m *Migrator) appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]time.Time, error) {
+	query := "CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, name varchar(255) NOT NULL, applied_at timestamp NOT NULL)"
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return nil, err
+	}
+
+	return m.readVersions(ctx, conn)
+}
+
+// readVersions return applied version of table schema_migrations with time it applied
+func (m *Migrator) readVersions(ctx context.Context, conn *sql.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	versions := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+
+		versions[version] = appliedAt
+	}
+
+	return versions, rows.Err()
+}
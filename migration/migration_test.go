@@ -0,0 +1,187 @@
+package migration_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlMock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/muhammadhidayah/configuration-service/migration"
+	"github.com/stretchr/testify/assert"
+)
+
+var testMigrations = []migration.Migration{
+	{
+		Version: 2,
+		Name:    "second",
+		Up:      []string{"ALTER TABLE first ADD COLUMN second int"},
+		Down:    []string{"ALTER TABLE first DROP COLUMN second"},
+	},
+	{
+		Version: 1,
+		Name:    "first",
+		Up:      []string{"CREATE TABLE first (id int)"},
+		Down:    []string{"DROP TABLE first"},
+	},
+}
+
+func expectSchemaMigrations(mock sqlMock.Sqlmock, versions ...int64) {
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS schema_migrations")).WillReturnResult(sqlMock.NewResult(0, 0))
+
+	rows := sqlMock.NewRows([]string{"version", "applied_at"})
+	for _, version := range versions {
+		rows.AddRow(version, time.Date(2019, 11, 1, 0, 0, 0, 0, time.UTC))
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version, applied_at FROM schema_migrations")).WillReturnRows(rows)
+}
+
+func TestUp(t *testing.T) {
+	db, mock, err := sqlMock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	defer db.Close()
+
+	t.Run("Apply pending migration by version order", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(migration.PostgresDialect.Lock)).WillReturnResult(sqlMock.NewResult(0, 0))
+		expectSchemaMigrations(mock)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE first (id int)")).WillReturnResult(sqlMock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO schema_migrations").WithArgs(int64(1), "first", sqlMock.AnyArg()).WillReturnResult(sqlMock.NewResult(0, 1))
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE first ADD COLUMN second int")).WillReturnResult(sqlMock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO schema_migrations").WithArgs(int64(2), "second", sqlMock.AnyArg()).WillReturnResult(sqlMock.NewResult(0, 1))
+		mock.ExpectCommit()
+		mock.ExpectExec(regexp.QuoteMeta(migration.PostgresDialect.Unlock)).WillReturnResult(sqlMock.NewResult(0, 0))
+
+		applied, err := migration.NewMigrator(db, migration.PostgresDialect, testMigrations).Up(context.TODO())
+		assert.NoError(t, err)
+		assert.Len(t, applied, 2)
+		assert.Equal(t, int64(1), applied[0].Version)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Skip applied migration and rollback failed migration", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(migration.PostgresDialect.Lock)).WillReturnResult(sqlMock.NewResult(0, 0))
+		expectSchemaMigrations(mock, 1)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE first ADD COLUMN second int")).WillReturnError(fmt.Errorf("column second already exists"))
+		mock.ExpectRollback()
+		mock.ExpectExec(regexp.QuoteMeta(migration.PostgresDialect.Unlock)).WillReturnResult(sqlMock.NewResult(0, 0))
+
+		applied, err := migration.NewMigrator(db, migration.PostgresDialect, testMigrations).Up(context.TODO())
+		assert.Error(t, err)
+		assert.Len(t, applied, 0)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDown(t *testing.T) {
+	db, mock, err := sqlMock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	defer db.Close()
+
+	t.Run("Revert only the latest applied migration", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(migration.PostgresDialect.Lock)).WillReturnResult(sqlMock.NewResult(0, 0))
+		expectSchemaMigrations(mock, 1, 2)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE first DROP COLUMN second")).WillReturnResult(sqlMock.NewResult(0, 0))
+		mock.ExpectExec("DELETE FROM schema_migrations").WithArgs(int64(2)).WillReturnResult(sqlMock.NewResult(0, 1))
+		mock.ExpectCommit()
+		mock.ExpectExec(regexp.QuoteMeta(migration.PostgresDialect.Unlock)).WillReturnResult(sqlMock.NewResult(0, 0))
+
+		reverted, err := migration.NewMigrator(db, migration.PostgresDialect, testMigrations).Down(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, "second", reverted.Name)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Nothing to revert", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(migration.PostgresDialect.Lock)).WillReturnResult(sqlMock.NewResult(0, 0))
+		expectSchemaMigrations(mock)
+		mock.ExpectExec(regexp.QuoteMeta(migration.PostgresDialect.Unlock)).WillReturnResult(sqlMock.NewResult(0, 0))
+
+		reverted, err := migration.NewMigrator(db, migration.PostgresDialect, testMigrations).Down(context.TODO())
+		assert.NoError(t, err)
+		assert.Nil(t, reverted)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestStatus(t *testing.T) {
+	db, mock, err := sqlMock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	defer db.Close()
+
+	t.Run("Status of applied migration", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(migration.PostgresDialect.MigrationsTable)).WillReturnRows(sqlMock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT version, applied_at FROM schema_migrations")).WillReturnRows(sqlMock.NewRows([]string{"version", "applied_at"}).AddRow(1, time.Date(2019, 11, 1, 0, 0, 0, 0, time.UTC)))
+
+		status, err := migration.NewMigrator(db, migration.PostgresDialect, testMigrations).Status(context.TODO())
+		assert.NoError(t, err)
+		assert.Len(t, status, 2)
+		assert.True(t, status[0].Applied)
+		assert.False(t, status[1].Applied)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Table schema_migrations is not created by status", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(migration.PostgresDialect.MigrationsTable)).WillReturnRows(sqlMock.NewRows([]string{"count"}).AddRow(0))
+
+		status, err := migration.NewMigrator(db, migration.PostgresDialect, testMigrations).Status(context.TODO())
+		assert.NoError(t, err)
+		assert.Len(t, status, 2)
+		assert.False(t, status[0].Applied)
+		assert.False(t, status[1].Applied)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMigrationsSequential(t *testing.T) {
+	for name, migrations := range map[string][]migration.Migration{"postgres": migration.Postgres, "sqlite": migration.SQLite, "mysql": migration.MySQL} {
+		for i, m := range migrations {
+			assert.Equal(t, int64(i+1), m.Version, "%s migration version must be sequential", name)
+			assert.NotEmpty(t, m.Name)
+			assert.NotEmpty(t, m.Up)
+			assert.NotEmpty(t, m.Down)
+		}
+	}
+}
+
+func TestSQLiteUp(t *testing.T) {
+	db, mock, err := sqlMock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	defer db.Close()
+
+	expectSchemaMigrations(mock)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE first (id int)")).WillReturnResult(sqlMock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)")).WithArgs(int64(1), "first", sqlMock.AnyArg()).WillReturnResult(sqlMock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	applied, err := migration.NewMigrator(db, migration.SQLiteDialect, testMigrations[1:]).Up(context.TODO())
+	assert.NoError(t, err)
+	assert.Len(t, applied, 1)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,148 @@
+package migration
+
+// MySQL is list of schema migration for mysql database. mysql commit DDL implicitly, so statement of one migration is not rolled back when the next one fail
+var MySQL = []Migration{
+	{
+		Version: 1,
+		Name:    "create_configuration_tables",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS configuration_client (
+				config_client_id bigint NOT NULL AUTO_INCREMENT,
+				config_client_uuid varchar(255) NULL,
+				multiple_language_id int NULL,
+				appname varchar(255) NULL,
+				report_title varchar(255) NULL,
+				company_subs_id varchar(255) NULL,
+				is_config_deleted int NOT NULL DEFAULT 0,
+				CONSTRAINT configuration_client_pk PRIMARY KEY (config_client_id)
+			)`,
+			// ssl is reserved word of mysql so it is quoted. mysql has no partial index, is_active_key is null for inactive row so only one row can be active
+			`CREATE TABLE IF NOT EXISTS configuration_global (
+				config_global_id int NOT NULL AUTO_INCREMENT,
+				footertext text NULL,
+				server_smpt text NULL,
+				` + "`ssl`" + ` boolean NULL,
+				port bigint NULL,
+				is_auth boolean NULL,
+				username varchar(255) NULL,
+				password varchar(255) NULL,
+				is_active boolean NOT NULL DEFAULT false,
+				is_active_key tinyint GENERATED ALWAYS AS (IF(is_active, 1, NULL)) STORED,
+				CONSTRAINT configuration_global_pk PRIMARY KEY (config_global_id),
+				CONSTRAINT configuration_global_is_active_uq UNIQUE (is_active_key)
+			)`,
+		},
+		Down: []string{
+			`DROP TABLE configuration_global`,
+			`DROP TABLE configuration_client`,
+		},
+	},
+	{
+		Version: 2,
+		Name:    "create_outbox",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS outbox (
+				id bigint NOT NULL AUTO_INCREMENT,
+				topic varchar(255) NOT NULL,
+				event_key varchar(255) NOT NULL,
+				payload longblob NOT NULL,
+				attempts int NOT NULL DEFAULT 0,
+				next_attempt_at datetime(6) NOT NULL,
+				last_error text NULL,
+				created_at datetime(6) NOT NULL,
+				CONSTRAINT outbox_pk PRIMARY KEY (id)
+			)`,
+		},
+		Down: []string{
+			`DROP TABLE outbox`,
+		},
+	},
+	{
+		Version: 3,
+		Name:    "create_webhook",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS webhook (
+				webhook_id bigint NOT NULL AUTO_INCREMENT,
+				url text NOT NULL,
+				secret varchar(255) NOT NULL,
+				topics text NOT NULL,
+				CONSTRAINT webhook_pk PRIMARY KEY (webhook_id)
+			)`,
+			`CREATE TABLE IF NOT EXISTS webhook_delivery (
+				delivery_id bigint NOT NULL AUTO_INCREMENT,
+				webhook_id bigint NOT NULL,
+				event_id varchar(255) NOT NULL,
+				topic varchar(255) NOT NULL,
+				payload longtext NOT NULL,
+				status varchar(16) NOT NULL,
+				attempts int NOT NULL DEFAULT 0,
+				next_attempt_at bigint NOT NULL,
+				last_error text NULL,
+				CONSTRAINT webhook_delivery_pk PRIMARY KEY (delivery_id),
+				INDEX webhook_delivery_status_idx (status, next_attempt_at)
+			)`,
+		},
+		Down: []string{
+			`DROP TABLE webhook_delivery`,
+			`DROP TABLE webhook`,
+		},
+	},
+	{
+		Version: 4,
+		Name:    "add_revision",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS configuration_revision (
+				id int NOT NULL,
+				revision bigint NOT NULL,
+				CONSTRAINT configuration_revision_pk PRIMARY KEY (id)
+			)`,
+			`INSERT INTO configuration_revision (id, revision) VALUES (1, 1)`,
+			`ALTER TABLE configuration_client ADD COLUMN revision bigint NOT NULL DEFAULT 1, ADD INDEX configuration_client_revision_idx (revision)`,
+			`ALTER TABLE configuration_global ADD COLUMN revision bigint NOT NULL DEFAULT 1, ADD INDEX configuration_global_revision_idx (revision)`,
+			`CREATE TABLE IF NOT EXISTS configuration_global_tombstone (
+				config_global_id int NOT NULL,
+				revision bigint NOT NULL,
+				CONSTRAINT configuration_global_tombstone_pk PRIMARY KEY (config_global_id)
+			)`,
+		},
+		Down: []string{
+			`DROP TABLE configuration_global_tombstone`,
+			`ALTER TABLE configuration_global DROP INDEX configuration_global_revision_idx, DROP COLUMN revision`,
+			`ALTER TABLE configuration_client DROP INDEX configuration_client_revision_idx, DROP COLUMN revision`,
+			`DROP TABLE configuration_revision`,
+		},
+	},
+	{
+		Version: 5,
+		Name:    "unique_active_company_subs_id",
+		Up: []string{
+			// like is_active_key, active_company_subs_id is null for deleted row so only row that is not deleted is unique
+			`ALTER TABLE configuration_client
+				ADD COLUMN active_company_subs_id varchar(255) GENERATED ALWAYS AS (IF(is_config_deleted = 0, company_subs_id, NULL)) STORED,
+				ADD CONSTRAINT configuration_client_company_subs_id_uq UNIQUE (active_company_subs_id)`,
+		},
+		Down: []string{
+			`ALTER TABLE configuration_client DROP INDEX configuration_client_company_subs_id_uq, DROP COLUMN active_company_subs_id`,
+		},
+	},
+	{
+		Version: 6,
+		Name:    "index_config_client_uuid",
+		Up: []string{
+			`ALTER TABLE configuration_client ADD INDEX configuration_client_uuid_idx (config_client_uuid)`,
+		},
+		Down: []string{
+			`ALTER TABLE configuration_client DROP INDEX configuration_client_uuid_idx`,
+		},
+	},
+	{
+		Version: 7,
+		Name:    "outbox_dead_letter",
+		Up: []string{
+			`ALTER TABLE outbox ADD COLUMN dead_at datetime(6) NULL, ADD INDEX outbox_event_key_idx (event_key, id)`,
+		},
+		Down: []string{
+			`ALTER TABLE outbox DROP INDEX outbox_event_key_idx, DROP COLUMN dead_at`,
+		},
+	},
+}
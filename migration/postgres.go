@@ -0,0 +1,212 @@
+package migration
+
+// Postgres is list of schema migration for postgres database. new migration must be appended with the next version, never edit migration that has been released
+var Postgres = []Migration{
+	{
+		Version: 1,
+		Name:    "create_configuration_tables",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS configuration_client (
+				config_client_id serial NOT NULL,
+				config_client_uuid varchar(255) NULL,
+				multiple_language_id int NULL,
+				appname varchar(255) NULL,
+				report_title varchar(255) NULL,
+				company_subs_id varchar(255) NULL,
+				is_config_deleted bool NULL,
+				CONSTRAINT configuration_client_pk PRIMARY KEY (config_client_id)
+			)`,
+			`CREATE TABLE IF NOT EXISTS configuration_global (
+				config_global_id serial NOT NULL,
+				footertext text NULL,
+				server_smpt text NULL,
+				ssl bool NULL,
+				port int8 NULL,
+				is_auth bool NULL,
+				username varchar(255) NULL,
+				"password" varchar(255) NULL,
+				is_active bool NULL,
+				CONSTRAINT configuration_global_pk PRIMARY KEY (config_global_id)
+			)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS configuration_global_is_active_uq ON configuration_global (is_active) WHERE is_active`,
+		},
+		Down: []string{
+			`DROP TABLE configuration_global`,
+			`DROP TABLE configuration_client`,
+		},
+	},
+	{
+		Version: 2,
+		Name:    "align_flags_with_repository",
+		Up: []string{
+			// is_config_deleted is int32 in proto and compared with 0 and 1 by repository
+			`ALTER TABLE configuration_client ALTER COLUMN is_config_deleted TYPE int USING (CASE WHEN is_config_deleted THEN 1 ELSE 0 END)`,
+			`ALTER TABLE configuration_client ALTER COLUMN is_config_deleted SET DEFAULT 0`,
+			`ALTER TABLE configuration_client ALTER COLUMN is_config_deleted SET NOT NULL`,
+			`UPDATE configuration_global SET is_active = false WHERE is_active IS NULL`,
+			`ALTER TABLE configuration_global ALTER COLUMN is_active SET DEFAULT false`,
+			`ALTER TABLE configuration_global ALTER COLUMN is_active SET NOT NULL`,
+		},
+		Down: []string{
+			`ALTER TABLE configuration_global ALTER COLUMN is_active DROP NOT NULL`,
+			`ALTER TABLE configuration_global ALTER COLUMN is_active DROP DEFAULT`,
+			`ALTER TABLE configuration_client ALTER COLUMN is_config_deleted DROP NOT NULL`,
+			`ALTER TABLE configuration_client ALTER COLUMN is_config_deleted DROP DEFAULT`,
+			`ALTER TABLE configuration_client ALTER COLUMN is_config_deleted TYPE bool USING (is_config_deleted <> 0)`,
+		},
+	},
+	{
+		Version: 3,
+		Name:    "notify_configuration_changed",
+		Up: []string{
+			// payload is key of changed data, the same as key of repository cache: client:<company_subs_id>, global:<config_global_id> and global:active
+			`CREATE OR REPLACE FUNCTION configuration_notify_changed() RETURNS trigger AS $$
+			BEGIN
+				IF TG_TABLE_NAME = 'configuration_client' THEN
+					IF TG_OP <> 'INSERT' THEN
+						PERFORM pg_notify('configuration_changed', 'client:' || coalesce(OLD.company_subs_id, ''));
+					END IF;
+					IF TG_OP <> 'DELETE' THEN
+						PERFORM pg_notify('configuration_changed', 'client:' || coalesce(NEW.company_subs_id, ''));
+					END IF;
+				ELSE
+					IF TG_OP <> 'INSERT' THEN
+						PERFORM pg_notify('configuration_changed', 'global:' || OLD.config_global_id);
+					END IF;
+					IF TG_OP <> 'DELETE' THEN
+						PERFORM pg_notify('configuration_changed', 'global:' || NEW.config_global_id);
+					END IF;
+					PERFORM pg_notify('configuration_changed', 'global:active');
+				END IF;
+				RETURN NULL;
+			END;
+			$$ LANGUAGE plpgsql`,
+			`CREATE TRIGGER configuration_client_notify_changed AFTER INSERT OR UPDATE OR DELETE ON configuration_client FOR EACH ROW EXECUTE PROCEDURE configuration_notify_changed()`,
+			`CREATE TRIGGER configuration_global_notify_changed AFTER INSERT OR UPDATE OR DELETE ON configuration_global FOR EACH ROW EXECUTE PROCEDURE configuration_notify_changed()`,
+		},
+		Down: []string{
+			`DROP TRIGGER IF EXISTS configuration_global_notify_changed ON configuration_global`,
+			`DROP TRIGGER IF EXISTS configuration_client_notify_changed ON configuration_client`,
+			`DROP FUNCTION IF EXISTS configuration_notify_changed()`,
+		},
+	},
+	{
+		Version: 4,
+		Name:    "create_outbox",
+		Up: []string{
+			// event is written with the change in one transaction, relay publish it to broker then delete it
+			`CREATE TABLE IF NOT EXISTS outbox (
+				id bigserial NOT NULL,
+				topic varchar(255) NOT NULL,
+				event_key varchar(255) NOT NULL,
+				payload bytea NOT NULL,
+				attempts int NOT NULL DEFAULT 0,
+				next_attempt_at timestamptz NOT NULL DEFAULT now(),
+				last_error text NULL,
+				created_at timestamptz NOT NULL DEFAULT now(),
+				CONSTRAINT outbox_pk PRIMARY KEY (id)
+			)`,
+		},
+		Down: []string{
+			`DROP TABLE outbox`,
+		},
+	},
+	{
+		Version: 5,
+		Name:    "create_webhook",
+		Up: []string{
+			// topics is comma separated topic, empty when all of topic is subscribed
+			`CREATE TABLE IF NOT EXISTS webhook (
+				webhook_id bigserial NOT NULL,
+				url text NOT NULL,
+				secret varchar(255) NOT NULL,
+				topics text NOT NULL DEFAULT '',
+				CONSTRAINT webhook_pk PRIMARY KEY (webhook_id)
+			)`,
+			// next_attempt_at is unix time in millisecond, the same as proto
+			`CREATE TABLE IF NOT EXISTS webhook_delivery (
+				delivery_id bigserial NOT NULL,
+				webhook_id bigint NOT NULL,
+				event_id varchar(255) NOT NULL,
+				topic varchar(255) NOT NULL,
+				payload text NOT NULL,
+				status varchar(16) NOT NULL,
+				attempts int NOT NULL DEFAULT 0,
+				next_attempt_at bigint NOT NULL,
+				last_error text NULL,
+				CONSTRAINT webhook_delivery_pk PRIMARY KEY (delivery_id)
+			)`,
+			`CREATE INDEX IF NOT EXISTS webhook_delivery_status_idx ON webhook_delivery (status, next_attempt_at)`,
+		},
+		Down: []string{
+			`DROP TABLE webhook_delivery`,
+			`DROP TABLE webhook`,
+		},
+	},
+	{
+		Version: 6,
+		Name:    "add_revision",
+		Up: []string{
+			// configuration_revision has one row, write lock it until commit so revision is committed in order
+			`CREATE TABLE IF NOT EXISTS configuration_revision (
+				id int NOT NULL,
+				revision bigint NOT NULL,
+				CONSTRAINT configuration_revision_pk PRIMARY KEY (id)
+			)`,
+			`INSERT INTO configuration_revision (id, revision) VALUES (1, 1)`,
+			// existing row is revision 1, so it is returned by sync from revision 0
+			`ALTER TABLE configuration_client ADD COLUMN IF NOT EXISTS revision bigint NOT NULL DEFAULT 1`,
+			`ALTER TABLE configuration_global ADD COLUMN IF NOT EXISTS revision bigint NOT NULL DEFAULT 1`,
+			`CREATE INDEX IF NOT EXISTS configuration_client_revision_idx ON configuration_client (revision)`,
+			`CREATE INDEX IF NOT EXISTS configuration_global_revision_idx ON configuration_global (revision)`,
+			// global is deleted from the table, so deletion is kept here to be returned by sync
+			`CREATE TABLE IF NOT EXISTS configuration_global_tombstone (
+				config_global_id int NOT NULL,
+				revision bigint NOT NULL,
+				CONSTRAINT configuration_global_tombstone_pk PRIMARY KEY (config_global_id)
+			)`,
+		},
+		Down: []string{
+			`DROP TABLE configuration_global_tombstone`,
+			`ALTER TABLE configuration_global DROP COLUMN revision`,
+			`ALTER TABLE configuration_client DROP COLUMN revision`,
+			`DROP TABLE configuration_revision`,
+		},
+	},
+	{
+		Version: 7,
+		Name:    "unique_active_company_subs_id",
+		Up: []string{
+			// client that is not deleted is looked up by company_subs_id, so only one of them can exist.
+			// this fail when duplicate exists, run command repair-clients before the migration
+			`CREATE UNIQUE INDEX IF NOT EXISTS configuration_client_company_subs_id_uq ON configuration_client (company_subs_id) WHERE is_config_deleted = 0`,
+		},
+		Down: []string{
+			`DROP INDEX configuration_client_company_subs_id_uq`,
+		},
+	},
+	{
+		Version: 8,
+		Name:    "index_config_client_uuid",
+		Up: []string{
+			// update and its event read client by uuid
+			`CREATE INDEX IF NOT EXISTS configuration_client_uuid_idx ON configuration_client (config_client_uuid)`,
+		},
+		Down: []string{
+			`DROP INDEX configuration_client_uuid_idx`,
+		},
+	},
+	{
+		Version: 9,
+		Name:    "outbox_dead_letter",
+		Up: []string{
+			// event that cannot be published is kept as dead instead of deleted
+			`ALTER TABLE outbox ADD COLUMN IF NOT EXISTS dead_at timestamptz NULL`,
+			`CREATE INDEX IF NOT EXISTS outbox_event_key_idx ON outbox (event_key, id)`,
+		},
+		Down: []string{
+			`DROP INDEX outbox_event_key_idx`,
+			`ALTER TABLE outbox DROP COLUMN dead_at`,
+		},
+	},
+}
@@ -0,0 +1,141 @@
+package migration
+
+// SQLite is list of schema migration for sqlite database. sqlite has no boolean type, so flag column is integer 0 or 1
+var SQLite = []Migration{
+	{
+		Version: 1,
+		Name:    "create_configuration_tables",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS configuration_client (
+				config_client_id integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+				config_client_uuid varchar(255) NULL,
+				multiple_language_id int NULL,
+				appname varchar(255) NULL,
+				report_title varchar(255) NULL,
+				company_subs_id varchar(255) NULL,
+				is_config_deleted int NOT NULL DEFAULT 0
+			)`,
+			`CREATE TABLE IF NOT EXISTS configuration_global (
+				config_global_id integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+				footertext text NULL,
+				server_smpt text NULL,
+				ssl boolean NULL,
+				port bigint NULL,
+				is_auth boolean NULL,
+				username varchar(255) NULL,
+				"password" varchar(255) NULL,
+				is_active boolean NOT NULL DEFAULT 0
+			)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS configuration_global_is_active_uq ON configuration_global (is_active) WHERE is_active = 1`,
+		},
+		Down: []string{
+			`DROP TABLE configuration_global`,
+			`DROP TABLE configuration_client`,
+		},
+	},
+	{
+		Version: 2,
+		Name:    "create_outbox",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS outbox (
+				id integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+				topic varchar(255) NOT NULL,
+				event_key varchar(255) NOT NULL,
+				payload blob NOT NULL,
+				attempts int NOT NULL DEFAULT 0,
+				next_attempt_at timestamp NOT NULL,
+				last_error text NULL,
+				created_at timestamp NOT NULL
+			)`,
+		},
+		Down: []string{
+			`DROP TABLE outbox`,
+		},
+	},
+	{
+		Version: 3,
+		Name:    "create_webhook",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS webhook (
+				webhook_id integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+				url text NOT NULL,
+				secret varchar(255) NOT NULL,
+				topics text NOT NULL DEFAULT ''
+			)`,
+			`CREATE TABLE IF NOT EXISTS webhook_delivery (
+				delivery_id integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+				webhook_id bigint NOT NULL,
+				event_id varchar(255) NOT NULL,
+				topic varchar(255) NOT NULL,
+				payload text NOT NULL,
+				status varchar(16) NOT NULL,
+				attempts int NOT NULL DEFAULT 0,
+				next_attempt_at bigint NOT NULL,
+				last_error text NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS webhook_delivery_status_idx ON webhook_delivery (status, next_attempt_at)`,
+		},
+		Down: []string{
+			`DROP TABLE webhook_delivery`,
+			`DROP TABLE webhook`,
+		},
+	},
+	{
+		Version: 4,
+		Name:    "add_revision",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS configuration_revision (
+				id int NOT NULL PRIMARY KEY,
+				revision bigint NOT NULL
+			)`,
+			`INSERT INTO configuration_revision (id, revision) VALUES (1, 1)`,
+			`ALTER TABLE configuration_client ADD COLUMN revision bigint NOT NULL DEFAULT 1`,
+			`ALTER TABLE configuration_global ADD COLUMN revision bigint NOT NULL DEFAULT 1`,
+			`CREATE INDEX IF NOT EXISTS configuration_client_revision_idx ON configuration_client (revision)`,
+			`CREATE INDEX IF NOT EXISTS configuration_global_revision_idx ON configuration_global (revision)`,
+			`CREATE TABLE IF NOT EXISTS configuration_global_tombstone (
+				config_global_id int NOT NULL PRIMARY KEY,
+				revision bigint NOT NULL
+			)`,
+		},
+		Down: []string{
+			`DROP TABLE configuration_global_tombstone`,
+			`DROP INDEX configuration_global_revision_idx`,
+			`DROP INDEX configuration_client_revision_idx`,
+			// sqlite bundled by the driver cannot drop column, column revision is left and ignored by older repository
+			`DROP TABLE configuration_revision`,
+		},
+	},
+	{
+		Version: 5,
+		Name:    "unique_active_company_subs_id",
+		Up: []string{
+			`CREATE UNIQUE INDEX IF NOT EXISTS configuration_client_company_subs_id_uq ON configuration_client (company_subs_id) WHERE is_config_deleted = 0`,
+		},
+		Down: []string{
+			`DROP INDEX configuration_client_company_subs_id_uq`,
+		},
+	},
+	{
+		Version: 6,
+		Name:    "index_config_client_uuid",
+		Up: []string{
+			`CREATE INDEX IF NOT EXISTS configuration_client_uuid_idx ON configuration_client (config_client_uuid)`,
+		},
+		Down: []string{
+			`DROP INDEX configuration_client_uuid_idx`,
+		},
+	},
+	{
+		Version: 7,
+		Name:    "outbox_dead_letter",
+		Up: []string{
+			`ALTER TABLE outbox ADD COLUMN dead_at timestamp NULL`,
+			`CREATE INDEX IF NOT EXISTS outbox_event_key_idx ON outbox (event_key, id)`,
+		},
+		Down: []string{
+			// column dead_at is left like column revision of migration 4
+			`DROP INDEX outbox_event_key_idx`,
+		},
+	},
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"github.com/muhammadhidayah/configuration-service/api"
+	"github.com/muhammadhidayah/configuration-service/api/repository"
+)
+
+// changeInvalidator pass notification of postgres to the cache first, then wake the watcher.
+// so watcher that read again after woken up doesn't get stale data from cache
+type changeInvalidator struct {
+	cache    repository.CacheInvalidator
+	notifier api.Notifier
+}
+
+func (i changeInvalidator) InvalidateKey(key string) {
+	if i.cache != nil {
+		i.cache.InvalidateKey(key)
+	}
+
+	i.notifier.Notify(key)
+}
+
+func (i changeInvalidator) InvalidateAll() {
+	if i.cache != nil {
+		i.cache.InvalidateAll()
+	}
+
+	i.notifier.NotifyPrefix("")
+}
@@ -39,6 +39,9 @@ type ConfigurationService interface {
 	AddConfigurationClient(ctx context.Context, in *RequestConfigCient, opts ...client.CallOption) (*ResponseConfigClient, error)
 	UpdateConfigurationClientBySubs(ctx context.Context, in *RequestConfigCient, opts ...client.CallOption) (*ResponseConfigClient, error)
 	DeleteConfigurationClientBySubs(ctx context.Context, in *RequestConfigCient, opts ...client.CallOption) (*ResponseConfigClient, error)
+	// UpsertConfigurationClientBySubs create client of company_subs_id with generated config_client_uuid, or update the existing client.
+	// status.created or status.updated tell which one is done
+	UpsertConfigurationClientBySubs(ctx context.Context, in *RequestConfigCient, opts ...client.CallOption) (*ResponseConfigClient, error)
 	AddConfigurationGlobal(ctx context.Context, in *RequestConfigGlobal, opts ...client.CallOption) (*ResponseConfigGlobal, error)
 	UpdateConfigurationGlobal(ctx context.Context, in *RequestConfigGlobal, opts ...client.CallOption) (*ResponseConfigGlobal, error)
 	DeleteConfiguration(ctx context.Context, in *RequestConfigGlobal, opts ...client.CallOption) (*ResponseConfigGlobal, error)
@@ -46,6 +49,19 @@ type ConfigurationService interface {
 	GetConfigurationGlobalByID(ctx context.Context, in *RequestConfigGlobal, opts ...client.CallOption) (*ResponseConfigGlobal, error)
 	GetConfigurationGlobalActive(ctx context.Context, in *RequestConfigGlobal, opts ...client.CallOption) (*ResponseConfigGlobal, error)
 	SetConfigurationGlobalActive(ctx context.Context, in *RequestConfigGlobal, opts ...client.CallOption) (*ResponseConfigGlobal, error)
+	// Watch send the current value first, then send again every time it changed.
+	// configclient of response is empty when client of company_subs_id not exists or deleted
+	WatchConfigurationClient(ctx context.Context, in *RequestConfigCient, opts ...client.CallOption) (ConfigurationService_WatchConfigurationClientService, error)
+	WatchConfigurationGlobalActive(ctx context.Context, in *RequestConfigGlobal, opts ...client.CallOption) (ConfigurationService_WatchConfigurationGlobalActiveService, error)
+	// Webhook POST event as json to url of subscription, signed with secret of the subscription
+	AddWebhook(ctx context.Context, in *RequestWebhook, opts ...client.CallOption) (*ResponseWebhook, error)
+	DeleteWebhook(ctx context.Context, in *RequestWebhook, opts ...client.CallOption) (*ResponseWebhook, error)
+	GetWebhooks(ctx context.Context, in *RequestWebhook, opts ...client.CallOption) (*ResponseWebhook, error)
+	GetWebhookDeliveries(ctx context.Context, in *RequestWebhook, opts ...client.CallOption) (*ResponseWebhook, error)
+	RedeliverWebhook(ctx context.Context, in *RequestWebhook, opts ...client.CallOption) (*ResponseWebhook, error)
+	// SyncConfiguration return client and global changed after since_revision, and revision to be used by the next sync.
+	// tombstone should be applied before the changed rows
+	SyncConfiguration(ctx context.Context, in *RequestSync, opts ...client.CallOption) (*ResponseSync, error)
 }
 
 type configurationService struct {
@@ -116,6 +132,16 @@ func (c *configurationService) DeleteConfigurationClientBySubs(ctx context.Conte
 	return out, nil
 }
 
+func (c *configurationService) UpsertConfigurationClientBySubs(ctx context.Context, in *RequestConfigCient, opts ...client.CallOption) (*ResponseConfigClient, error) {
+	req := c.c.NewRequest(c.name, "ConfigurationService.UpsertConfigurationClientBySubs", in)
+	out := new(ResponseConfigClient)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *configurationService) AddConfigurationGlobal(ctx context.Context, in *RequestConfigGlobal, opts ...client.CallOption) (*ResponseConfigGlobal, error) {
 	req := c.c.NewRequest(c.name, "ConfigurationService.AddConfigurationGlobal", in)
 	out := new(ResponseConfigGlobal)
@@ -186,6 +212,164 @@ func (c *configurationService) SetConfigurationGlobalActive(ctx context.Context,
 	return out, nil
 }
 
+func (c *configurationService) WatchConfigurationClient(ctx context.Context, in *RequestConfigCient, opts ...client.CallOption) (ConfigurationService_WatchConfigurationClientService, error) {
+	req := c.c.NewRequest(c.name, "ConfigurationService.WatchConfigurationClient", &RequestConfigCient{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &configurationServiceWatchConfigurationClient{stream}, nil
+}
+
+type ConfigurationService_WatchConfigurationClientService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Recv() (*ResponseConfigClient, error)
+}
+
+type configurationServiceWatchConfigurationClient struct {
+	stream client.Stream
+}
+
+func (x *configurationServiceWatchConfigurationClient) Close() error {
+	return x.stream.Close()
+}
+
+func (x *configurationServiceWatchConfigurationClient) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *configurationServiceWatchConfigurationClient) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *configurationServiceWatchConfigurationClient) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *configurationServiceWatchConfigurationClient) Recv() (*ResponseConfigClient, error) {
+	m := new(ResponseConfigClient)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *configurationService) WatchConfigurationGlobalActive(ctx context.Context, in *RequestConfigGlobal, opts ...client.CallOption) (ConfigurationService_WatchConfigurationGlobalActiveService, error) {
+	req := c.c.NewRequest(c.name, "ConfigurationService.WatchConfigurationGlobalActive", &RequestConfigGlobal{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &configurationServiceWatchConfigurationGlobalActive{stream}, nil
+}
+
+type ConfigurationService_WatchConfigurationGlobalActiveService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Recv() (*ResponseConfigGlobal, error)
+}
+
+type configurationServiceWatchConfigurationGlobalActive struct {
+	stream client.Stream
+}
+
+func (x *configurationServiceWatchConfigurationGlobalActive) Close() error {
+	return x.stream.Close()
+}
+
+func (x *configurationServiceWatchConfigurationGlobalActive) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *configurationServiceWatchConfigurationGlobalActive) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *configurationServiceWatchConfigurationGlobalActive) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *configurationServiceWatchConfigurationGlobalActive) Recv() (*ResponseConfigGlobal, error) {
+	m := new(ResponseConfigGlobal)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *configurationService) AddWebhook(ctx context.Context, in *RequestWebhook, opts ...client.CallOption) (*ResponseWebhook, error) {
+	req := c.c.NewRequest(c.name, "ConfigurationService.AddWebhook", in)
+	out := new(ResponseWebhook)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configurationService) DeleteWebhook(ctx context.Context, in *RequestWebhook, opts ...client.CallOption) (*ResponseWebhook, error) {
+	req := c.c.NewRequest(c.name, "ConfigurationService.DeleteWebhook", in)
+	out := new(ResponseWebhook)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configurationService) GetWebhooks(ctx context.Context, in *RequestWebhook, opts ...client.CallOption) (*ResponseWebhook, error) {
+	req := c.c.NewRequest(c.name, "ConfigurationService.GetWebhooks", in)
+	out := new(ResponseWebhook)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configurationService) GetWebhookDeliveries(ctx context.Context, in *RequestWebhook, opts ...client.CallOption) (*ResponseWebhook, error) {
+	req := c.c.NewRequest(c.name, "ConfigurationService.GetWebhookDeliveries", in)
+	out := new(ResponseWebhook)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configurationService) RedeliverWebhook(ctx context.Context, in *RequestWebhook, opts ...client.CallOption) (*ResponseWebhook, error) {
+	req := c.c.NewRequest(c.name, "ConfigurationService.RedeliverWebhook", in)
+	out := new(ResponseWebhook)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configurationService) SyncConfiguration(ctx context.Context, in *RequestSync, opts ...client.CallOption) (*ResponseSync, error) {
+	req := c.c.NewRequest(c.name, "ConfigurationService.SyncConfiguration", in)
+	out := new(ResponseSync)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for ConfigurationService service
 
 type ConfigurationServiceHandler interface {
@@ -194,6 +378,9 @@ type ConfigurationServiceHandler interface {
 	AddConfigurationClient(context.Context, *RequestConfigCient, *ResponseConfigClient) error
 	UpdateConfigurationClientBySubs(context.Context, *RequestConfigCient, *ResponseConfigClient) error
 	DeleteConfigurationClientBySubs(context.Context, *RequestConfigCient, *ResponseConfigClient) error
+	// UpsertConfigurationClientBySubs create client of company_subs_id with generated config_client_uuid, or update the existing client.
+	// status.created or status.updated tell which one is done
+	UpsertConfigurationClientBySubs(context.Context, *RequestConfigCient, *ResponseConfigClient) error
 	AddConfigurationGlobal(context.Context, *RequestConfigGlobal, *ResponseConfigGlobal) error
 	UpdateConfigurationGlobal(context.Context, *RequestConfigGlobal, *ResponseConfigGlobal) error
 	DeleteConfiguration(context.Context, *RequestConfigGlobal, *ResponseConfigGlobal) error
@@ -201,6 +388,19 @@ type ConfigurationServiceHandler interface {
 	GetConfigurationGlobalByID(context.Context, *RequestConfigGlobal, *ResponseConfigGlobal) error
 	GetConfigurationGlobalActive(context.Context, *RequestConfigGlobal, *ResponseConfigGlobal) error
 	SetConfigurationGlobalActive(context.Context, *RequestConfigGlobal, *ResponseConfigGlobal) error
+	// Watch send the current value first, then send again every time it changed.
+	// configclient of response is empty when client of company_subs_id not exists or deleted
+	WatchConfigurationClient(context.Context, *RequestConfigCient, ConfigurationService_WatchConfigurationClientStream) error
+	WatchConfigurationGlobalActive(context.Context, *RequestConfigGlobal, ConfigurationService_WatchConfigurationGlobalActiveStream) error
+	// Webhook POST event as json to url of subscription, signed with secret of the subscription
+	AddWebhook(context.Context, *RequestWebhook, *ResponseWebhook) error
+	DeleteWebhook(context.Context, *RequestWebhook, *ResponseWebhook) error
+	GetWebhooks(context.Context, *RequestWebhook, *ResponseWebhook) error
+	GetWebhookDeliveries(context.Context, *RequestWebhook, *ResponseWebhook) error
+	RedeliverWebhook(context.Context, *RequestWebhook, *ResponseWebhook) error
+	// SyncConfiguration return client and global changed after since_revision, and revision to be used by the next sync.
+	// tombstone should be applied before the changed rows
+	SyncConfiguration(context.Context, *RequestSync, *ResponseSync) error
 }
 
 func RegisterConfigurationServiceHandler(s server.Server, hdlr ConfigurationServiceHandler, opts ...server.HandlerOption) error {
@@ -210,6 +410,7 @@ func RegisterConfigurationServiceHandler(s server.Server, hdlr ConfigurationServ
 		AddConfigurationClient(ctx context.Context, in *RequestConfigCient, out *ResponseConfigClient) error
 		UpdateConfigurationClientBySubs(ctx context.Context, in *RequestConfigCient, out *ResponseConfigClient) error
 		DeleteConfigurationClientBySubs(ctx context.Context, in *RequestConfigCient, out *ResponseConfigClient) error
+		UpsertConfigurationClientBySubs(ctx context.Context, in *RequestConfigCient, out *ResponseConfigClient) error
 		AddConfigurationGlobal(ctx context.Context, in *RequestConfigGlobal, out *ResponseConfigGlobal) error
 		UpdateConfigurationGlobal(ctx context.Context, in *RequestConfigGlobal, out *ResponseConfigGlobal) error
 		DeleteConfiguration(ctx context.Context, in *RequestConfigGlobal, out *ResponseConfigGlobal) error
@@ -217,6 +418,14 @@ func RegisterConfigurationServiceHandler(s server.Server, hdlr ConfigurationServ
 		GetConfigurationGlobalByID(ctx context.Context, in *RequestConfigGlobal, out *ResponseConfigGlobal) error
 		GetConfigurationGlobalActive(ctx context.Context, in *RequestConfigGlobal, out *ResponseConfigGlobal) error
 		SetConfigurationGlobalActive(ctx context.Context, in *RequestConfigGlobal, out *ResponseConfigGlobal) error
+		WatchConfigurationClient(ctx context.Context, stream server.Stream) error
+		WatchConfigurationGlobalActive(ctx context.Context, stream server.Stream) error
+		AddWebhook(ctx context.Context, in *RequestWebhook, out *ResponseWebhook) error
+		DeleteWebhook(ctx context.Context, in *RequestWebhook, out *ResponseWebhook) error
+		GetWebhooks(ctx context.Context, in *RequestWebhook, out *ResponseWebhook) error
+		GetWebhookDeliveries(ctx context.Context, in *RequestWebhook, out *ResponseWebhook) error
+		RedeliverWebhook(ctx context.Context, in *RequestWebhook, out *ResponseWebhook) error
+		SyncConfiguration(ctx context.Context, in *RequestSync, out *ResponseSync) error
 	}
 	type ConfigurationService struct {
 		configurationService
@@ -249,6 +458,10 @@ func (h *configurationServiceHandler) DeleteConfigurationClientBySubs(ctx contex
 	return h.ConfigurationServiceHandler.DeleteConfigurationClientBySubs(ctx, in, out)
 }
 
+func (h *configurationServiceHandler) UpsertConfigurationClientBySubs(ctx context.Context, in *RequestConfigCient, out *ResponseConfigClient) error {
+	return h.ConfigurationServiceHandler.UpsertConfigurationClientBySubs(ctx, in, out)
+}
+
 func (h *configurationServiceHandler) AddConfigurationGlobal(ctx context.Context, in *RequestConfigGlobal, out *ResponseConfigGlobal) error {
 	return h.ConfigurationServiceHandler.AddConfigurationGlobal(ctx, in, out)
 }
@@ -276,3 +489,107 @@ func (h *configurationServiceHandler) GetConfigurationGlobalActive(ctx context.C
 func (h *configurationServiceHandler) SetConfigurationGlobalActive(ctx context.Context, in *RequestConfigGlobal, out *ResponseConfigGlobal) error {
 	return h.ConfigurationServiceHandler.SetConfigurationGlobalActive(ctx, in, out)
 }
+
+func (h *configurationServiceHandler) WatchConfigurationClient(ctx context.Context, stream server.Stream) error {
+	m := new(RequestConfigCient)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.ConfigurationServiceHandler.WatchConfigurationClient(ctx, m, &configurationServiceWatchConfigurationClientStream{stream})
+}
+
+type ConfigurationService_WatchConfigurationClientStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*ResponseConfigClient) error
+}
+
+type configurationServiceWatchConfigurationClientStream struct {
+	stream server.Stream
+}
+
+func (x *configurationServiceWatchConfigurationClientStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *configurationServiceWatchConfigurationClientStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *configurationServiceWatchConfigurationClientStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *configurationServiceWatchConfigurationClientStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *configurationServiceWatchConfigurationClientStream) Send(m *ResponseConfigClient) error {
+	return x.stream.Send(m)
+}
+
+func (h *configurationServiceHandler) WatchConfigurationGlobalActive(ctx context.Context, stream server.Stream) error {
+	m := new(RequestConfigGlobal)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.ConfigurationServiceHandler.WatchConfigurationGlobalActive(ctx, m, &configurationServiceWatchConfigurationGlobalActiveStream{stream})
+}
+
+type ConfigurationService_WatchConfigurationGlobalActiveStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*ResponseConfigGlobal) error
+}
+
+type configurationServiceWatchConfigurationGlobalActiveStream struct {
+	stream server.Stream
+}
+
+func (x *configurationServiceWatchConfigurationGlobalActiveStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *configurationServiceWatchConfigurationGlobalActiveStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *configurationServiceWatchConfigurationGlobalActiveStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *configurationServiceWatchConfigurationGlobalActiveStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *configurationServiceWatchConfigurationGlobalActiveStream) Send(m *ResponseConfigGlobal) error {
+	return x.stream.Send(m)
+}
+
+func (h *configurationServiceHandler) AddWebhook(ctx context.Context, in *RequestWebhook, out *ResponseWebhook) error {
+	return h.ConfigurationServiceHandler.AddWebhook(ctx, in, out)
+}
+
+func (h *configurationServiceHandler) DeleteWebhook(ctx context.Context, in *RequestWebhook, out *ResponseWebhook) error {
+	return h.ConfigurationServiceHandler.DeleteWebhook(ctx, in, out)
+}
+
+func (h *configurationServiceHandler) GetWebhooks(ctx context.Context, in *RequestWebhook, out *ResponseWebhook) error {
+	return h.ConfigurationServiceHandler.GetWebhooks(ctx, in, out)
+}
+
+func (h *configurationServiceHandler) GetWebhookDeliveries(ctx context.Context, in *RequestWebhook, out *ResponseWebhook) error {
+	return h.ConfigurationServiceHandler.GetWebhookDeliveries(ctx, in, out)
+}
+
+func (h *configurationServiceHandler) RedeliverWebhook(ctx context.Context, in *RequestWebhook, out *ResponseWebhook) error {
+	return h.ConfigurationServiceHandler.RedeliverWebhook(ctx, in, out)
+}
+
+func (h *configurationServiceHandler) SyncConfiguration(ctx context.Context, in *RequestSync, out *ResponseSync) error {
+	return h.ConfigurationServiceHandler.SyncConfiguration(ctx, in, out)
+}
@@ -76,13 +76,15 @@ func (m *ConfigurationStatus) GetDeleted() bool {
 }
 
 type ConfigurationClient struct {
-	ConfigClientId       int64    `protobuf:"varint,1,opt,name=config_client_id,json=configClientId,proto3" json:"config_client_id,omitempty"`
-	ConfigClientUuid     string   `protobuf:"bytes,2,opt,name=config_client_uuid,json=configClientUuid,proto3" json:"config_client_uuid,omitempty"`
-	MultipleLanguageId   int32    `protobuf:"varint,3,opt,name=multiple_language_id,json=multipleLanguageId,proto3" json:"multiple_language_id,omitempty"`
-	Appname              string   `protobuf:"bytes,4,opt,name=appname,proto3" json:"appname,omitempty"`
-	ReportTitle          string   `protobuf:"bytes,5,opt,name=report_title,json=reportTitle,proto3" json:"report_title,omitempty"`
-	CompanySubsId        string   `protobuf:"bytes,6,opt,name=company_subs_id,json=companySubsId,proto3" json:"company_subs_id,omitempty"`
-	IsConfigDeleted      int32    `protobuf:"varint,7,opt,name=is_config_deleted,json=isConfigDeleted,proto3" json:"is_config_deleted,omitempty"`
+	ConfigClientId     int64  `protobuf:"varint,1,opt,name=config_client_id,json=configClientId,proto3" json:"config_client_id,omitempty"`
+	ConfigClientUuid   string `protobuf:"bytes,2,opt,name=config_client_uuid,json=configClientUuid,proto3" json:"config_client_uuid,omitempty"`
+	MultipleLanguageId int32  `protobuf:"varint,3,opt,name=multiple_language_id,json=multipleLanguageId,proto3" json:"multiple_language_id,omitempty"`
+	Appname            string `protobuf:"bytes,4,opt,name=appname,proto3" json:"appname,omitempty"`
+	ReportTitle        string `protobuf:"bytes,5,opt,name=report_title,json=reportTitle,proto3" json:"report_title,omitempty"`
+	CompanySubsId      string `protobuf:"bytes,6,opt,name=company_subs_id,json=companySubsId,proto3" json:"company_subs_id,omitempty"`
+	IsConfigDeleted    int32  `protobuf:"varint,7,opt,name=is_config_deleted,json=isConfigDeleted,proto3" json:"is_config_deleted,omitempty"`
+	// revision is the global revision of the last change of the client, the same as revision of SyncConfiguration
+	Revision             int64    `protobuf:"varint,8,opt,name=revision,proto3" json:"revision,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -162,11 +164,26 @@ func (m *ConfigurationClient) GetIsConfigDeleted() int32 {
 	return 0
 }
 
+func (m *ConfigurationClient) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
 type RequestConfigCient struct {
-	Configclient         *ConfigurationClient `protobuf:"bytes,1,opt,name=configclient,proto3" json:"configclient,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Configclient *ConfigurationClient `protobuf:"bytes,1,opt,name=configclient,proto3" json:"configclient,omitempty"`
+	// content_hash of configclient that caller already has, used by GetConfigurationClientBySubs
+	ContentHash string `protobuf:"bytes,2,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	// max_wait_ms make GetConfigurationClientBySubs wait up to max_wait_ms millisecond until configclient
+	// differ from content_hash or revision, then it return not_modified when nothing changed.
+	// it is ignored when both content_hash and revision is empty
+	MaxWaitMs int64 `protobuf:"varint,3,opt,name=max_wait_ms,json=maxWaitMs,proto3" json:"max_wait_ms,omitempty"`
+	// revision of configclient that caller already has, used by GetConfigurationClientBySubs like content_hash
+	Revision             int64    `protobuf:"varint,4,opt,name=revision,proto3" json:"revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *RequestConfigCient) Reset()         { *m = RequestConfigCient{} }
@@ -201,13 +218,42 @@ func (m *RequestConfigCient) GetConfigclient() *ConfigurationClient {
 	return nil
 }
 
+func (m *RequestConfigCient) GetContentHash() string {
+	if m != nil {
+		return m.ContentHash
+	}
+	return ""
+}
+
+func (m *RequestConfigCient) GetMaxWaitMs() int64 {
+	if m != nil {
+		return m.MaxWaitMs
+	}
+	return 0
+}
+
+func (m *RequestConfigCient) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
 type ResponseConfigClient struct {
-	Status               *ConfigurationStatus   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	Configclient         *ConfigurationClient   `protobuf:"bytes,2,opt,name=configclient,proto3" json:"configclient,omitempty"`
-	Configclients        []*ConfigurationClient `protobuf:"bytes,3,rep,name=configclients,proto3" json:"configclients,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+	Status        *ConfigurationStatus   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Configclient  *ConfigurationClient   `protobuf:"bytes,2,opt,name=configclient,proto3" json:"configclient,omitempty"`
+	Configclients []*ConfigurationClient `protobuf:"bytes,3,rep,name=configclients,proto3" json:"configclients,omitempty"`
+	// content_hash is hash of configclient. when it is equal with content_hash of request,
+	// not_modified is true and configclient is empty
+	ContentHash string `protobuf:"bytes,4,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	NotModified bool   `protobuf:"varint,5,opt,name=not_modified,json=notModified,proto3" json:"not_modified,omitempty"`
+	// violations is not empty when configclient of request is not valid, then nothing is stored
+	Violations []*FieldViolation `protobuf:"bytes,6,rep,name=violations,proto3" json:"violations,omitempty"`
+	// revision of configclient, it is sent with not_modified too
+	Revision             int64    `protobuf:"varint,7,opt,name=revision,proto3" json:"revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ResponseConfigClient) Reset()         { *m = ResponseConfigClient{} }
@@ -256,6 +302,34 @@ func (m *ResponseConfigClient) GetConfigclients() []*ConfigurationClient {
 	return nil
 }
 
+func (m *ResponseConfigClient) GetContentHash() string {
+	if m != nil {
+		return m.ContentHash
+	}
+	return ""
+}
+
+func (m *ResponseConfigClient) GetNotModified() bool {
+	if m != nil {
+		return m.NotModified
+	}
+	return false
+}
+
+func (m *ResponseConfigClient) GetViolations() []*FieldViolation {
+	if m != nil {
+		return m.Violations
+	}
+	return nil
+}
+
+func (m *ResponseConfigClient) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
 type ConfigurationGlobal struct {
 	ConfigGlobalId       int32    `protobuf:"varint,1,opt,name=config_global_id,json=configGlobalId,proto3" json:"config_global_id,omitempty"`
 	Footertext           string   `protobuf:"bytes,2,opt,name=footertext,proto3" json:"footertext,omitempty"`
@@ -360,10 +434,14 @@ func (m *ConfigurationGlobal) GetIsActive() bool {
 }
 
 type RequestConfigGlobal struct {
-	Configglobal         *ConfigurationGlobal `protobuf:"bytes,1,opt,name=configglobal,proto3" json:"configglobal,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Configglobal *ConfigurationGlobal `protobuf:"bytes,1,opt,name=configglobal,proto3" json:"configglobal,omitempty"`
+	// content_hash of configglobal that caller already has, used by GetConfigurationGlobalActive
+	ContentHash string `protobuf:"bytes,2,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	// override_lint make SetConfigurationGlobalActive activate configglobal that has error finding
+	OverrideLint         bool     `protobuf:"varint,3,opt,name=override_lint,json=overrideLint,proto3" json:"override_lint,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *RequestConfigGlobal) Reset()         { *m = RequestConfigGlobal{} }
@@ -398,13 +476,37 @@ func (m *RequestConfigGlobal) GetConfigglobal() *ConfigurationGlobal {
 	return nil
 }
 
+func (m *RequestConfigGlobal) GetContentHash() string {
+	if m != nil {
+		return m.ContentHash
+	}
+	return ""
+}
+
+func (m *RequestConfigGlobal) GetOverrideLint() bool {
+	if m != nil {
+		return m.OverrideLint
+	}
+	return false
+}
+
 type ResponseConfigGlobal struct {
-	Configstatus         *ConfigurationStatus   `protobuf:"bytes,1,opt,name=configstatus,proto3" json:"configstatus,omitempty"`
-	Configglobal         *ConfigurationGlobal   `protobuf:"bytes,2,opt,name=configglobal,proto3" json:"configglobal,omitempty"`
-	Configglobals        []*ConfigurationGlobal `protobuf:"bytes,3,rep,name=configglobals,proto3" json:"configglobals,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+	Configstatus  *ConfigurationStatus   `protobuf:"bytes,1,opt,name=configstatus,proto3" json:"configstatus,omitempty"`
+	Configglobal  *ConfigurationGlobal   `protobuf:"bytes,2,opt,name=configglobal,proto3" json:"configglobal,omitempty"`
+	Configglobals []*ConfigurationGlobal `protobuf:"bytes,3,rep,name=configglobals,proto3" json:"configglobals,omitempty"`
+	// content_hash is hash of configglobal. when it is equal with content_hash of request,
+	// not_modified is true and configglobal is empty
+	ContentHash string `protobuf:"bytes,4,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	NotModified bool   `protobuf:"varint,5,opt,name=not_modified,json=notModified,proto3" json:"not_modified,omitempty"`
+	// violations is not empty when configglobal of request is not valid, then nothing is stored
+	Violations []*FieldViolation `protobuf:"bytes,6,rep,name=violations,proto3" json:"violations,omitempty"`
+	// findings of lint rule for configglobal that is added, updated or activated.
+	// configglobal is not activated when one of findings has severity error, unless override_lint is true.
+	// activation refused by lint return error with code 409, and the findings is sent in detail of the error instead
+	Findings             []*LintFinding `protobuf:"bytes,7,rep,name=findings,proto3" json:"findings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *ResponseConfigGlobal) Reset()         { *m = ResponseConfigGlobal{} }
@@ -453,6 +555,787 @@ func (m *ResponseConfigGlobal) GetConfigglobals() []*ConfigurationGlobal {
 	return nil
 }
 
+func (m *ResponseConfigGlobal) GetContentHash() string {
+	if m != nil {
+		return m.ContentHash
+	}
+	return ""
+}
+
+func (m *ResponseConfigGlobal) GetNotModified() bool {
+	if m != nil {
+		return m.NotModified
+	}
+	return false
+}
+
+func (m *ResponseConfigGlobal) GetViolations() []*FieldViolation {
+	if m != nil {
+		return m.Violations
+	}
+	return nil
+}
+
+func (m *ResponseConfigGlobal) GetFindings() []*LintFinding {
+	if m != nil {
+		return m.Findings
+	}
+	return nil
+}
+
+// FieldViolation is one invalid field of request. field is name of field in proto, like company_subs_id
+type FieldViolation struct {
+	Field                string   `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Description          string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FieldViolation) Reset()         { *m = FieldViolation{} }
+func (m *FieldViolation) String() string { return proto.CompactTextString(m) }
+func (*FieldViolation) ProtoMessage()    {}
+func (*FieldViolation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_edff19f92b198a8f, []int{7}
+}
+
+func (m *FieldViolation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FieldViolation.Unmarshal(m, b)
+}
+func (m *FieldViolation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FieldViolation.Marshal(b, m, deterministic)
+}
+func (m *FieldViolation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FieldViolation.Merge(m, src)
+}
+func (m *FieldViolation) XXX_Size() int {
+	return xxx_messageInfo_FieldViolation.Size(m)
+}
+func (m *FieldViolation) XXX_DiscardUnknown() {
+	xxx_messageInfo_FieldViolation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FieldViolation proto.InternalMessageInfo
+
+func (m *FieldViolation) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *FieldViolation) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+// LintFinding is risky setting of configuration global found by rule. severity is info, warning or error
+type LintFinding struct {
+	Rule                 string   `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	Field                string   `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	Severity             string   `protobuf:"bytes,3,opt,name=severity,proto3" json:"severity,omitempty"`
+	Message              string   `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LintFinding) Reset()         { *m = LintFinding{} }
+func (m *LintFinding) String() string { return proto.CompactTextString(m) }
+func (*LintFinding) ProtoMessage()    {}
+func (*LintFinding) Descriptor() ([]byte, []int) {
+	return fileDescriptor_edff19f92b198a8f, []int{8}
+}
+
+func (m *LintFinding) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LintFinding.Unmarshal(m, b)
+}
+func (m *LintFinding) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LintFinding.Marshal(b, m, deterministic)
+}
+func (m *LintFinding) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LintFinding.Merge(m, src)
+}
+func (m *LintFinding) XXX_Size() int {
+	return xxx_messageInfo_LintFinding.Size(m)
+}
+func (m *LintFinding) XXX_DiscardUnknown() {
+	xxx_messageInfo_LintFinding.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LintFinding proto.InternalMessageInfo
+
+func (m *LintFinding) GetRule() string {
+	if m != nil {
+		return m.Rule
+	}
+	return ""
+}
+
+func (m *LintFinding) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *LintFinding) GetSeverity() string {
+	if m != nil {
+		return m.Severity
+	}
+	return ""
+}
+
+func (m *LintFinding) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// ConfigurationClientEvent is published to broker after configuration client changed.
+// before is empty when client created, after is empty when client deleted
+type ConfigurationClientEvent struct {
+	Id    string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Topic string `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	// unix time in millisecond
+	OccurredAt           int64                `protobuf:"varint,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	Before               *ConfigurationClient `protobuf:"bytes,4,opt,name=before,proto3" json:"before,omitempty"`
+	After                *ConfigurationClient `protobuf:"bytes,5,opt,name=after,proto3" json:"after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ConfigurationClientEvent) Reset()         { *m = ConfigurationClientEvent{} }
+func (m *ConfigurationClientEvent) String() string { return proto.CompactTextString(m) }
+func (*ConfigurationClientEvent) ProtoMessage()    {}
+func (*ConfigurationClientEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_edff19f92b198a8f, []int{9}
+}
+
+func (m *ConfigurationClientEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigurationClientEvent.Unmarshal(m, b)
+}
+func (m *ConfigurationClientEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigurationClientEvent.Marshal(b, m, deterministic)
+}
+func (m *ConfigurationClientEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigurationClientEvent.Merge(m, src)
+}
+func (m *ConfigurationClientEvent) XXX_Size() int {
+	return xxx_messageInfo_ConfigurationClientEvent.Size(m)
+}
+func (m *ConfigurationClientEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigurationClientEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigurationClientEvent proto.InternalMessageInfo
+
+func (m *ConfigurationClientEvent) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ConfigurationClientEvent) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *ConfigurationClientEvent) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+func (m *ConfigurationClientEvent) GetBefore() *ConfigurationClient {
+	if m != nil {
+		return m.Before
+	}
+	return nil
+}
+
+func (m *ConfigurationClientEvent) GetAfter() *ConfigurationClient {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
+// ConfigurationGlobalEvent is published to broker after configuration global changed.
+// before of config.global.activated is the configuration that was active before
+type ConfigurationGlobalEvent struct {
+	Id    string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Topic string `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	// unix time in millisecond
+	OccurredAt           int64                `protobuf:"varint,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	Before               *ConfigurationGlobal `protobuf:"bytes,4,opt,name=before,proto3" json:"before,omitempty"`
+	After                *ConfigurationGlobal `protobuf:"bytes,5,opt,name=after,proto3" json:"after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ConfigurationGlobalEvent) Reset()         { *m = ConfigurationGlobalEvent{} }
+func (m *ConfigurationGlobalEvent) String() string { return proto.CompactTextString(m) }
+func (*ConfigurationGlobalEvent) ProtoMessage()    {}
+func (*ConfigurationGlobalEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_edff19f92b198a8f, []int{10}
+}
+
+func (m *ConfigurationGlobalEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigurationGlobalEvent.Unmarshal(m, b)
+}
+func (m *ConfigurationGlobalEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigurationGlobalEvent.Marshal(b, m, deterministic)
+}
+func (m *ConfigurationGlobalEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigurationGlobalEvent.Merge(m, src)
+}
+func (m *ConfigurationGlobalEvent) XXX_Size() int {
+	return xxx_messageInfo_ConfigurationGlobalEvent.Size(m)
+}
+func (m *ConfigurationGlobalEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigurationGlobalEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigurationGlobalEvent proto.InternalMessageInfo
+
+func (m *ConfigurationGlobalEvent) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ConfigurationGlobalEvent) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *ConfigurationGlobalEvent) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+func (m *ConfigurationGlobalEvent) GetBefore() *ConfigurationGlobal {
+	if m != nil {
+		return m.Before
+	}
+	return nil
+}
+
+func (m *ConfigurationGlobalEvent) GetAfter() *ConfigurationGlobal {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
+// Webhook is subscription of event. topics is empty when all of topic is subscribed
+type Webhook struct {
+	WebhookId            int64    `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Url                  string   `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Secret               string   `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	Topics               []string `protobuf:"bytes,4,rep,name=topics,proto3" json:"topics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Webhook) Reset()         { *m = Webhook{} }
+func (m *Webhook) String() string { return proto.CompactTextString(m) }
+func (*Webhook) ProtoMessage()    {}
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return fileDescriptor_edff19f92b198a8f, []int{11}
+}
+
+func (m *Webhook) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Webhook.Unmarshal(m, b)
+}
+func (m *Webhook) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Webhook.Marshal(b, m, deterministic)
+}
+func (m *Webhook) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Webhook.Merge(m, src)
+}
+func (m *Webhook) XXX_Size() int {
+	return xxx_messageInfo_Webhook.Size(m)
+}
+func (m *Webhook) XXX_DiscardUnknown() {
+	xxx_messageInfo_Webhook.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Webhook proto.InternalMessageInfo
+
+func (m *Webhook) GetWebhookId() int64 {
+	if m != nil {
+		return m.WebhookId
+	}
+	return 0
+}
+
+func (m *Webhook) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *Webhook) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+func (m *Webhook) GetTopics() []string {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+// WebhookDelivery is one event to be posted to one webhook. status is pending, delivered or dead
+type WebhookDelivery struct {
+	DeliveryId int64  `protobuf:"varint,1,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	WebhookId  int64  `protobuf:"varint,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	EventId    string `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Topic      string `protobuf:"bytes,4,opt,name=topic,proto3" json:"topic,omitempty"`
+	Payload    string `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	Status     string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Attempts   int32  `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// unix time in millisecond
+	NextAttemptAt        int64    `protobuf:"varint,8,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	LastError            string   `protobuf:"bytes,9,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WebhookDelivery) Reset()         { *m = WebhookDelivery{} }
+func (m *WebhookDelivery) String() string { return proto.CompactTextString(m) }
+func (*WebhookDelivery) ProtoMessage()    {}
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_edff19f92b198a8f, []int{12}
+}
+
+func (m *WebhookDelivery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookDelivery.Unmarshal(m, b)
+}
+func (m *WebhookDelivery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookDelivery.Marshal(b, m, deterministic)
+}
+func (m *WebhookDelivery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookDelivery.Merge(m, src)
+}
+func (m *WebhookDelivery) XXX_Size() int {
+	return xxx_messageInfo_WebhookDelivery.Size(m)
+}
+func (m *WebhookDelivery) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookDelivery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookDelivery proto.InternalMessageInfo
+
+func (m *WebhookDelivery) GetDeliveryId() int64 {
+	if m != nil {
+		return m.DeliveryId
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetWebhookId() int64 {
+	if m != nil {
+		return m.WebhookId
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetEventId() string {
+	if m != nil {
+		return m.EventId
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetPayload() string {
+	if m != nil {
+		return m.Payload
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetAttempts() int32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetNextAttemptAt() int64 {
+	if m != nil {
+		return m.NextAttemptAt
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+type RequestWebhook struct {
+	Webhook              *Webhook `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	DeliveryId           int64    `protobuf:"varint,2,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	Status               string   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestWebhook) Reset()         { *m = RequestWebhook{} }
+func (m *RequestWebhook) String() string { return proto.CompactTextString(m) }
+func (*RequestWebhook) ProtoMessage()    {}
+func (*RequestWebhook) Descriptor() ([]byte, []int) {
+	return fileDescriptor_edff19f92b198a8f, []int{13}
+}
+
+func (m *RequestWebhook) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestWebhook.Unmarshal(m, b)
+}
+func (m *RequestWebhook) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestWebhook.Marshal(b, m, deterministic)
+}
+func (m *RequestWebhook) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestWebhook.Merge(m, src)
+}
+func (m *RequestWebhook) XXX_Size() int {
+	return xxx_messageInfo_RequestWebhook.Size(m)
+}
+func (m *RequestWebhook) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestWebhook.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestWebhook proto.InternalMessageInfo
+
+func (m *RequestWebhook) GetWebhook() *Webhook {
+	if m != nil {
+		return m.Webhook
+	}
+	return nil
+}
+
+func (m *RequestWebhook) GetDeliveryId() int64 {
+	if m != nil {
+		return m.DeliveryId
+	}
+	return 0
+}
+
+func (m *RequestWebhook) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+type ResponseWebhook struct {
+	Status               *ConfigurationStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Webhook              *Webhook             `protobuf:"bytes,2,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	Webhooks             []*Webhook           `protobuf:"bytes,3,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	Deliveries           []*WebhookDelivery   `protobuf:"bytes,4,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ResponseWebhook) Reset()         { *m = ResponseWebhook{} }
+func (m *ResponseWebhook) String() string { return proto.CompactTextString(m) }
+func (*ResponseWebhook) ProtoMessage()    {}
+func (*ResponseWebhook) Descriptor() ([]byte, []int) {
+	return fileDescriptor_edff19f92b198a8f, []int{14}
+}
+
+func (m *ResponseWebhook) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResponseWebhook.Unmarshal(m, b)
+}
+func (m *ResponseWebhook) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResponseWebhook.Marshal(b, m, deterministic)
+}
+func (m *ResponseWebhook) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseWebhook.Merge(m, src)
+}
+func (m *ResponseWebhook) XXX_Size() int {
+	return xxx_messageInfo_ResponseWebhook.Size(m)
+}
+func (m *ResponseWebhook) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseWebhook.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseWebhook proto.InternalMessageInfo
+
+func (m *ResponseWebhook) GetStatus() *ConfigurationStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *ResponseWebhook) GetWebhook() *Webhook {
+	if m != nil {
+		return m.Webhook
+	}
+	return nil
+}
+
+func (m *ResponseWebhook) GetWebhooks() []*Webhook {
+	if m != nil {
+		return m.Webhooks
+	}
+	return nil
+}
+
+func (m *ResponseWebhook) GetDeliveries() []*WebhookDelivery {
+	if m != nil {
+		return m.Deliveries
+	}
+	return nil
+}
+
+type RequestSync struct {
+	SinceRevision        int64    `protobuf:"varint,1,opt,name=since_revision,json=sinceRevision,proto3" json:"since_revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestSync) Reset()         { *m = RequestSync{} }
+func (m *RequestSync) String() string { return proto.CompactTextString(m) }
+func (*RequestSync) ProtoMessage()    {}
+func (*RequestSync) Descriptor() ([]byte, []int) {
+	return fileDescriptor_edff19f92b198a8f, []int{15}
+}
+
+func (m *RequestSync) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestSync.Unmarshal(m, b)
+}
+func (m *RequestSync) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestSync.Marshal(b, m, deterministic)
+}
+func (m *RequestSync) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestSync.Merge(m, src)
+}
+func (m *RequestSync) XXX_Size() int {
+	return xxx_messageInfo_RequestSync.Size(m)
+}
+func (m *RequestSync) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestSync.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestSync proto.InternalMessageInfo
+
+func (m *RequestSync) GetSinceRevision() int64 {
+	if m != nil {
+		return m.SinceRevision
+	}
+	return 0
+}
+
+// ClientTombstone is client of company_subs_id that is deleted at revision
+type ClientTombstone struct {
+	CompanySubsId        string   `protobuf:"bytes,1,opt,name=company_subs_id,json=companySubsId,proto3" json:"company_subs_id,omitempty"`
+	Revision             int64    `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClientTombstone) Reset()         { *m = ClientTombstone{} }
+func (m *ClientTombstone) String() string { return proto.CompactTextString(m) }
+func (*ClientTombstone) ProtoMessage()    {}
+func (*ClientTombstone) Descriptor() ([]byte, []int) {
+	return fileDescriptor_edff19f92b198a8f, []int{16}
+}
+
+func (m *ClientTombstone) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClientTombstone.Unmarshal(m, b)
+}
+func (m *ClientTombstone) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClientTombstone.Marshal(b, m, deterministic)
+}
+func (m *ClientTombstone) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClientTombstone.Merge(m, src)
+}
+func (m *ClientTombstone) XXX_Size() int {
+	return xxx_messageInfo_ClientTombstone.Size(m)
+}
+func (m *ClientTombstone) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClientTombstone.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClientTombstone proto.InternalMessageInfo
+
+func (m *ClientTombstone) GetCompanySubsId() string {
+	if m != nil {
+		return m.CompanySubsId
+	}
+	return ""
+}
+
+func (m *ClientTombstone) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+// GlobalTombstone is global of config_global_id that is deleted at revision
+type GlobalTombstone struct {
+	ConfigGlobalId       int32    `protobuf:"varint,1,opt,name=config_global_id,json=configGlobalId,proto3" json:"config_global_id,omitempty"`
+	Revision             int64    `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GlobalTombstone) Reset()         { *m = GlobalTombstone{} }
+func (m *GlobalTombstone) String() string { return proto.CompactTextString(m) }
+func (*GlobalTombstone) ProtoMessage()    {}
+func (*GlobalTombstone) Descriptor() ([]byte, []int) {
+	return fileDescriptor_edff19f92b198a8f, []int{17}
+}
+
+func (m *GlobalTombstone) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GlobalTombstone.Unmarshal(m, b)
+}
+func (m *GlobalTombstone) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GlobalTombstone.Marshal(b, m, deterministic)
+}
+func (m *GlobalTombstone) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GlobalTombstone.Merge(m, src)
+}
+func (m *GlobalTombstone) XXX_Size() int {
+	return xxx_messageInfo_GlobalTombstone.Size(m)
+}
+func (m *GlobalTombstone) XXX_DiscardUnknown() {
+	xxx_messageInfo_GlobalTombstone.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GlobalTombstone proto.InternalMessageInfo
+
+func (m *GlobalTombstone) GetConfigGlobalId() int32 {
+	if m != nil {
+		return m.ConfigGlobalId
+	}
+	return 0
+}
+
+func (m *GlobalTombstone) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+type ResponseSync struct {
+	Revision             int64                  `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Configclients        []*ConfigurationClient `protobuf:"bytes,2,rep,name=configclients,proto3" json:"configclients,omitempty"`
+	Configglobals        []*ConfigurationGlobal `protobuf:"bytes,3,rep,name=configglobals,proto3" json:"configglobals,omitempty"`
+	DeletedClients       []*ClientTombstone     `protobuf:"bytes,4,rep,name=deleted_clients,json=deletedClients,proto3" json:"deleted_clients,omitempty"`
+	DeletedGlobals       []*GlobalTombstone     `protobuf:"bytes,5,rep,name=deleted_globals,json=deletedGlobals,proto3" json:"deleted_globals,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *ResponseSync) Reset()         { *m = ResponseSync{} }
+func (m *ResponseSync) String() string { return proto.CompactTextString(m) }
+func (*ResponseSync) ProtoMessage()    {}
+func (*ResponseSync) Descriptor() ([]byte, []int) {
+	return fileDescriptor_edff19f92b198a8f, []int{18}
+}
+
+func (m *ResponseSync) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResponseSync.Unmarshal(m, b)
+}
+func (m *ResponseSync) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResponseSync.Marshal(b, m, deterministic)
+}
+func (m *ResponseSync) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseSync.Merge(m, src)
+}
+func (m *ResponseSync) XXX_Size() int {
+	return xxx_messageInfo_ResponseSync.Size(m)
+}
+func (m *ResponseSync) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseSync.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseSync proto.InternalMessageInfo
+
+func (m *ResponseSync) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *ResponseSync) GetConfigclients() []*ConfigurationClient {
+	if m != nil {
+		return m.Configclients
+	}
+	return nil
+}
+
+func (m *ResponseSync) GetConfigglobals() []*ConfigurationGlobal {
+	if m != nil {
+		return m.Configglobals
+	}
+	return nil
+}
+
+func (m *ResponseSync) GetDeletedClients() []*ClientTombstone {
+	if m != nil {
+		return m.DeletedClients
+	}
+	return nil
+}
+
+func (m *ResponseSync) GetDeletedGlobals() []*GlobalTombstone {
+	if m != nil {
+		return m.DeletedGlobals
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ConfigurationStatus)(nil), "configuration.ConfigurationStatus")
 	proto.RegisterType((*ConfigurationClient)(nil), "configuration.ConfigurationClient")
@@ -461,6 +1344,18 @@ func init() {
 	proto.RegisterType((*ConfigurationGlobal)(nil), "configuration.ConfigurationGlobal")
 	proto.RegisterType((*RequestConfigGlobal)(nil), "configuration.RequestConfigGlobal")
 	proto.RegisterType((*ResponseConfigGlobal)(nil), "configuration.ResponseConfigGlobal")
+	proto.RegisterType((*FieldViolation)(nil), "configuration.FieldViolation")
+	proto.RegisterType((*LintFinding)(nil), "configuration.LintFinding")
+	proto.RegisterType((*ConfigurationClientEvent)(nil), "configuration.ConfigurationClientEvent")
+	proto.RegisterType((*ConfigurationGlobalEvent)(nil), "configuration.ConfigurationGlobalEvent")
+	proto.RegisterType((*Webhook)(nil), "configuration.Webhook")
+	proto.RegisterType((*WebhookDelivery)(nil), "configuration.WebhookDelivery")
+	proto.RegisterType((*RequestWebhook)(nil), "configuration.RequestWebhook")
+	proto.RegisterType((*ResponseWebhook)(nil), "configuration.ResponseWebhook")
+	proto.RegisterType((*RequestSync)(nil), "configuration.RequestSync")
+	proto.RegisterType((*ClientTombstone)(nil), "configuration.ClientTombstone")
+	proto.RegisterType((*GlobalTombstone)(nil), "configuration.GlobalTombstone")
+	proto.RegisterType((*ResponseSync)(nil), "configuration.ResponseSync")
 }
 
 func init() {
@@ -468,49 +1363,99 @@ func init() {
 }
 
 var fileDescriptor_edff19f92b198a8f = []byte{
-	// 692 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0xdf, 0x4e, 0xd4, 0x4e,
-	0x14, 0x86, 0x5d, 0xd8, 0x3f, 0x07, 0xf8, 0xc1, 0x6f, 0x20, 0x58, 0xd1, 0x08, 0xd4, 0x44, 0x89,
-	0x31, 0x68, 0xf0, 0xce, 0x3b, 0x58, 0x22, 0x6e, 0xe2, 0xd5, 0xac, 0xdc, 0x69, 0x6a, 0xb7, 0x1d,
-	0x96, 0x09, 0xdd, 0xb6, 0x76, 0x66, 0x50, 0xde, 0xc3, 0x07, 0xf1, 0xa1, 0x7c, 0x00, 0x6f, 0xbc,
-	0x37, 0x3d, 0xa7, 0xc5, 0xed, 0xb6, 0xd1, 0x95, 0x54, 0xee, 0xf6, 0x9c, 0xef, 0x9c, 0xf3, 0x9d,
-	0xf9, 0xfa, 0xcd, 0x00, 0x3c, 0x8e, 0x93, 0x48, 0x47, 0xcf, 0xbc, 0x28, 0x3c, 0x93, 0x23, 0x93,
-	0xb8, 0x5a, 0x46, 0x61, 0x31, 0xda, 0xc7, 0x0a, 0xb6, 0x52, 0x48, 0xda, 0x1e, 0xac, 0xf7, 0x26,
-	0x13, 0x03, 0xed, 0x6a, 0xa3, 0x98, 0x05, 0x6d, 0x2f, 0x11, 0xae, 0x16, 0xbe, 0x35, 0xbf, 0x33,
-	0xbf, 0xd7, 0xe1, 0x79, 0x98, 0x22, 0x26, 0xf6, 0x11, 0x69, 0x10, 0x92, 0x85, 0x29, 0xe2, 0x8b,
-	0x40, 0xa4, 0x48, 0x93, 0x90, 0x2c, 0xb4, 0xbf, 0x36, 0xa6, 0x58, 0x7a, 0x81, 0x14, 0xa1, 0x66,
-	0x7b, 0xb0, 0x46, 0xdb, 0x38, 0x1e, 0x26, 0x1c, 0x49, 0x74, 0x4d, 0xfe, 0x1f, 0xe5, 0xa9, 0xae,
-	0xef, 0xb3, 0xa7, 0xc0, 0x8a, 0x95, 0xc6, 0x48, 0x5a, 0xa0, 0xcb, 0xd7, 0x26, 0x6b, 0x4f, 0x8d,
-	0xf4, 0xd9, 0x73, 0xd8, 0x18, 0x9b, 0x40, 0xcb, 0x38, 0x10, 0x4e, 0xe0, 0x86, 0x23, 0xe3, 0x8e,
-	0x44, 0x3a, 0x3b, 0x5d, 0x6b, 0x91, 0xb3, 0x1c, 0x7b, 0x93, 0x41, 0x7d, 0xdc, 0xdd, 0x8d, 0xe3,
-	0xd0, 0x1d, 0x0b, 0x6b, 0x01, 0x87, 0xe6, 0x21, 0xdb, 0x85, 0xe5, 0x44, 0xc4, 0x51, 0xa2, 0x1d,
-	0x2d, 0x75, 0x20, 0xac, 0x45, 0x84, 0x97, 0x28, 0xf7, 0x36, 0x4d, 0xb1, 0x47, 0xb0, 0xea, 0x45,
-	0xe3, 0xd8, 0x0d, 0xaf, 0x1c, 0x65, 0x86, 0x2a, 0x65, 0x6a, 0x61, 0xd5, 0x4a, 0x96, 0x1e, 0x98,
-	0xa1, 0xea, 0xfb, 0xec, 0x09, 0xfc, 0x2f, 0x95, 0x93, 0x9d, 0x23, 0x97, 0xaa, 0x8d, 0x3b, 0xad,
-	0x4a, 0x45, 0x02, 0x1d, 0x67, 0x92, 0xbd, 0x03, 0xc6, 0xc5, 0x47, 0x23, 0x94, 0xa6, 0x7c, 0x0f,
-	0x05, 0x7b, 0x05, 0xcb, 0xd4, 0x4e, 0x2a, 0xa0, 0x58, 0x4b, 0x07, 0xf6, 0x7e, 0xf1, 0x43, 0x57,
-	0x48, 0xcd, 0x0b, 0x7d, 0xf6, 0xb7, 0x79, 0xd8, 0xe0, 0x42, 0xc5, 0x51, 0xa8, 0x44, 0x6f, 0x42,
-	0x3d, 0xf6, 0x12, 0x5a, 0x0a, 0x1d, 0x30, 0xcb, 0x68, 0xf2, 0x0a, 0xcf, 0x3a, 0x4a, 0xcb, 0x35,
-	0x6e, 0xb6, 0x1c, 0x7b, 0x0d, 0x2b, 0x93, 0xb1, 0xb2, 0x9a, 0x3b, 0xcd, 0x19, 0x07, 0x15, 0x1b,
-	0xed, 0x2f, 0xd3, 0xbe, 0x3b, 0x09, 0xa2, 0xa1, 0x1b, 0x4c, 0xf8, 0x6e, 0x84, 0x89, 0xdc, 0x77,
-	0x8b, 0xb9, 0xef, 0xa8, 0xae, 0xef, 0xb3, 0x07, 0x00, 0x67, 0x51, 0xa4, 0x45, 0xa2, 0xc5, 0x67,
-	0x9d, 0xf9, 0x6d, 0x22, 0xc3, 0xb6, 0x61, 0x49, 0x89, 0xe4, 0x52, 0x24, 0x8e, 0x1a, 0xc7, 0x1a,
-	0x0d, 0xd6, 0xe5, 0x40, 0xa9, 0xc1, 0x38, 0xd6, 0x6c, 0x0d, 0x9a, 0x4a, 0x05, 0x68, 0xaa, 0x0e,
-	0x4f, 0x7f, 0x32, 0x06, 0x0b, 0xa9, 0x75, 0xd0, 0x48, 0x4d, 0x8e, 0xbf, 0xd9, 0x1d, 0x68, 0x4b,
-	0xe5, 0xb8, 0x46, 0x9f, 0xa3, 0x73, 0x3a, 0xbc, 0x25, 0xd5, 0xa1, 0xd1, 0xe7, 0x6c, 0x0b, 0x3a,
-	0x46, 0x89, 0x04, 0x8d, 0xd9, 0xc6, 0xe1, 0xd7, 0x71, 0x8a, 0xc5, 0xae, 0x52, 0x9f, 0xa2, 0xc4,
-	0xb7, 0x3a, 0x84, 0xe5, 0x31, 0xbb, 0x07, 0xdd, 0x74, 0xa0, 0xa7, 0xe5, 0xa5, 0xb0, 0xba, 0x38,
-	0xb2, 0x23, 0xd5, 0x21, 0xc6, 0xf6, 0x7b, 0x58, 0x2f, 0x78, 0x2b, 0x53, 0xe5, 0xfa, 0xfb, 0x91,
-	0x28, 0xb3, 0x38, 0x80, 0x3a, 0x79, 0xa1, 0xcf, 0xfe, 0x5e, 0x32, 0xd7, 0x34, 0xc1, 0x5f, 0x5b,
-	0xac, 0xd0, 0x57, 0x5a, 0xb4, 0x71, 0xb3, 0x45, 0x7f, 0x19, 0x8d, 0xe2, 0x99, 0x8c, 0x96, 0x0d,
-	0x2a, 0x36, 0x1e, 0xfc, 0xe8, 0xc2, 0x46, 0x71, 0x6f, 0x91, 0x5c, 0x4a, 0x4f, 0xb0, 0x21, 0x6c,
-	0x9e, 0x08, 0x5d, 0xf5, 0xf6, 0xed, 0x4e, 0xb1, 0x94, 0x6f, 0xfb, 0xd6, 0xc3, 0x52, 0x49, 0xf9,
-	0xc6, 0xda, 0x73, 0xec, 0x1c, 0xee, 0x57, 0x73, 0x1c, 0xe1, 0xc3, 0x53, 0x23, 0xd3, 0x10, 0x36,
-	0x0f, 0x7d, 0xff, 0xdf, 0x9e, 0xe6, 0x02, 0xb6, 0x4f, 0xf1, 0x0f, 0xca, 0x6d, 0x1c, 0xe8, 0x02,
-	0xb6, 0xe9, 0xc1, 0xbd, 0x0d, 0x32, 0xaf, 0xac, 0x5e, 0x76, 0x31, 0xec, 0xdf, 0x71, 0x50, 0xcd,
-	0x1f, 0x48, 0xa8, 0xc8, 0x9e, 0x63, 0x67, 0x70, 0xb7, 0x42, 0xbe, 0xfa, 0x79, 0x3e, 0xc0, 0x7a,
-	0x85, 0x72, 0x75, 0x32, 0x78, 0xe5, 0xab, 0x53, 0xff, 0x31, 0x46, 0xb0, 0x55, 0x4d, 0x72, 0x74,
-	0xd5, 0x3f, 0xae, 0x93, 0x48, 0x96, 0x2f, 0x29, 0x61, 0xf4, 0x26, 0xd7, 0x4c, 0x35, 0xb8, 0x1d,
-	0xaa, 0x61, 0x0b, 0xff, 0xa7, 0x7c, 0xf1, 0x33, 0x00, 0x00, 0xff, 0xff, 0x7f, 0x17, 0x4c, 0xf5,
-	0x7e, 0x0a, 0x00, 0x00,
+	// 1489 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0xdb, 0x6e, 0x1b, 0x45,
+	0x1f, 0x8f, 0xd7, 0x49, 0x6c, 0xff, 0x9d, 0x53, 0xa7, 0x51, 0x3e, 0xd7, 0xfd, 0x9a, 0xb6, 0x5b,
+	0x7d, 0x1f, 0x15, 0x42, 0x25, 0x0a, 0x08, 0x21, 0x24, 0x90, 0xdc, 0xa4, 0x4d, 0x2d, 0xb5, 0x08,
+	0x6d, 0x1a, 0x72, 0xb9, 0xac, 0x77, 0xc7, 0xf6, 0xd0, 0x3d, 0x31, 0x33, 0xeb, 0x26, 0xe2, 0x9a,
+	0x37, 0x40, 0xe2, 0x09, 0x90, 0xb8, 0xe1, 0x09, 0x78, 0x05, 0x2e, 0xb8, 0xe3, 0x41, 0x78, 0x01,
+	0x34, 0x87, 0xdd, 0xec, 0xa9, 0xc5, 0xa4, 0x6e, 0xe0, 0x6e, 0xff, 0xbf, 0xf9, 0x9f, 0x0f, 0xb3,
+	0xff, 0x5d, 0x78, 0x27, 0xa6, 0x11, 0x8f, 0xde, 0x77, 0xa3, 0x70, 0x4c, 0x26, 0x09, 0x75, 0x38,
+	0x89, 0xc2, 0x22, 0xf5, 0x40, 0x72, 0xa0, 0xf5, 0x02, 0x68, 0xba, 0x70, 0xfd, 0x20, 0x0f, 0x1c,
+	0x73, 0x87, 0x27, 0x0c, 0xf5, 0xa0, 0xe5, 0x52, 0xec, 0x70, 0xec, 0xf5, 0x1a, 0x77, 0x1a, 0xf7,
+	0xdb, 0x56, 0x4a, 0x8a, 0x93, 0x24, 0xf6, 0xe4, 0x89, 0xa1, 0x4e, 0x34, 0x29, 0x4e, 0x3c, 0xec,
+	0x63, 0x71, 0xd2, 0x54, 0x27, 0x9a, 0x34, 0x7f, 0x35, 0x4a, 0x56, 0x0e, 0x7c, 0x82, 0x43, 0x8e,
+	0xee, 0xc3, 0x96, 0xf2, 0xc6, 0x76, 0x25, 0x60, 0x13, 0x65, 0xae, 0x69, 0x6d, 0x28, 0x5c, 0xf1,
+	0x0d, 0x3d, 0xf4, 0x1e, 0xa0, 0x22, 0x67, 0x92, 0x10, 0xe5, 0x40, 0xc7, 0xda, 0xca, 0xf3, 0x9e,
+	0x24, 0xc4, 0x43, 0x7b, 0xb0, 0x1d, 0x24, 0x3e, 0x27, 0xb1, 0x8f, 0x6d, 0xdf, 0x09, 0x27, 0x89,
+	0x33, 0xc1, 0x36, 0x51, 0x6e, 0xad, 0x58, 0x28, 0x3d, 0x7b, 0xaa, 0x8f, 0x86, 0xd2, 0x77, 0x27,
+	0x8e, 0x43, 0x27, 0xc0, 0xbd, 0x65, 0xa9, 0x34, 0x25, 0xd1, 0x5d, 0x58, 0xa3, 0x38, 0x8e, 0x28,
+	0xb7, 0x39, 0xe1, 0x3e, 0xee, 0xad, 0xc8, 0xe3, 0xae, 0xc2, 0x9e, 0x0b, 0x08, 0xfd, 0x1f, 0x36,
+	0xdd, 0x28, 0x88, 0x9d, 0xf0, 0xdc, 0x66, 0xc9, 0x88, 0x09, 0x4b, 0xab, 0x92, 0x6b, 0x5d, 0xc3,
+	0xc7, 0xc9, 0x88, 0x0d, 0x3d, 0xf4, 0x2e, 0x5c, 0x23, 0xcc, 0xd6, 0x71, 0xa4, 0xa9, 0x6a, 0x49,
+	0x9f, 0x36, 0x09, 0x53, 0x09, 0x3a, 0x54, 0x30, 0xea, 0x43, 0x9b, 0xe2, 0x19, 0x61, 0x24, 0x0a,
+	0x7b, 0x6d, 0x99, 0x92, 0x8c, 0x36, 0x7f, 0x69, 0x00, 0xb2, 0xf0, 0x37, 0x09, 0x66, 0x5c, 0x09,
+	0x1d, 0xc8, 0x6c, 0x3e, 0x86, 0x35, 0xa5, 0x5b, 0xa5, 0x48, 0x66, 0xb2, 0xbb, 0x6f, 0x3e, 0x28,
+	0x76, 0x41, 0x4d, 0x1d, 0xac, 0x82, 0x9c, 0x88, 0xd8, 0x8d, 0x42, 0x2e, 0xb2, 0x3c, 0x75, 0xd8,
+	0x54, 0x67, 0xb9, 0xab, 0xb1, 0x27, 0x0e, 0x9b, 0xa2, 0x5d, 0xe8, 0x06, 0xce, 0x99, 0xfd, 0xd2,
+	0x21, 0xdc, 0x0e, 0x98, 0xcc, 0x6b, 0xd3, 0xea, 0x04, 0xce, 0xd9, 0xa9, 0x43, 0xf8, 0x33, 0x56,
+	0xf0, 0x7e, 0xb9, 0xe4, 0xfd, 0x77, 0x4d, 0xd8, 0xb6, 0x30, 0x8b, 0xa3, 0x90, 0xe1, 0x83, 0x5c,
+	0xe5, 0xd0, 0x27, 0xb0, 0xca, 0x64, 0xf7, 0xcd, 0xe3, 0xb9, 0xea, 0x53, 0x4b, 0x4b, 0x54, 0x62,
+	0x37, 0x2e, 0x19, 0xfb, 0x13, 0x58, 0xcf, 0xd3, 0x22, 0xb4, 0xe6, 0x9c, 0x8a, 0x8a, 0x82, 0x95,
+	0x2c, 0x2e, 0x57, 0xb3, 0x78, 0x17, 0xd6, 0xc2, 0x88, 0xdb, 0x41, 0xe4, 0x91, 0x31, 0xc1, 0x9e,
+	0x6c, 0xad, 0xb6, 0xd5, 0x0d, 0x23, 0xfe, 0x4c, 0x43, 0xe8, 0x53, 0x80, 0x19, 0x89, 0x7c, 0x69,
+	0x87, 0xf5, 0x56, 0xa5, 0x33, 0xb7, 0x4a, 0xce, 0x3c, 0x26, 0xd8, 0xf7, 0xbe, 0x4c, 0xb9, 0xac,
+	0x9c, 0x40, 0xa1, 0x0e, 0xad, 0x52, 0x1d, 0xbe, 0x2f, 0x0f, 0xe5, 0x91, 0x1f, 0x8d, 0x1c, 0x3f,
+	0x37, 0x94, 0x13, 0x09, 0xa4, 0x43, 0xb9, 0x92, 0x0e, 0xa5, 0xe2, 0x1b, 0x7a, 0x68, 0x17, 0x60,
+	0x1c, 0x45, 0x1c, 0x53, 0x8e, 0xcf, 0xb8, 0x6e, 0x93, 0x1c, 0x82, 0x6e, 0x43, 0x97, 0x61, 0x3a,
+	0xc3, 0xd4, 0x66, 0x41, 0xcc, 0x65, 0x97, 0x74, 0x2c, 0x50, 0xd0, 0x71, 0x10, 0x73, 0xb4, 0x05,
+	0x4d, 0xc6, 0x7c, 0x99, 0x9a, 0xb6, 0x25, 0x1e, 0x11, 0x82, 0x65, 0x31, 0x57, 0x32, 0x15, 0x4d,
+	0x4b, 0x3e, 0xa3, 0xff, 0x40, 0x8b, 0x30, 0xdb, 0x49, 0xf8, 0x54, 0x8e, 0x55, 0xdb, 0x5a, 0x25,
+	0x6c, 0x90, 0xf0, 0xa9, 0x88, 0x2e, 0x61, 0x98, 0xca, 0xa9, 0x6d, 0x49, 0xe5, 0x19, 0x2d, 0xce,
+	0x62, 0x87, 0xb1, 0x97, 0x11, 0xf5, 0xe4, 0xfc, 0x74, 0xac, 0x8c, 0x46, 0x37, 0xa1, 0x23, 0x14,
+	0xba, 0x9c, 0xcc, 0x70, 0xaf, 0x23, 0x55, 0xb6, 0x09, 0x1b, 0x48, 0xda, 0xfc, 0xb1, 0x01, 0xd7,
+	0x0b, 0xc3, 0xa5, 0xd3, 0x92, 0x75, 0x98, 0xca, 0xca, 0x3c, 0x3d, 0xaa, 0x24, 0xad, 0x82, 0xdc,
+	0x3c, 0xd3, 0x75, 0x0f, 0xd6, 0xa3, 0x19, 0xa6, 0x94, 0x78, 0xd8, 0xf6, 0x49, 0xc8, 0xf5, 0x75,
+	0xba, 0x96, 0x82, 0x4f, 0x49, 0xc8, 0xcd, 0x9f, 0x2b, 0x63, 0x54, 0x76, 0xf4, 0x6f, 0x0f, 0x53,
+	0x41, 0xae, 0x12, 0xb0, 0x71, 0xc9, 0x80, 0xb3, 0x91, 0x52, 0xf4, 0x5c, 0x23, 0xa5, 0x15, 0x15,
+	0x05, 0xff, 0x1d, 0x23, 0xf5, 0x11, 0xb4, 0xc7, 0x24, 0xf4, 0x48, 0x38, 0x61, 0xbd, 0x96, 0x14,
+	0xee, 0x97, 0x84, 0x45, 0x79, 0x1e, 0x2b, 0x16, 0x2b, 0xe3, 0x35, 0x9f, 0xc0, 0x46, 0x51, 0x2b,
+	0xda, 0x86, 0x95, 0xb1, 0x40, 0x64, 0x85, 0x3a, 0x96, 0x22, 0xd0, 0x1d, 0xe8, 0x7a, 0x98, 0xb9,
+	0x94, 0xc4, 0x82, 0x29, 0x6d, 0x8f, 0x1c, 0x64, 0x06, 0xd0, 0xcd, 0x99, 0x10, 0x23, 0x43, 0x13,
+	0x1f, 0x6b, 0x2d, 0xf2, 0xf9, 0x42, 0xb5, 0x91, 0x57, 0xdd, 0x87, 0x36, 0xc3, 0x33, 0x4c, 0x09,
+	0x3f, 0xd7, 0xc3, 0x98, 0xd1, 0xe2, 0x05, 0x18, 0x60, 0xc6, 0x9c, 0x49, 0xf6, 0x02, 0xd4, 0xa4,
+	0xf9, 0x7b, 0x03, 0x7a, 0x35, 0xf7, 0xdd, 0xa3, 0x99, 0xb8, 0x2f, 0x37, 0xc0, 0x20, 0x69, 0x00,
+	0x06, 0xf1, 0x84, 0x61, 0x1e, 0xc5, 0xc4, 0x4d, 0x0d, 0x4b, 0x42, 0x5c, 0x04, 0x91, 0xeb, 0x26,
+	0x94, 0x62, 0xcf, 0x76, 0xb8, 0x7e, 0x5d, 0x40, 0x0a, 0x0d, 0xe4, 0xd5, 0x3f, 0xc2, 0xe3, 0x88,
+	0x2a, 0xe3, 0xf3, 0xdd, 0xb7, 0x5a, 0x02, 0x7d, 0x0c, 0x2b, 0xce, 0x98, 0x63, 0xda, 0x5b, 0x99,
+	0x5b, 0x54, 0x09, 0x54, 0x23, 0x53, 0x6d, 0xf7, 0xcf, 0x45, 0xa6, 0xdb, 0xfe, 0x32, 0x91, 0x69,
+	0x51, 0x1d, 0xd9, 0xd7, 0xd0, 0x3a, 0xc5, 0xa3, 0x69, 0x14, 0xbd, 0x40, 0xb7, 0x00, 0x5e, 0xaa,
+	0xc7, 0x8b, 0xed, 0xaa, 0xa3, 0x91, 0xa1, 0x27, 0xae, 0xe0, 0x84, 0xfa, 0x3a, 0x28, 0xf1, 0x88,
+	0x76, 0x60, 0x95, 0x61, 0x97, 0xe2, 0xf4, 0xc2, 0xd6, 0x94, 0xc0, 0x65, 0xcc, 0xac, 0xb7, 0x7c,
+	0xa7, 0x29, 0x70, 0x45, 0x99, 0x3f, 0x18, 0xb0, 0xa9, 0x8d, 0x1d, 0x62, 0x9f, 0xcc, 0x30, 0x3d,
+	0x17, 0x69, 0xf1, 0xf4, 0xf3, 0x85, 0x55, 0x48, 0xa1, 0xa1, 0x57, 0xf2, 0xca, 0x28, 0x7b, 0x75,
+	0x03, 0xda, 0x78, 0xa6, 0x17, 0x42, 0xe5, 0x45, 0x4b, 0xd2, 0xc3, 0x5c, 0x1d, 0x96, 0xf3, 0x75,
+	0xe8, 0x41, 0x2b, 0x76, 0xce, 0xfd, 0xc8, 0xf1, 0xf4, 0x82, 0x96, 0x92, 0x32, 0x1c, 0x75, 0x11,
+	0xae, 0xea, 0x70, 0x24, 0x25, 0x86, 0xc1, 0xe1, 0x1c, 0x07, 0x31, 0x67, 0x7a, 0x07, 0xcb, 0x68,
+	0xb1, 0xd0, 0x85, 0xf8, 0x8c, 0xdb, 0x1a, 0x10, 0x95, 0x55, 0x3b, 0xd8, 0xba, 0x80, 0x07, 0x0a,
+	0x1d, 0x70, 0x11, 0x85, 0xef, 0x30, 0x6e, 0x63, 0x4a, 0x23, 0x2a, 0xdf, 0x24, 0x1d, 0xab, 0x23,
+	0x90, 0x47, 0x02, 0x30, 0xbf, 0x85, 0x0d, 0xfd, 0x26, 0x49, 0x8b, 0xb1, 0x07, 0x2d, 0x1d, 0xa4,
+	0xbe, 0x96, 0x77, 0x4a, 0x35, 0xd5, 0x8c, 0x56, 0xca, 0x56, 0xce, 0xa4, 0x51, 0xc9, 0xe4, 0x45,
+	0x7c, 0xcd, 0x7c, 0x7c, 0xe6, 0x1f, 0x0d, 0xd8, 0x4c, 0xdf, 0x0f, 0xa9, 0xf9, 0x37, 0xd9, 0xb0,
+	0x72, 0xae, 0x1b, 0xf3, 0xb9, 0xbe, 0x0f, 0x6d, 0xfd, 0x98, 0xde, 0xf9, 0xaf, 0x12, 0xc9, 0xf8,
+	0xd0, 0x67, 0x90, 0xc6, 0x46, 0xb0, 0x6a, 0xb4, 0xee, 0xfe, 0x6e, 0xbd, 0x54, 0xda, 0x6c, 0x56,
+	0x4e, 0xc2, 0xfc, 0x10, 0xba, 0x3a, 0xe5, 0xc7, 0xe7, 0xa1, 0x8b, 0xfe, 0x07, 0x1b, 0x8c, 0x84,
+	0x2e, 0xb6, 0xb3, 0x2d, 0x48, 0xb5, 0xe2, 0xba, 0x44, 0x2d, 0x0d, 0x9a, 0x27, 0xb0, 0xa9, 0x6e,
+	0x86, 0xe7, 0x51, 0x30, 0x62, 0x3c, 0x0a, 0x6b, 0x77, 0xfa, 0x46, 0xdd, 0x4e, 0x9f, 0xdf, 0xb0,
+	0x8c, 0xd2, 0x86, 0x75, 0x0a, 0x9b, 0x6a, 0x2c, 0x2f, 0xd4, 0xce, 0xbf, 0x5c, 0xbd, 0x4e, 0xf1,
+	0x6f, 0x06, 0xac, 0xa5, 0xb5, 0x95, 0x71, 0xe6, 0x99, 0x1b, 0x45, 0xe6, 0xea, 0x4a, 0x6b, 0x5c,
+	0x76, 0xa5, 0x5d, 0xdc, 0x9b, 0xfc, 0x08, 0x36, 0xf5, 0xf7, 0x8f, 0x9d, 0x7a, 0x55, 0x5f, 0xeb,
+	0x52, 0x59, 0xac, 0x0d, 0x2d, 0x76, 0xa0, 0x5d, 0xca, 0x29, 0x4a, 0x9d, 0x5a, 0xa9, 0x55, 0x54,
+	0x2a, 0x44, 0xa6, 0x48, 0xe1, 0x6c, 0xff, 0xa7, 0x2d, 0xd8, 0x2e, 0xb6, 0x3f, 0xa6, 0x33, 0xe2,
+	0x62, 0x34, 0x82, 0x9d, 0x23, 0xcc, 0xeb, 0xbe, 0x5e, 0xef, 0x96, 0x4c, 0x54, 0x3f, 0xc9, 0xfa,
+	0xf7, 0x2a, 0x2c, 0xd5, 0xef, 0x1e, 0x73, 0x09, 0x4d, 0xe1, 0xbf, 0xf5, 0x36, 0x1e, 0xca, 0x36,
+	0x5b, 0xa0, 0xa5, 0x11, 0xec, 0x0c, 0x3c, 0xef, 0xed, 0x46, 0xf3, 0x02, 0x6e, 0x9f, 0xc8, 0x5f,
+	0x02, 0x57, 0x11, 0xd0, 0x0b, 0xb8, 0xad, 0x3e, 0x99, 0xaf, 0xc8, 0xd8, 0x49, 0xcc, 0x30, 0xbd,
+	0x92, 0x52, 0xb9, 0xd5, 0x52, 0xe9, 0x0d, 0xdf, 0x7c, 0x9d, 0x0d, 0xc5, 0xf3, 0x17, 0x46, 0x14,
+	0x93, 0xb9, 0x84, 0xc6, 0x70, 0xa3, 0xa6, 0x56, 0x8b, 0xb7, 0xf3, 0x15, 0x5c, 0xaf, 0x29, 0xd3,
+	0x22, 0x2d, 0xb8, 0xd5, 0x39, 0x5d, 0x7c, 0x18, 0x13, 0xe8, 0xd7, 0x1b, 0x79, 0x78, 0x3e, 0x3c,
+	0x5c, 0xa4, 0x21, 0x52, 0xbd, 0x11, 0xd4, 0x99, 0xfa, 0x4a, 0x5d, 0xb0, 0xa9, 0xe3, 0x2b, 0x32,
+	0x35, 0x86, 0xde, 0xa9, 0xc3, 0xdd, 0xe9, 0x5b, 0xbd, 0x7f, 0xf6, 0x1a, 0x28, 0x80, 0xdd, 0xaa,
+	0x9d, 0xb7, 0x14, 0xd4, 0x5e, 0x03, 0x3d, 0x03, 0x18, 0x78, 0x5e, 0xb6, 0x70, 0xd7, 0xab, 0xd6,
+	0xc7, 0xfd, 0xdd, 0x57, 0x68, 0xd5, 0xe7, 0xe6, 0x12, 0xfa, 0x02, 0xd6, 0xd5, 0xac, 0x2c, 0x4c,
+	0xe3, 0xe7, 0xd0, 0x3d, 0xc2, 0x29, 0x3f, 0x7b, 0x73, 0x7d, 0xa7, 0xb0, 0x7d, 0xa1, 0xef, 0x30,
+	0xdb, 0xbe, 0xde, 0x5c, 0xf1, 0x31, 0x6c, 0x59, 0x58, 0xaf, 0x73, 0x0b, 0x8c, 0xfe, 0x9a, 0x58,
+	0x92, 0x8a, 0x37, 0x4f, 0xbf, 0x5e, 0xab, 0x60, 0xec, 0xdf, 0x7c, 0x85, 0x4a, 0x71, 0x68, 0x2e,
+	0x8d, 0x56, 0xe5, 0x8f, 0xf4, 0x0f, 0xfe, 0x1c, 0x00, 0x1a, 0xcf, 0x03, 0x51, 0x73, 0x17, 0x00,
+	0x00,
 }
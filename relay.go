@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+	"github.com/muhammadhidayah/configuration-service/api/outbox"
+	"github.com/muhammadhidayah/configuration-service/api/webhook"
+)
+
+// startRelay run relay of outbox in background, it is woken up by notifier every time event is saved by this instance.
+// the returned function stop the relay and wait until it stopped
+func startRelay(store api.Outbox, publisher api.EventPublisher, notifier api.Notifier, interval time.Duration, maxAttempts int) func() {
+	wake, stopWake := notifier.Subscribe(api.OutboxKey)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	relay := outbox.NewRelay(store, publisher, interval, outbox.WithMaxAttempts(maxAttempts))
+	go func() {
+		defer close(done)
+		relay.Run(ctx, wake)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+		stopWake()
+	}
+}
+
+// startDispatcher run dispatcher of webhook in background, it is woken up when delivery is saved or redelivered by this instance.
+// the returned function stop the dispatcher and wait until it stopped
+func startDispatcher(repo api.Repository, notifier api.Notifier, interval time.Duration, maxAttempts int) func() {
+	wake, stopWake := notifier.Subscribe(api.WebhookKey)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	dispatcher := webhook.NewDispatcher(repo, webhook.WithMaxAttempts(maxAttempts))
+	go func() {
+		defer close(done)
+		dispatcher.Run(ctx, interval, wake)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+		stopWake()
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/muhammadhidayah/configuration-service/api"
+	pb "github.com/muhammadhidayah/configuration-service/proto/configuration"
+)
+
+const repairUsage = "usage: configuration-service repair-clients list|apply"
+
+// runRepairClients handle command `configuration-service repair-clients list|apply`. list print client that has the same
+// company_subs_id as older client, apply soft delete them. duplicate must be repaired before migration unique_active_company_subs_id
+func runRepairClients(repo api.Repository, args []string) error {
+	if len(args) != 1 {
+		return errors.New(repairUsage)
+	}
+
+	ctx := context.Background()
+
+	var duplicates []*pb.ConfigurationClient
+	var err error
+	switch args[0] {
+	case "list":
+		duplicates, err = repo.GetDuplicateConfigurationClient(ctx)
+	case "apply":
+		duplicates, err = repo.RepairDuplicateConfigurationClient(ctx)
+	default:
+		return errors.New(repairUsage)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if len(duplicates) == 0 {
+		fmt.Println("no duplicate client")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "CONFIG CLIENT ID\tUUID\tCOMPANY SUBS ID\tAPPNAME\tDELETED")
+	for _, cc := range duplicates {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%t\n", cc.ConfigClientId, cc.ConfigClientUuid, cc.CompanySubsId, cc.Appname, cc.IsConfigDeleted != 0)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/micro/cli"
+	"github.com/muhammadhidayah/configuration-service/api"
+	"github.com/muhammadhidayah/configuration-service/api/repository"
+	"github.com/muhammadhidayah/configuration-service/migration"
+)
+
+// getEnv return value of environment variable key, or fallback when it is empty
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	return fallback
+}
+
+// openDatabase open database used by store, and return migrator of the database
+func openDatabase(store, sqlitePath, mysqlDSN string) (*sql.DB, *migration.Migrator, error) {
+	switch store {
+	case "postgres":
+		db, err := createConnection()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return db, migration.NewMigrator(db, migration.PostgresDialect, migration.Postgres), nil
+	case "sqlite":
+		db, err := sql.Open("sqlite3", sqlitePath)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// sqlite allow only one writer, so one connection avoid error database is locked
+		db.SetMaxOpenConns(1)
+
+		return db, migration.NewMigrator(db, migration.SQLiteDialect, migration.SQLite), nil
+	case "mysql":
+		db, err := sql.Open("mysql", mysqlDSN)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return db, migration.NewMigrator(db, migration.MySQLDialect, migration.MySQL), nil
+	default:
+		return nil, nil, fmt.Errorf("unknown store %q, must be one of postgres, mysql, sqlite or memory", store)
+	}
+}
+
+// createRepository create api.Repository of backend chosen by flag store. the returned function release the backend when service stopped
+func createRepository(c *cli.Context) (api.Repository, func() error, error) {
+	store := c.String("store")
+
+	// data only live as long as the process, used for local development and tests
+	if store == "memory" {
+		return repository.NewMemoryConfiguration(), func() error { return nil }, nil
+	}
+
+	db, migrator, err := openDatabase(store, c.String("sqlite_path"), c.String("mysql_dsn"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("Could not connect to DB: %v", err)
+	}
+
+	if c.Bool("auto_migrate") {
+		if err := autoMigrate(migrator); err != nil {
+			db.Close()
+			return nil, nil, fmt.Errorf("Could not migrate DB: %v", err)
+		}
+	}
+
+	if store != "postgres" {
+		repo := sqlRepository(store, db)
+		return repo, closeRepository(repo, db), nil
+	}
+
+	// refuse to start when database schema drift from columns used by repository
+	if err := repository.VerifyPgSchema(context.Background(), db); err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("Could not verify DB schema: %v", err)
+	}
+
+	replicaDSNs := c.StringSlice("pg_replica_dsn")
+	if len(replicaDSNs) == 0 {
+		repo := repository.NewPgConfiguration(db)
+		return repo, closeRepository(repo, db), nil
+	}
+
+	replicas := make([]*sql.DB, 0, len(replicaDSNs))
+	for _, dsn := range replicaDSNs {
+		replica, err := sql.Open("postgres", dsn)
+		if err != nil {
+			db.Close()
+			return nil, nil, fmt.Errorf("Could not connect to replica DB: %v", err)
+		}
+
+		replicas = append(replicas, replica)
+	}
+
+	repo := repository.NewPgConfigurationWithReplicas(db, replicas...)
+	stopHealthCheck := checkHealthEvery(repo.(repository.HealthChecker), 15*time.Second)
+	closePrimary := closeRepository(repo, db)
+
+	return repo, func() error {
+		stopHealthCheck()
+
+		for _, replica := range replicas {
+			replica.Close()
+		}
+
+		return closePrimary()
+	}, nil
+}
+
+// sqlRepository return repository of store on db, without replica
+func sqlRepository(store string, db *sql.DB) api.Repository {
+	switch store {
+	case "sqlite":
+		return repository.NewSqliteConfiguration(db)
+	case "mysql":
+		return repository.NewMysqlConfiguration(db)
+	}
+
+	return repository.NewPgConfiguration(db)
+}
+
+// closeRepository return function that close prepared statement of repo, then close db
+func closeRepository(repo api.Repository, db *sql.DB) func() error {
+	return func() error {
+		if closer, ok := repo.(io.Closer); ok {
+			closer.Close()
+		}
+
+		return db.Close()
+	}
+}